@@ -2,10 +2,11 @@ package account
 
 import (
 	"github.com/ans-group/cli/internal/pkg/factory"
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 )
 
-func AccountRootCmd(f factory.ClientFactory) *cobra.Command {
+func AccountRootCmd(f factory.ClientFactory, fs afero.Fs) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "account",
 		Short: "Commands relating to Account service",
@@ -16,7 +17,7 @@ func AccountRootCmd(f factory.ClientFactory) *cobra.Command {
 	cmd.AddCommand(accountContactRootCmd(f))
 	cmd.AddCommand(accountDetailsRootCmd(f))
 	cmd.AddCommand(accountCreditRootCmd(f))
-	cmd.AddCommand(accountApplicationRootCmd(f))
+	cmd.AddCommand(accountApplicationRootCmd(f, fs))
 
 	return cmd
 }
@@ -9,10 +9,11 @@ import (
 	"github.com/ans-group/cli/internal/pkg/helper"
 	"github.com/ans-group/cli/internal/pkg/output"
 	"github.com/ans-group/sdk-go/pkg/service/account"
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 )
 
-func accountApplicationRootCmd(f factory.ClientFactory) *cobra.Command {
+func accountApplicationRootCmd(f factory.ClientFactory, fs afero.Fs) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "application",
 		Short: "sub-commands relating to applications",
@@ -24,6 +25,7 @@ func accountApplicationRootCmd(f factory.ClientFactory) *cobra.Command {
 	cmd.AddCommand(accountApplicationCreateCmd(f))
 	cmd.AddCommand(accountApplicationUpdateCmd(f))
 	cmd.AddCommand(accountApplicationDeleteCmd(f))
+	cmd.AddCommand(accountApplicationApplyCmd(f, fs))
 	cmd.AddCommand(accountApplicationRestrictionsRootCmd(f))
 
 	return cmd
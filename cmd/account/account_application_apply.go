@@ -0,0 +1,452 @@
+package account
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ans-group/cli/internal/pkg/factory"
+	"github.com/ans-group/cli/internal/pkg/helper"
+	"github.com/ans-group/cli/internal/pkg/output"
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/ans-group/sdk-go/pkg/service/account"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// ApplicationPolicy represents a policy file declaring API applications
+type ApplicationPolicy struct {
+	Applications []ApplicationPolicyApplication `yaml:"applications"`
+}
+
+// ApplicationPolicyApplication represents an API application declared in a policy file
+type ApplicationPolicyApplication struct {
+	Name        string                   `yaml:"name"`
+	Description string                   `yaml:"description"`
+	Scopes      []ApplicationPolicyScope `yaml:"scopes"`
+	AllowIPs    []string                 `yaml:"allow_ips"`
+	DenyIPs     []string                 `yaml:"deny_ips"`
+}
+
+// ApplicationPolicyScope represents a service scope declared in a policy file
+type ApplicationPolicyScope struct {
+	Service string   `yaml:"service"`
+	Roles   []string `yaml:"roles"`
+}
+
+func (a ApplicationPolicyApplication) scopes() []account.ApplicationServiceScope {
+	scopes := []account.ApplicationServiceScope{}
+	for _, scope := range a.Scopes {
+		scopes = append(scopes, account.ApplicationServiceScope{Service: scope.Service, Roles: scope.Roles})
+	}
+
+	return scopes
+}
+
+func (a ApplicationPolicyApplication) restriction() account.ApplicationRestriction {
+	if len(a.AllowIPs) > 0 {
+		return account.ApplicationRestriction{IPRestrictionType: "allowlist", IPRanges: a.AllowIPs}
+	}
+	if len(a.DenyIPs) > 0 {
+		return account.ApplicationRestriction{IPRestrictionType: "denylist", IPRanges: a.DenyIPs}
+	}
+
+	return account.ApplicationRestriction{}
+}
+
+func accountApplicationApplyCmd(f factory.ClientFactory, fs afero.Fs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Applies applications from a policy file",
+		Long: "This command reconciles applications against a YAML policy file declaring applications by name, along with " +
+			"their description, service scopes and IP allow/deny list. Applications missing from the account are created, " +
+			"and existing applications are updated to match. Keys for new and rotated applications are output, and are only " +
+			"shown once, including where later changes to the new application fail.\n\n" +
+			"Keys are rotated with --rotate, which replaces the named application with a new application with the same " +
+			"configuration, then removes the original application, expiring its key. Applications not declared in the policy " +
+			"file are removed with --prune. Use --dry-run to output the changes without applying them.\n\n" +
+			"Example policy file:\n\n" +
+			"applications:\n" +
+			"  - name: monitoring\n" +
+			"    description: Read-only monitoring\n" +
+			"    scopes:\n" +
+			"      - service: ecloud\n" +
+			"        roles: [read]\n" +
+			"    allow_ips: [203.0.113.0/24]",
+		Example: "ans account application apply -f apps.yaml --dry-run\n" +
+			"ans account application apply -f apps.yaml\n" +
+			"ans account application apply -f apps.yaml --rotate monitoring --prune",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := f.NewClient()
+			if err != nil {
+				return err
+			}
+
+			return accountApplicationApply(c.AccountService(), cmd, fs, args)
+		},
+	}
+
+	cmd.Flags().StringP("file", "f", "", "Path to YAML policy file declaring applications")
+	_ = cmd.MarkFlagRequired("file")
+	cmd.Flags().Bool("dry-run", false, "Specifies that changes should be output without being applied")
+	cmd.Flags().Bool("prune", false, "Specifies that applications not declared in the policy file should be removed")
+	cmd.Flags().StringSlice("rotate", []string{}, "Specifies name of application to rotate key for. Can be repeated")
+
+	return cmd
+}
+
+// applicationApplyChange represents a planned change to an application
+type applicationApplyChange struct {
+	Action   string
+	Existing account.Application
+	Desired  ApplicationPolicyApplication
+	Changes  []string
+
+	updateDescription  bool
+	updateScopes       bool
+	updateRestrictions bool
+}
+
+func accountApplicationApply(service account.AccountService, cmd *cobra.Command, fs afero.Fs, args []string) error {
+	content, err := helper.GetContentsFromFilePathFlag(cmd, fs, "file")
+	if err != nil {
+		return fmt.Errorf("account: failed to read policy file: %s", err)
+	}
+
+	var policy ApplicationPolicy
+	err = yaml.Unmarshal([]byte(content), &policy)
+	if err != nil {
+		return fmt.Errorf("account: failed to parse policy file: %s", err)
+	}
+
+	err = validateApplicationPolicy(policy)
+	if err != nil {
+		return fmt.Errorf("account: invalid policy file: %s", err)
+	}
+
+	applications, err := service.GetApplications(connection.APIRequestParameters{})
+	if err != nil {
+		return fmt.Errorf("account: error retrieving applications: %s", err)
+	}
+
+	rotate, _ := cmd.Flags().GetStringSlice("rotate")
+	prune, _ := cmd.Flags().GetBool("prune")
+
+	changes, err := planApplicationChanges(service, policy, applications, rotate, prune)
+	if err != nil {
+		return err
+	}
+
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if dryRun {
+		return output.CommandOutput(cmd, applicationApplyResults(changes))
+	}
+
+	var results []ApplicationApplyResult
+	for _, change := range changes {
+		result, err := applyApplicationChange(service, change)
+		if err != nil {
+			// The result is output regardless, as a created application's key can't be retrieved again
+			result.Error = err.Error()
+			output.OutputWithErrorLevelf("Error applying changes to application [%s]: %s", change.Desired.Name, err)
+		}
+
+		results = append(results, result)
+	}
+
+	return output.CommandOutput(cmd, ApplicationApplyResultCollection(results))
+}
+
+func validateApplicationPolicy(policy ApplicationPolicy) error {
+	names := make(map[string]bool)
+	for _, application := range policy.Applications {
+		if application.Name == "" {
+			return fmt.Errorf("application name is required")
+		}
+		if names[application.Name] {
+			return fmt.Errorf("application [%s] declared more than once", application.Name)
+		}
+		names[application.Name] = true
+
+		if len(application.AllowIPs) > 0 && len(application.DenyIPs) > 0 {
+			return fmt.Errorf("application [%s] cannot specify both allow_ips and deny_ips", application.Name)
+		}
+		if err := validateIPRanges(slices.Concat(application.AllowIPs, application.DenyIPs)); err != nil {
+			return fmt.Errorf("application [%s]: %s", application.Name, err)
+		}
+		for _, scope := range application.Scopes {
+			if scope.Service == "" {
+				return fmt.Errorf("application [%s] has scope with no service", application.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// planApplicationChanges compares applications declared in policy with existing applications, matched by name
+func planApplicationChanges(service account.AccountService, policy ApplicationPolicy, applications []account.Application, rotate []string, prune bool) ([]applicationApplyChange, error) {
+	existing := make(map[string][]account.Application)
+	for _, application := range applications {
+		existing[application.Name] = append(existing[application.Name], application)
+	}
+
+	declared := make(map[string]bool)
+	for _, application := range policy.Applications {
+		declared[application.Name] = true
+	}
+
+	for _, name := range rotate {
+		if !declared[name] {
+			return nil, fmt.Errorf("account: application [%s] to rotate isn't declared in policy file", name)
+		}
+	}
+
+	var changes []applicationApplyChange
+	for _, desired := range policy.Applications {
+		matches := existing[desired.Name]
+		if len(matches) > 1 {
+			return nil, fmt.Errorf("account: multiple existing applications named [%s]", desired.Name)
+		}
+
+		if len(matches) == 0 {
+			change := applicationApplyChange{Action: "create", Desired: desired}
+			change.Changes = append(change.Changes,
+				fmt.Sprintf("scopes: %s", formatApplicationScopes(desired.scopes())),
+				fmt.Sprintf("restriction: %s", formatApplicationRestriction(desired.restriction())),
+			)
+			changes = append(changes, change)
+			continue
+		}
+
+		change, err := planApplicationUpdate(service, matches[0], desired)
+		if err != nil {
+			return nil, err
+		}
+
+		if slices.Contains(rotate, desired.Name) {
+			change.Action = "rotate"
+			change.Changes = append(change.Changes, "key: rotated")
+		}
+
+		changes = append(changes, change)
+	}
+
+	if prune {
+		for _, application := range applications {
+			if !declared[application.Name] {
+				changes = append(changes, applicationApplyChange{
+					Action:   "delete",
+					Existing: application,
+					Desired:  ApplicationPolicyApplication{Name: application.Name},
+				})
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+func planApplicationUpdate(service account.AccountService, existing account.Application, desired ApplicationPolicyApplication) (applicationApplyChange, error) {
+	change := applicationApplyChange{Action: "unchanged", Existing: existing, Desired: desired}
+
+	if existing.Description != desired.Description {
+		change.updateDescription = true
+		change.Changes = append(change.Changes, fmt.Sprintf("description: %q -> %q", existing.Description, desired.Description))
+	}
+
+	services, err := service.GetApplicationServices(existing.ID)
+	if err != nil {
+		return change, fmt.Errorf("account: error retrieving services for application [%s]: %s", existing.Name, err)
+	}
+
+	currentScopes := formatApplicationScopes(services.Scopes)
+	desiredScopes := formatApplicationScopes(desired.scopes())
+	if currentScopes != desiredScopes {
+		change.updateScopes = true
+		change.Changes = append(change.Changes, fmt.Sprintf("scopes: %s -> %s", currentScopes, desiredScopes))
+	}
+
+	restriction, err := service.GetApplicationRestrictions(existing.ID)
+	if err != nil {
+		return change, fmt.Errorf("account: error retrieving restrictions for application [%s]: %s", existing.Name, err)
+	}
+
+	currentRestriction := formatApplicationRestriction(restriction)
+	desiredRestriction := formatApplicationRestriction(desired.restriction())
+	if currentRestriction != desiredRestriction {
+		change.updateRestrictions = true
+		change.Changes = append(change.Changes, fmt.Sprintf("restriction: %s -> %s", currentRestriction, desiredRestriction))
+	}
+
+	if len(change.Changes) > 0 {
+		change.Action = "update"
+	}
+
+	return change, nil
+}
+
+func applyApplicationChange(service account.AccountService, change applicationApplyChange) (ApplicationApplyResult, error) {
+	result := ApplicationApplyResult{
+		ID:      change.Existing.ID,
+		Name:    change.Desired.Name,
+		Action:  change.Action,
+		Changes: change.Changes,
+	}
+
+	switch change.Action {
+	case "create", "rotate":
+		response, err := createPolicyApplication(service, change.Desired)
+		if response.ID != "" {
+			result.ID = response.ID
+			result.Key = response.Key
+		}
+		if err != nil {
+			return result, err
+		}
+
+		if change.Action == "rotate" {
+			err := service.DeleteApplication(change.Existing.ID)
+			if err != nil {
+				return result, fmt.Errorf("replacement application [%s] created but failed to remove original application [%s]: %s", response.ID, change.Existing.ID, err)
+			}
+		}
+	case "update":
+		if change.updateDescription {
+			err := service.UpdateApplication(change.Existing.ID, account.UpdateApplicationRequest{Description: change.Desired.Description})
+			if err != nil {
+				return result, fmt.Errorf("error updating application: %s", err)
+			}
+		}
+		if change.updateScopes {
+			err := setPolicyApplicationServices(service, change.Existing.ID, change.Desired)
+			if err != nil {
+				return result, err
+			}
+		}
+		if change.updateRestrictions {
+			err := setPolicyApplicationRestrictions(service, change.Existing.ID, change.Desired)
+			if err != nil {
+				return result, err
+			}
+		}
+	case "delete":
+		err := service.DeleteApplication(change.Existing.ID)
+		if err != nil {
+			return result, fmt.Errorf("error removing application: %s", err)
+		}
+	}
+
+	return result, nil
+}
+
+func createPolicyApplication(service account.AccountService, desired ApplicationPolicyApplication) (account.CreateApplicationResponse, error) {
+	response, err := service.CreateApplication(account.CreateApplicationRequest{
+		Name:        desired.Name,
+		Description: desired.Description,
+	})
+	if err != nil {
+		return response, fmt.Errorf("error creating application: %s", err)
+	}
+
+	if len(desired.Scopes) > 0 {
+		err = setPolicyApplicationServices(service, response.ID, desired)
+		if err != nil {
+			return response, err
+		}
+	}
+
+	if desired.restriction().IPRestrictionType != "" {
+		err = setPolicyApplicationRestrictions(service, response.ID, desired)
+		if err != nil {
+			return response, err
+		}
+	}
+
+	return response, nil
+}
+
+func setPolicyApplicationServices(service account.AccountService, appID string, desired ApplicationPolicyApplication) error {
+	if len(desired.Scopes) == 0 {
+		err := service.DeleteApplicationServices(appID)
+		if err != nil {
+			return fmt.Errorf("error removing application services: %s", err)
+		}
+
+		return nil
+	}
+
+	err := service.SetApplicationServices(appID, account.SetServiceRequest{Scopes: desired.scopes()})
+	if err != nil {
+		return fmt.Errorf("error setting application services: %s", err)
+	}
+
+	return nil
+}
+
+func setPolicyApplicationRestrictions(service account.AccountService, appID string, desired ApplicationPolicyApplication) error {
+	restriction := desired.restriction()
+	if restriction.IPRestrictionType == "" {
+		err := service.DeleteApplicationRestrictions(appID)
+		if err != nil {
+			return fmt.Errorf("error clearing application restrictions: %s", err)
+		}
+
+		return nil
+	}
+
+	err := service.SetApplicationRestrictions(appID, account.SetRestrictionRequest{
+		IPRestrictionType: restriction.IPRestrictionType,
+		IPRanges:          restriction.IPRanges,
+	})
+	if err != nil {
+		return fmt.Errorf("error setting application restrictions: %s", err)
+	}
+
+	return nil
+}
+
+// formatApplicationScopes returns a normalised representation of scopes, sorted by service and role
+func formatApplicationScopes(scopes []account.ApplicationServiceScope) string {
+	if len(scopes) == 0 {
+		return "none"
+	}
+
+	var formatted []string
+	for _, scope := range scopes {
+		roles := slices.Clone(scope.Roles)
+		sort.Strings(roles)
+		formatted = append(formatted, fmt.Sprintf("%s[%s]", scope.Service, strings.Join(roles, ",")))
+	}
+	sort.Strings(formatted)
+
+	return strings.Join(formatted, " ")
+}
+
+// formatApplicationRestriction returns a normalised representation of restriction, with sorted IP ranges
+func formatApplicationRestriction(restriction account.ApplicationRestriction) string {
+	if restriction.IPRestrictionType == "" || len(restriction.IPRanges) == 0 {
+		return "none"
+	}
+
+	ranges := slices.Clone(restriction.IPRanges)
+	sort.Strings(ranges)
+
+	return fmt.Sprintf("%s[%s]", restriction.IPRestrictionType, strings.Join(ranges, ","))
+}
+
+func applicationApplyResults(changes []applicationApplyChange) ApplicationApplyResultCollection {
+	var results []ApplicationApplyResult
+	for _, change := range changes {
+		results = append(results, ApplicationApplyResult{
+			ID:      change.Existing.ID,
+			Name:    change.Desired.Name,
+			Action:  change.Action,
+			Changes: change.Changes,
+		})
+	}
+
+	return results
+}
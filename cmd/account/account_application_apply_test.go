@@ -0,0 +1,275 @@
+package account
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ans-group/cli/test/mocks"
+	"github.com/ans-group/cli/test/test_output"
+	"github.com/ans-group/sdk-go/pkg/service/account"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+const testApplicationPolicy = `applications:
+  - name: monitoring
+    description: Read-only monitoring
+    scopes:
+      - service: ecloud
+        roles: [read]
+    allow_ips: [203.0.113.0/24]
+  - name: deploy
+    description: Deployments
+    scopes:
+      - service: loadbalancer
+        roles: [write, read]
+`
+
+func newApplicationApplyCmd(t *testing.T, policy string, args ...string) (*cobra.Command, afero.Fs) {
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "/apps.yaml", []byte(policy), 0644)
+
+	cmd := accountApplicationApplyCmd(nil, fs)
+	cmd.Flags().String("output", "", "")
+	err := cmd.ParseFlags(append([]string{"-f", "/apps.yaml", "--output", "csv"}, args...))
+	assert.Nil(t, err)
+
+	return cmd, fs
+}
+
+func Test_accountApplicationApply(t *testing.T) {
+	t.Run("DryRun_OutputsChanges", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockAccountService(mockCtrl)
+		cmd, fs := newApplicationApplyCmd(t, testApplicationPolicy, "--dry-run", "--prune")
+
+		service.EXPECT().GetApplications(gomock.Any()).Return([]account.Application{
+			{ID: "app1", Name: "monitoring", Description: "Monitoring"},
+			{ID: "app2", Name: "legacy"},
+		}, nil)
+		service.EXPECT().GetApplicationServices("app1").Return(account.ApplicationServiceMapping{
+			Scopes: []account.ApplicationServiceScope{{Service: "ecloud", Roles: []string{"read"}}},
+		}, nil)
+		service.EXPECT().GetApplicationRestrictions("app1").Return(account.ApplicationRestriction{}, nil)
+
+		test_output.AssertOutput(t, "id,name,action,changes,key,error\n"+
+			"app1,monitoring,update,\"description: \"\"Monitoring\"\" -> \"\"Read-only monitoring\"\"; restriction: none -> allowlist[203.0.113.0/24]\",,\n"+
+			",deploy,create,\"scopes: loadbalancer[read,write]; restriction: none\",,\n"+
+			"app2,legacy,delete,,,\n", func() {
+			err := accountApplicationApply(service, cmd, fs, []string{})
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("Apply_CreatesUpdatesAndRotates", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockAccountService(mockCtrl)
+		cmd, fs := newApplicationApplyCmd(t, testApplicationPolicy, "--rotate", "monitoring")
+
+		service.EXPECT().GetApplications(gomock.Any()).Return([]account.Application{
+			{ID: "app1", Name: "monitoring", Description: "Read-only monitoring"},
+			{ID: "app2", Name: "legacy"},
+		}, nil)
+		service.EXPECT().GetApplicationServices("app1").Return(account.ApplicationServiceMapping{}, nil)
+		service.EXPECT().GetApplicationRestrictions("app1").Return(account.ApplicationRestriction{IPRestrictionType: "allowlist", IPRanges: []string{"203.0.113.0/24"}}, nil)
+
+		gomock.InOrder(
+			service.EXPECT().CreateApplication(account.CreateApplicationRequest{Name: "monitoring", Description: "Read-only monitoring"}).Return(account.CreateApplicationResponse{ID: "app3", Key: "key3"}, nil),
+			service.EXPECT().SetApplicationServices("app3", account.SetServiceRequest{Scopes: []account.ApplicationServiceScope{{Service: "ecloud", Roles: []string{"read"}}}}).Return(nil),
+			service.EXPECT().SetApplicationRestrictions("app3", account.SetRestrictionRequest{IPRestrictionType: "allowlist", IPRanges: []string{"203.0.113.0/24"}}).Return(nil),
+			service.EXPECT().DeleteApplication("app1").Return(nil),
+			service.EXPECT().CreateApplication(account.CreateApplicationRequest{Name: "deploy", Description: "Deployments"}).Return(account.CreateApplicationResponse{ID: "app4", Key: "key4"}, nil),
+			service.EXPECT().SetApplicationServices("app4", gomock.Any()).Return(nil),
+		)
+
+		test_output.AssertOutput(t, "id,name,action,changes,key,error\n"+
+			"app3,monitoring,rotate,scopes: none -> ecloud[read]; key: rotated,key3,\n"+
+			"app4,deploy,create,\"scopes: loadbalancer[read,write]; restriction: none\",key4,\n", func() {
+			err := accountApplicationApply(service, cmd, fs, []string{})
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("UpdateRemovesScopesAndRestrictions", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockAccountService(mockCtrl)
+		cmd, fs := newApplicationApplyCmd(t, "applications:\n  - name: monitoring\n")
+
+		service.EXPECT().GetApplications(gomock.Any()).Return([]account.Application{{ID: "app1", Name: "monitoring"}}, nil)
+		service.EXPECT().GetApplicationServices("app1").Return(account.ApplicationServiceMapping{
+			Scopes: []account.ApplicationServiceScope{{Service: "ecloud", Roles: []string{"read"}}},
+		}, nil)
+		service.EXPECT().GetApplicationRestrictions("app1").Return(account.ApplicationRestriction{IPRestrictionType: "denylist", IPRanges: []string{"1.2.3.4"}}, nil)
+		service.EXPECT().DeleteApplicationServices("app1").Return(nil)
+		service.EXPECT().DeleteApplicationRestrictions("app1").Return(nil)
+
+		test_output.AssertOutput(t, "id,name,action,changes,key,error\n"+
+			"app1,monitoring,update,scopes: ecloud[read] -> none; restriction: denylist[1.2.3.4] -> none,,\n", func() {
+			err := accountApplicationApply(service, cmd, fs, []string{})
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("CreatedApplicationSetServicesError_OutputsKeyAndError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockAccountService(mockCtrl)
+		cmd, fs := newApplicationApplyCmd(t, testApplicationPolicy)
+
+		service.EXPECT().GetApplications(gomock.Any()).Return([]account.Application{}, nil)
+		gomock.InOrder(
+			service.EXPECT().CreateApplication(account.CreateApplicationRequest{Name: "monitoring", Description: "Read-only monitoring"}).Return(account.CreateApplicationResponse{ID: "app3", Key: "key3"}, nil),
+			service.EXPECT().SetApplicationServices("app3", gomock.Any()).Return(errors.New("test error")),
+			service.EXPECT().CreateApplication(account.CreateApplicationRequest{Name: "deploy", Description: "Deployments"}).Return(account.CreateApplicationResponse{ID: "app4", Key: "key4"}, nil),
+			service.EXPECT().SetApplicationServices("app4", gomock.Any()).Return(nil),
+		)
+
+		test_output.AssertCombinedOutput(t, "id,name,action,changes,key,error\n"+
+			"app3,monitoring,create,scopes: ecloud[read]; restriction: allowlist[203.0.113.0/24],key3,error setting application services: test error\n"+
+			"app4,deploy,create,\"scopes: loadbalancer[read,write]; restriction: none\",key4,\n",
+			"Error applying changes to application [monitoring]: error setting application services: test error\n", func() {
+				accountApplicationApply(service, cmd, fs, []string{})
+			})
+	})
+
+	t.Run("RotateDeleteError_OutputsKeyAndError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockAccountService(mockCtrl)
+		cmd, fs := newApplicationApplyCmd(t, "applications:\n  - name: monitoring\n", "--rotate", "monitoring")
+
+		service.EXPECT().GetApplications(gomock.Any()).Return([]account.Application{{ID: "app1", Name: "monitoring"}}, nil)
+		service.EXPECT().GetApplicationServices("app1").Return(account.ApplicationServiceMapping{}, nil)
+		service.EXPECT().GetApplicationRestrictions("app1").Return(account.ApplicationRestriction{}, nil)
+		gomock.InOrder(
+			service.EXPECT().CreateApplication(gomock.Any()).Return(account.CreateApplicationResponse{ID: "app2", Key: "key2"}, nil),
+			service.EXPECT().DeleteApplication("app1").Return(errors.New("test error")),
+		)
+
+		test_output.AssertCombinedOutput(t, "id,name,action,changes,key,error\n"+
+			"app2,monitoring,rotate,key: rotated,key2,replacement application [app2] created but failed to remove original application [app1]: test error\n",
+			"Error applying changes to application [monitoring]: replacement application [app2] created but failed to remove original application [app1]: test error\n", func() {
+				accountApplicationApply(service, cmd, fs, []string{})
+			})
+	})
+
+	t.Run("ApplyError_OutputsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockAccountService(mockCtrl)
+		cmd, fs := newApplicationApplyCmd(t, "applications:\n  - name: monitoring\n")
+
+		service.EXPECT().GetApplications(gomock.Any()).Return([]account.Application{}, nil)
+		service.EXPECT().CreateApplication(gomock.Any()).Return(account.CreateApplicationResponse{}, errors.New("test error"))
+
+		test_output.AssertErrorOutput(t, "Error applying changes to application [monitoring]: error creating application: test error\n", func() {
+			accountApplicationApply(service, cmd, fs, []string{})
+		})
+	})
+
+	t.Run("MissingFile_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockAccountService(mockCtrl)
+		cmd := accountApplicationApplyCmd(nil, afero.NewMemMapFs())
+		cmd.ParseFlags([]string{"-f", "/missing.yaml"})
+
+		err := accountApplicationApply(service, cmd, afero.NewMemMapFs(), []string{})
+
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "account: failed to read policy file")
+	})
+
+	t.Run("InvalidPolicy_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockAccountService(mockCtrl)
+		cmd, fs := newApplicationApplyCmd(t, "applications:\n  - name: monitoring\n    allow_ips: [1.2.3.4]\n    deny_ips: [5.6.7.8]\n")
+
+		err := accountApplicationApply(service, cmd, fs, []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "account: invalid policy file: application [monitoring] cannot specify both allow_ips and deny_ips", err.Error())
+	})
+
+	t.Run("RotateUndeclared_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockAccountService(mockCtrl)
+		cmd, fs := newApplicationApplyCmd(t, testApplicationPolicy, "--rotate", "unknown")
+
+		service.EXPECT().GetApplications(gomock.Any()).Return([]account.Application{}, nil)
+
+		err := accountApplicationApply(service, cmd, fs, []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "account: application [unknown] to rotate isn't declared in policy file", err.Error())
+	})
+
+	t.Run("DuplicateExistingName_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockAccountService(mockCtrl)
+		cmd, fs := newApplicationApplyCmd(t, "applications:\n  - name: monitoring\n")
+
+		service.EXPECT().GetApplications(gomock.Any()).Return([]account.Application{{ID: "app1", Name: "monitoring"}, {ID: "app2", Name: "monitoring"}}, nil)
+
+		err := accountApplicationApply(service, cmd, fs, []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "account: multiple existing applications named [monitoring]", err.Error())
+	})
+
+	t.Run("GetApplicationsError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockAccountService(mockCtrl)
+		cmd, fs := newApplicationApplyCmd(t, testApplicationPolicy)
+
+		service.EXPECT().GetApplications(gomock.Any()).Return(nil, errors.New("test error"))
+
+		err := accountApplicationApply(service, cmd, fs, []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "account: error retrieving applications: test error", err.Error())
+	})
+}
+
+func Test_validateApplicationPolicy(t *testing.T) {
+	t.Run("DuplicateName_ReturnsError", func(t *testing.T) {
+		err := validateApplicationPolicy(ApplicationPolicy{Applications: []ApplicationPolicyApplication{{Name: "a"}, {Name: "a"}}})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "application [a] declared more than once", err.Error())
+	})
+
+	t.Run("MissingName_ReturnsError", func(t *testing.T) {
+		err := validateApplicationPolicy(ApplicationPolicy{Applications: []ApplicationPolicyApplication{{}}})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "application name is required", err.Error())
+	})
+
+	t.Run("InvalidIP_ReturnsError", func(t *testing.T) {
+		err := validateApplicationPolicy(ApplicationPolicy{Applications: []ApplicationPolicyApplication{{Name: "a", AllowIPs: []string{"invalid"}}}})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "application [a]: invalid IP address or CIDR range: invalid", err.Error())
+	})
+}
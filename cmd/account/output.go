@@ -68,3 +68,34 @@ func (m ApplicationRestrictionCollection) Fields() []*output.OrderedFields {
 	}
 	return data
 }
+
+// ApplicationApplyResult represents a change applied to an application from a policy file
+type ApplicationApplyResult struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Action  string   `json:"action"`
+	Changes []string `json:"changes"`
+	Key     string   `json:"key,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+type ApplicationApplyResultCollection []ApplicationApplyResult
+
+func (m ApplicationApplyResultCollection) DefaultColumns() []string {
+	return []string{"id", "name", "action", "changes", "key", "error"}
+}
+
+func (m ApplicationApplyResultCollection) Fields() []*output.OrderedFields {
+	var data []*output.OrderedFields
+	for _, result := range m {
+		fields := output.NewOrderedFields()
+		fields.Set("id", result.ID)
+		fields.Set("name", result.Name)
+		fields.Set("action", result.Action)
+		fields.Set("changes", strings.Join(result.Changes, "; "))
+		fields.Set("key", result.Key)
+		fields.Set("error", result.Error)
+		data = append(data, fields)
+	}
+	return data
+}
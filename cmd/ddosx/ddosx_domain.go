@@ -31,7 +31,7 @@ func ddosxDomainRootCmd(f factory.ClientFactory, fs afero.Fs) *cobra.Command {
 	cmd.AddCommand(ddosxDomainACLRootCmd(f))
 	cmd.AddCommand(ddosxDomainPropertyRootCmd(f, fs))
 	cmd.AddCommand(ddosxDomainVerificationRootCmd(f, fs))
-	cmd.AddCommand(ddosxDomainCDNRootCmd(f))
+	cmd.AddCommand(ddosxDomainCDNRootCmd(f, fs))
 	cmd.AddCommand(ddosxDomainHSTSRootCmd(f))
 	cmd.AddCommand(ddosxDomainDNSRootCmd(f))
 
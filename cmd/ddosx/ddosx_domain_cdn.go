@@ -1,16 +1,30 @@
 package ddosx
 
 import (
+	"bufio"
 	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ans-group/cli/internal/pkg/factory"
 	"github.com/ans-group/cli/internal/pkg/output"
+	"github.com/ans-group/sdk-go/pkg/connection"
 	"github.com/ans-group/sdk-go/pkg/service/ddosx"
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 )
 
-func ddosxDomainCDNRootCmd(f factory.ClientFactory) *cobra.Command {
+// cdnPurgeVerifyClient is the HTTP client used for verifying purged URLs
+var cdnPurgeVerifyClient = &http.Client{Timeout: 30 * time.Second}
+
+func ddosxDomainCDNRootCmd(f factory.ClientFactory, fs afero.Fs) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "cdn",
 		Short: "sub-commands relating to domain CDN",
@@ -20,6 +34,7 @@ func ddosxDomainCDNRootCmd(f factory.ClientFactory) *cobra.Command {
 	cmd.AddCommand(ddosxDomainCDNEnableCmd(f))
 	cmd.AddCommand(ddosxDomainCDNDisableCmd(f))
 	cmd.AddCommand(ddosxDomainCDNPurgeCmd(f))
+	cmd.AddCommand(ddosxDomainCDNPurgeBatchCmd(f, fs))
 
 	// Child root commands
 	cmd.AddCommand(ddosxDomainCDNRuleRootCmd(f))
@@ -162,3 +177,247 @@ func ddosxDomainCDNPurge(service ddosx.DDoSXService, cmd *cobra.Command, args []
 
 	return nil
 }
+
+func ddosxDomainCDNPurgeBatchCmd(f factory.ClientFactory, fs afero.Fs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "purgebatch",
+		Short: "Purges CDN content for a list of URLs",
+		Long: "This command purges CDN content for a list of URLs read from a file, grouping URLs by domain and record automatically.\n\n" +
+			"Hostnames may contain wildcards, e.g. https://*.example.com/index.html, which are expanded to the matching records " +
+			"of the domain. Wildcards aren't supported within the URL path",
+		Example: "ans ddosx domain cdn purgebatch --file urls.txt --verify",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := f.NewClient()
+			if err != nil {
+				return err
+			}
+
+			return ddosxDomainCDNPurgeBatch(c.DDoSXService(), cmd, fs, args)
+		},
+	}
+
+	cmd.Flags().String("file", "", "Path to file containing URLs to purge, one per line")
+	_ = cmd.MarkFlagRequired("file")
+	cmd.Flags().Bool("verify", false, "Specifies that purged URLs should be requested to verify fresh content is returned")
+	cmd.Flags().Int("verify-delay", 5, "Number of seconds to wait after purging before verifying URLs")
+
+	return cmd
+}
+
+func ddosxDomainCDNPurgeBatch(service ddosx.DDoSXService, cmd *cobra.Command, fs afero.Fs, args []string) error {
+	filePath, _ := cmd.Flags().GetString("file")
+	file, err := fs.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open URL file: %s", err)
+	}
+	defer file.Close()
+
+	urls, err := parseCDNPurgeURLs(bufio.NewScanner(file))
+	if err != nil {
+		return err
+	}
+	if len(urls) < 1 {
+		return errors.New("no URLs found in file")
+	}
+
+	domains, err := service.GetDomains(connection.APIRequestParameters{})
+	if err != nil {
+		return fmt.Errorf("error retrieving domains: %s", err)
+	}
+
+	var results []OutputDDoSXDomainCDNPurgeResult
+	domainRecords := make(map[string][]ddosx.Record)
+	for _, purgeURL := range urls {
+		domain := findCDNPurgeDomain(domains, purgeURL.Hostname())
+		if domain == "" {
+			output.OutputWithErrorLevelf("Error purging CDN content for URL [%s]: no matching domain found", purgeURL)
+			continue
+		}
+
+		purgeURLs := []*url.URL{purgeURL}
+		if strings.Contains(purgeURL.Hostname(), "*") {
+			records, retrieved := domainRecords[domain]
+			if !retrieved {
+				records, err = service.GetDomainRecords(domain, connection.APIRequestParameters{})
+				if err != nil {
+					output.OutputWithErrorLevelf("Error purging CDN content for URL [%s]: error retrieving domain records: %s", purgeURL, err)
+					continue
+				}
+				domainRecords[domain] = records
+			}
+
+			purgeURLs = expandCDNPurgeURL(purgeURL, records)
+			if len(purgeURLs) < 1 {
+				output.OutputWithErrorLevelf("Error purging CDN content for URL [%s]: no matching records found", purgeURL)
+				continue
+			}
+		}
+
+		for _, u := range purgeURLs {
+			results = append(results, OutputDDoSXDomainCDNPurgeResult{
+				URL:        u.String(),
+				Domain:     domain,
+				RecordName: u.Hostname(),
+				URI:        u.RequestURI(),
+			})
+		}
+	}
+
+	// Sort results by domain and record, so purges for the same record are grouped together.
+	// Identical purges are only requested once, with the result being reused
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Domain != results[j].Domain {
+			return results[i].Domain < results[j].Domain
+		}
+		return results[i].RecordName < results[j].RecordName
+	})
+
+	purged := make(map[ddosx.PurgeCDNRequest]error)
+	for i := range results {
+		purgeRequest := ddosx.PurgeCDNRequest{
+			RecordName: results[i].RecordName,
+			URI:        results[i].URI,
+		}
+
+		err, exists := purged[purgeRequest]
+		if !exists {
+			err = service.PurgeDomainCDN(results[i].Domain, purgeRequest)
+			purged[purgeRequest] = err
+			if err != nil {
+				output.OutputWithErrorLevelf("Error purging CDN content for URL [%s]: %s", results[i].URL, err)
+			}
+		}
+
+		results[i].Purged = err == nil
+	}
+
+	verify, _ := cmd.Flags().GetBool("verify")
+	if !verify {
+		return output.CommandOutput(cmd, OutputDDoSXDomainCDNPurgeResultCollection(results))
+	}
+
+	purgedAt := time.Now()
+	verifyDelay, _ := cmd.Flags().GetInt("verify-delay")
+	time.Sleep(time.Duration(verifyDelay) * time.Second)
+
+	for i := range results {
+		if !results[i].Purged {
+			continue
+		}
+
+		err := verifyCDNPurgeURL(cdnPurgeVerifyClient, &results[i], purgedAt)
+		if err != nil {
+			output.OutputWithErrorLevelf("Error verifying URL [%s]: %s", results[i].URL, err)
+			continue
+		}
+		if !results[i].Fresh {
+			output.OutputWithErrorLevelf("URL [%s] returned stale content with age [%s]", results[i].URL, results[i].Age)
+		}
+	}
+
+	return output.CommandOutput(cmd, OutputDDoSXDomainCDNPurgeResultCollection(results), output.WithAdditionalColumns("status_code", "age", "cache_status", "fresh"))
+}
+
+// parseCDNPurgeURLs reads URLs from scanner, one per line. Empty lines and lines
+// starting with '#' are ignored. Wildcards are permitted in the hostname only
+func parseCDNPurgeURLs(scanner *bufio.Scanner) ([]*url.URL, error) {
+	var urls []*url.URL
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parsedURL, err := url.Parse(line)
+		if err != nil || parsedURL.Hostname() == "" {
+			return nil, fmt.Errorf("invalid URL [%s]", line)
+		}
+		if strings.Contains(parsedURL.RequestURI(), "*") {
+			return nil, fmt.Errorf("invalid URL [%s]: wildcards are only supported in the hostname", line)
+		}
+
+		urls = append(urls, parsedURL)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read URL file: %s", err)
+	}
+
+	return urls, nil
+}
+
+// expandCDNPurgeURL returns a URL for each distinct record name matching the wildcard
+// hostname of purgeURL
+func expandCDNPurgeURL(purgeURL *url.URL, records []ddosx.Record) []*url.URL {
+	pattern := strings.ToLower(purgeURL.Hostname())
+
+	var urls []*url.URL
+	seen := make(map[string]bool)
+	for _, record := range records {
+		name := strings.ToLower(strings.TrimSuffix(record.Name, "."))
+		if seen[name] {
+			continue
+		}
+
+		matched, _ := path.Match(pattern, name)
+		if !matched {
+			continue
+		}
+		seen[name] = true
+
+		expandedURL := *purgeURL
+		expandedURL.Host = name
+		if port := purgeURL.Port(); port != "" {
+			expandedURL.Host = net.JoinHostPort(name, port)
+		}
+		urls = append(urls, &expandedURL)
+	}
+
+	return urls
+}
+
+// findCDNPurgeDomain returns the name of the most specific domain which host belongs to,
+// or an empty string if none match
+func findCDNPurgeDomain(domains []ddosx.Domain, host string) string {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+
+	match := ""
+	for _, domain := range domains {
+		name := strings.ToLower(domain.Name)
+		if (host == name || strings.HasSuffix(host, "."+name)) && len(name) > len(match) {
+			match = domain.Name
+		}
+	}
+
+	return match
+}
+
+// verifyCDNPurgeURL requests the URL for result, populating cache related fields. Content is
+// considered fresh when the Age header is absent, or no older than the time since purgedAt
+func verifyCDNPurgeURL(client *http.Client, result *OutputDDoSXDomainCDNPurgeResult, purgedAt time.Time) error {
+	resp, err := client.Get(result.URL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	result.StatusCode = resp.StatusCode
+	result.Age = resp.Header.Get("Age")
+	result.CacheStatus = resp.Header.Get("X-Cache")
+	if result.CacheStatus == "" {
+		result.CacheStatus = resp.Header.Get("Cache-Status")
+	}
+
+	if result.Age == "" {
+		result.Fresh = true
+		return nil
+	}
+
+	age, err := strconv.Atoi(result.Age)
+	if err != nil {
+		return fmt.Errorf("invalid Age header [%s]", result.Age)
+	}
+
+	result.Fresh = time.Duration(age)*time.Second <= time.Since(purgedAt)
+	return nil
+}
@@ -2,12 +2,16 @@ package ddosx
 
 import (
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/ans-group/cli/test/mocks"
 	"github.com/ans-group/cli/test/test_output"
 	"github.com/ans-group/sdk-go/pkg/service/ddosx"
 	gomock "github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
@@ -177,3 +181,220 @@ func Test_ddosxDomainCDNPurge(t *testing.T) {
 		assert.Equal(t, "error purging CDN content for domain: test error", err.Error())
 	})
 }
+
+func Test_ddosxDomainCDNPurgeBatch(t *testing.T) {
+	t.Run("Valid_GroupsByDomainAndRecord", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/tmp/urls.txt", []byte("# comment\nhttps://www.example.com/test.html\n\nhttps://cdn.sub.example.com/img.png?v=1\nhttps://www.example.com/test.html\n"), 0644)
+
+		cmd := ddosxDomainCDNPurgeBatchCmd(nil, nil)
+		cmd.Flags().Set("file", "/tmp/urls.txt")
+
+		service := mocks.NewMockDDoSXService(mockCtrl)
+
+		service.EXPECT().GetDomains(gomock.Any()).Return([]ddosx.Domain{{Name: "example.com"}, {Name: "sub.example.com"}}, nil)
+		service.EXPECT().PurgeDomainCDN("example.com", ddosx.PurgeCDNRequest{RecordName: "www.example.com", URI: "/test.html"}).Return(nil).Times(1)
+		service.EXPECT().PurgeDomainCDN("sub.example.com", ddosx.PurgeCDNRequest{RecordName: "cdn.sub.example.com", URI: "/img.png?v=1"}).Return(nil).Times(1)
+
+		err := ddosxDomainCDNPurgeBatch(service, cmd, fs, []string{})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("WildcardHostname_ExpandsToMatchingRecords", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/tmp/urls.txt", []byte("https://*.example.com/test.html\n"), 0644)
+
+		cmd := ddosxDomainCDNPurgeBatchCmd(nil, nil)
+		cmd.Flags().Set("file", "/tmp/urls.txt")
+
+		service := mocks.NewMockDDoSXService(mockCtrl)
+
+		service.EXPECT().GetDomains(gomock.Any()).Return([]ddosx.Domain{{Name: "example.com"}}, nil)
+		service.EXPECT().GetDomainRecords("example.com", gomock.Any()).Return([]ddosx.Record{
+			{Name: "example.com"},
+			{Name: "www.example.com", Type: "A"},
+			{Name: "www.example.com", Type: "AAAA"},
+			{Name: "cdn.example.com"},
+		}, nil)
+		service.EXPECT().PurgeDomainCDN("example.com", ddosx.PurgeCDNRequest{RecordName: "www.example.com", URI: "/test.html"}).Return(nil).Times(1)
+		service.EXPECT().PurgeDomainCDN("example.com", ddosx.PurgeCDNRequest{RecordName: "cdn.example.com", URI: "/test.html"}).Return(nil).Times(1)
+
+		err := ddosxDomainCDNPurgeBatch(service, cmd, fs, []string{})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("WildcardHostnameNoMatchingRecords_OutputsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/tmp/urls.txt", []byte("https://*.example.com/test.html\n"), 0644)
+
+		cmd := ddosxDomainCDNPurgeBatchCmd(nil, nil)
+		cmd.Flags().Set("file", "/tmp/urls.txt")
+
+		service := mocks.NewMockDDoSXService(mockCtrl)
+
+		service.EXPECT().GetDomains(gomock.Any()).Return([]ddosx.Domain{{Name: "example.com"}}, nil)
+		service.EXPECT().GetDomainRecords("example.com", gomock.Any()).Return([]ddosx.Record{{Name: "example.com"}}, nil)
+
+		test_output.AssertErrorOutput(t, "Error purging CDN content for URL [https://*.example.com/test.html]: no matching records found\n", func() {
+			ddosxDomainCDNPurgeBatch(service, cmd, fs, []string{})
+		})
+	})
+
+	t.Run("WildcardPath_ReturnsError", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/tmp/urls.txt", []byte("https://www.example.com/images/*\n"), 0644)
+
+		cmd := ddosxDomainCDNPurgeBatchCmd(nil, nil)
+		cmd.Flags().Set("file", "/tmp/urls.txt")
+
+		err := ddosxDomainCDNPurgeBatch(nil, cmd, fs, []string{})
+
+		assert.Equal(t, "invalid URL [https://www.example.com/images/*]: wildcards are only supported in the hostname", err.Error())
+	})
+
+	t.Run("NoMatchingDomain_OutputsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/tmp/urls.txt", []byte("https://www.example.org/test.html\n"), 0644)
+
+		cmd := ddosxDomainCDNPurgeBatchCmd(nil, nil)
+		cmd.Flags().Set("file", "/tmp/urls.txt")
+
+		service := mocks.NewMockDDoSXService(mockCtrl)
+
+		service.EXPECT().GetDomains(gomock.Any()).Return([]ddosx.Domain{{Name: "example.com"}}, nil)
+
+		test_output.AssertErrorOutput(t, "Error purging CDN content for URL [https://www.example.org/test.html]: no matching domain found\n", func() {
+			ddosxDomainCDNPurgeBatch(service, cmd, fs, []string{})
+		})
+	})
+
+	t.Run("PurgeDomainCDNError_OutputsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/tmp/urls.txt", []byte("https://www.example.com/test.html\n"), 0644)
+
+		cmd := ddosxDomainCDNPurgeBatchCmd(nil, nil)
+		cmd.Flags().Set("file", "/tmp/urls.txt")
+
+		service := mocks.NewMockDDoSXService(mockCtrl)
+
+		service.EXPECT().GetDomains(gomock.Any()).Return([]ddosx.Domain{{Name: "example.com"}}, nil)
+		service.EXPECT().PurgeDomainCDN("example.com", gomock.Any()).Return(errors.New("test error"))
+
+		test_output.AssertErrorOutput(t, "Error purging CDN content for URL [https://www.example.com/test.html]: test error\n", func() {
+			ddosxDomainCDNPurgeBatch(service, cmd, fs, []string{})
+		})
+	})
+
+	t.Run("InvalidURL_ReturnsError", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/tmp/urls.txt", []byte("/test.html\n"), 0644)
+
+		cmd := ddosxDomainCDNPurgeBatchCmd(nil, nil)
+		cmd.Flags().Set("file", "/tmp/urls.txt")
+
+		err := ddosxDomainCDNPurgeBatch(nil, cmd, fs, []string{})
+
+		assert.Equal(t, "invalid URL [/test.html]", err.Error())
+	})
+
+	t.Run("MissingFile_ReturnsError", func(t *testing.T) {
+		cmd := ddosxDomainCDNPurgeBatchCmd(nil, nil)
+		cmd.Flags().Set("file", "/tmp/urls.txt")
+
+		err := ddosxDomainCDNPurgeBatch(nil, cmd, afero.NewMemMapFs(), []string{})
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("GetDomainsError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/tmp/urls.txt", []byte("https://www.example.com/test.html\n"), 0644)
+
+		cmd := ddosxDomainCDNPurgeBatchCmd(nil, nil)
+		cmd.Flags().Set("file", "/tmp/urls.txt")
+
+		service := mocks.NewMockDDoSXService(mockCtrl)
+
+		service.EXPECT().GetDomains(gomock.Any()).Return([]ddosx.Domain{}, errors.New("test error"))
+
+		err := ddosxDomainCDNPurgeBatch(service, cmd, fs, []string{})
+
+		assert.Equal(t, "error retrieving domains: test error", err.Error())
+	})
+}
+
+func Test_verifyCDNPurgeURL(t *testing.T) {
+	t.Run("NoAgeHeader_Fresh", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Cache", "MISS")
+		}))
+		defer server.Close()
+
+		result := OutputDDoSXDomainCDNPurgeResult{URL: server.URL}
+		err := verifyCDNPurgeURL(server.Client(), &result, time.Now())
+
+		assert.Nil(t, err)
+		assert.True(t, result.Fresh)
+		assert.Equal(t, 200, result.StatusCode)
+		assert.Equal(t, "MISS", result.CacheStatus)
+	})
+
+	t.Run("AgeOlderThanPurge_Stale", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Age", "3600")
+		}))
+		defer server.Close()
+
+		result := OutputDDoSXDomainCDNPurgeResult{URL: server.URL}
+		err := verifyCDNPurgeURL(server.Client(), &result, time.Now().Add(-10*time.Second))
+
+		assert.Nil(t, err)
+		assert.False(t, result.Fresh)
+		assert.Equal(t, "3600", result.Age)
+	})
+
+	t.Run("AgeSincePurge_Fresh", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Age", "2")
+		}))
+		defer server.Close()
+
+		result := OutputDDoSXDomainCDNPurgeResult{URL: server.URL}
+		err := verifyCDNPurgeURL(server.Client(), &result, time.Now().Add(-10*time.Second))
+
+		assert.Nil(t, err)
+		assert.True(t, result.Fresh)
+	})
+
+	t.Run("InvalidAgeHeader_ReturnsError", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Age", "invalid")
+		}))
+		defer server.Close()
+
+		result := OutputDDoSXDomainCDNPurgeResult{URL: server.URL}
+		err := verifyCDNPurgeURL(server.Client(), &result, time.Now())
+
+		assert.Equal(t, "invalid Age header [invalid]", err.Error())
+	})
+}
@@ -54,12 +54,12 @@ func ddosxWAFLogList(service ddosx.DDoSXService, cmd *cobra.Command, args []stri
 		return err
 	}
 
-	paginatedLogs, err := service.GetWAFLogsPaginated(params)
+	err = output.CommandOutputStream[WAFLogCollection](cmd, params, service.GetWAFLogsPaginated)
 	if err != nil {
 		return fmt.Errorf("error retrieving WAF logs: %s", err)
 	}
 
-	return output.CommandOutputPaginated(cmd, WAFLogCollection(paginatedLogs.Items()), paginatedLogs)
+	return nil
 }
 
 func ddosxWAFLogShowCmd(f factory.ClientFactory) *cobra.Command {
@@ -54,19 +54,20 @@ func ddosxWAFLogMatchList(service ddosx.DDoSXService, cmd *cobra.Command, args [
 		return err
 	}
 
-	var paginatedMatches *connection.Paginated[ddosx.WAFLogMatch]
-
+	getFunc := service.GetWAFLogMatchesPaginated
 	if cmd.Flags().Changed("log") {
 		log, _ := cmd.Flags().GetString("log")
-		paginatedMatches, err = service.GetWAFLogRequestMatchesPaginated(log, params)
-	} else {
-		paginatedMatches, err = service.GetWAFLogMatchesPaginated(params)
+		getFunc = func(parameters connection.APIRequestParameters) (*connection.Paginated[ddosx.WAFLogMatch], error) {
+			return service.GetWAFLogRequestMatchesPaginated(log, parameters)
+		}
 	}
+
+	err = output.CommandOutputStream[WAFLogMatchCollection](cmd, params, getFunc)
 	if err != nil {
 		return fmt.Errorf("error retrieving WAF log matches: %s", err)
 	}
 
-	return output.CommandOutputPaginated(cmd, WAFLogMatchCollection(paginatedMatches.Items()), paginatedMatches)
+	return nil
 }
 
 func ddosxWAFLogMatchShowCmd(f factory.ClientFactory) *cobra.Command {
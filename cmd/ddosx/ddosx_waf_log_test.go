@@ -34,7 +34,8 @@ func Test_ddosxWAFLogList(t *testing.T) {
 				Property: "domain",
 				Operator: connection.EQOperator,
 				Value:    []string{"example.com"},
-			})
+			}).
+			WithPagination(connection.APIRequestPagination{Page: 1})
 
 		cmd := ddosxWAFLogListCmd(nil)
 		cmd.Flags().Set("domain", "example.com")
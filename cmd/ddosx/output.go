@@ -99,6 +99,24 @@ func (m CDNRuleCollection) DefaultColumns() []string {
 	return []string{"id", "uri", "cache_control", "cache_control_duration", "mime_types", "type"}
 }
 
+type OutputDDoSXDomainCDNPurgeResult struct {
+	URL         string `json:"url"`
+	Domain      string `json:"domain"`
+	RecordName  string `json:"record_name"`
+	URI         string `json:"uri"`
+	Purged      bool   `json:"purged"`
+	StatusCode  int    `json:"status_code"`
+	Age         string `json:"age"`
+	CacheStatus string `json:"cache_status"`
+	Fresh       bool   `json:"fresh"`
+}
+
+type OutputDDoSXDomainCDNPurgeResultCollection []OutputDDoSXDomainCDNPurgeResult
+
+func (m OutputDDoSXDomainCDNPurgeResultCollection) DefaultColumns() []string {
+	return []string{"url", "domain", "record_name", "uri", "purged"}
+}
+
 type HSTSConfigurationCollection []ddosx.HSTSConfiguration
 
 func (m HSTSConfigurationCollection) DefaultColumns() []string {
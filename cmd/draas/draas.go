@@ -11,10 +11,14 @@ func DRaaSRootCmd(f factory.ClientFactory) *cobra.Command {
 		Short: "Commands relating to DRaaS service",
 	}
 
+	// Child commands
+	cmd.AddCommand(draasStatusCmd(f))
+
 	// Child root commands
 	cmd.AddCommand(draasSolutionRootCmd(f))
 	cmd.AddCommand(draasIOPSTierRootCmd(f))
 	cmd.AddCommand(draasBillingTypeRootCmd(f))
+	cmd.AddCommand(draasFailoverRootCmd(f))
 
 	return cmd
 }
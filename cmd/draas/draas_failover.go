@@ -0,0 +1,378 @@
+package draas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/ans-group/cli/internal/pkg/factory"
+	"github.com/ans-group/cli/internal/pkg/helper"
+	"github.com/ans-group/cli/internal/pkg/output"
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/ans-group/sdk-go/pkg/service/draas"
+	"github.com/spf13/cobra"
+)
+
+// failoverNow and failoverSleep are overridden in tests
+var failoverNow = time.Now
+var failoverSleep = func(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func draasFailoverRootCmd(f factory.ClientFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "failover",
+		Short: "sub-commands relating to failover orchestration",
+	}
+
+	// Child commands
+	cmd.AddCommand(draasFailoverRunCmd(f))
+
+	return cmd
+}
+
+func draasFailoverRunCmd(f factory.ClientFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run <solution: id> <failoverplan: id>",
+		Short: "Runs a failover plan with readiness checks",
+		Long: "This command checks that a solution is ready to fail over, starts a failover plan and outputs a timeline of events.\n\n" +
+			"Readiness checks verify that a replica exists and is powered off for each VM in the plan, that the solution IOPS tier " +
+			"can be retrieved and isn't exceeded by any replica, and that compute resources have CPU and memory headroom for the " +
+			"plan's replicas. Failed checks abort the failover unless --force is specified.\n\n" +
+			"With --wait, the command polls until all replicas in the plan are powered on. With --test-duration, the plan " +
+			"is stopped automatically once the specified duration has elapsed after the replicas are running (implies --wait). " +
+			"The plan is also stopped if waiting for replicas fails or the command is interrupted",
+		Example: "ans draas failover run 00000000-0000-0000-0000-000000000000 00000000-0000-0000-0000-000000000001 --wait\n" +
+			"ans draas failover run 00000000-0000-0000-0000-000000000000 00000000-0000-0000-0000-000000000001 --test-duration 30m",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("missing solution")
+			}
+			if len(args) < 2 {
+				return errors.New("missing failover plan")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := f.NewClient()
+			if err != nil {
+				return err
+			}
+
+			return draasFailoverRun(c.DRaaSService(), cmd, args)
+		},
+	}
+
+	cmd.Flags().Bool("wait", false, "Specifies that the command should wait until all replicas in the failover plan are powered on")
+	cmd.Flags().String("test-duration", "", "Specifies that this is a test failover, stopping the plan after given duration once replicas are running, e.g. 30m, 2h")
+	cmd.Flags().Bool("force", false, "Specifies that the failover plan should be started even if readiness checks fail")
+
+	return cmd
+}
+
+// failoverTimeline records timestamped events during a failover run
+type failoverTimeline struct {
+	start  time.Time
+	events []FailoverTimelineEvent
+}
+
+func newFailoverTimeline() *failoverTimeline {
+	return &failoverTimeline{start: failoverNow()}
+}
+
+func (t *failoverTimeline) Add(format string, a ...interface{}) {
+	now := failoverNow()
+	t.events = append(t.events, FailoverTimelineEvent{
+		Time:    now,
+		Elapsed: now.Sub(t.start),
+		Event:   fmt.Sprintf(format, a...),
+	})
+}
+
+func draasFailoverRun(service draas.DRaaSService, cmd *cobra.Command, args []string) (err error) {
+	solutionID := args[0]
+	planID := args[1]
+
+	wait, _ := cmd.Flags().GetBool("wait")
+	var testDuration time.Duration
+	if cmd.Flags().Changed("test-duration") {
+		testDurationFlag, _ := cmd.Flags().GetString("test-duration")
+		var err error
+		testDuration, err = helper.ParseDuration(testDurationFlag)
+		if err != nil {
+			return err
+		}
+		wait = true
+	}
+
+	timeline := newFailoverTimeline()
+
+	plan, err := service.GetSolutionFailoverPlan(solutionID, planID)
+	if err != nil {
+		return fmt.Errorf("error retrieving solution failover plan: %s", err)
+	}
+
+	replicas, err := getFailoverPlanReplicas(service, solutionID, plan)
+	if err != nil {
+		return err
+	}
+
+	checks := checkFailoverReadiness(service, solutionID, plan, replicas)
+	failed := false
+	for _, check := range checks {
+		if !check.Passed {
+			failed = true
+			output.Errorf("Readiness check [%s] failed: %s", check.Name, check.Detail)
+			timeline.Add("Readiness check [%s] failed: %s", check.Name, check.Detail)
+			continue
+		}
+
+		timeline.Add("Readiness check [%s] passed: %s", check.Name, check.Detail)
+	}
+
+	if failed {
+		force, _ := cmd.Flags().GetBool("force")
+		if !force {
+			return errors.New("readiness checks failed, use --force to start failover plan regardless")
+		}
+	}
+
+	err = service.StartSolutionFailoverPlan(solutionID, planID, draas.StartFailoverPlanRequest{})
+	if err != nil {
+		return fmt.Errorf("error starting solution failover plan: %s", err)
+	}
+	timeline.Add("Started failover plan [%s]", plan.Name)
+
+	// Once the plan has started, the timeline is always output, including where an error occurs
+	defer func() {
+		outputErr := output.CommandOutput(cmd, FailoverTimelineEventCollection(timeline.events))
+		if err == nil {
+			err = outputErr
+		}
+	}()
+
+	ctx := context.Background()
+	if testDuration > 0 {
+		// Test failover plans are always stopped, including where waiting fails or the command is interrupted
+		var stop context.CancelFunc
+		ctx, stop = signal.NotifyContext(ctx, os.Interrupt)
+		defer stop()
+
+		defer func() {
+			stopErr := service.StopSolutionFailoverPlan(solutionID, planID)
+			if stopErr != nil {
+				timeline.Add("Failed to stop failover plan [%s]: %s", plan.Name, stopErr)
+				if err == nil {
+					err = fmt.Errorf("error stopping solution failover plan: %s", stopErr)
+				}
+				return
+			}
+			timeline.Add("Stopped failover plan [%s]", plan.Name)
+		}()
+	}
+
+	if wait {
+		waitFunc := failoverPlanReplicasPoweredOnWaitFunc(service, solutionID, plan, timeline)
+		err := helper.WaitForCommandContext(ctx, waitFunc)
+		if err != nil {
+			timeline.Add("Failed waiting for replicas: %s", err)
+			return fmt.Errorf("error waiting for failover plan replicas: %s", err)
+		}
+		timeline.Add("All replicas running")
+	}
+
+	if testDuration > 0 {
+		timeline.Add("Test failover running for %s", testDuration)
+		err := failoverSleep(ctx, testDuration)
+		if err != nil {
+			timeline.Add("Test failover interrupted")
+			return errors.New("test failover interrupted")
+		}
+	}
+
+	return nil
+}
+
+// failoverReplica represents a replica along with the ID of the hardware plan it belongs to
+type failoverReplica struct {
+	draas.Replica
+	HardwarePlanID string
+}
+
+// getFailoverPlanReplicas returns replicas for the solution keyed by name, for VMs within plan
+func getFailoverPlanReplicas(service draas.DRaaSService, solutionID string, plan draas.FailoverPlan) (map[string]failoverReplica, error) {
+	hardwarePlans, err := service.GetSolutionHardwarePlans(solutionID, connection.APIRequestParameters{})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving solution hardware plans: %s", err)
+	}
+
+	vmNames := make(map[string]bool)
+	for _, vm := range plan.VMs {
+		vmNames[vm.Name] = true
+	}
+
+	replicas := make(map[string]failoverReplica)
+	for _, hardwarePlan := range hardwarePlans {
+		hardwarePlanReplicas, err := service.GetSolutionHardwarePlanReplicas(solutionID, hardwarePlan.ID, connection.APIRequestParameters{})
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving replicas for hardware plan [%s]: %s", hardwarePlan.ID, err)
+		}
+
+		for _, replica := range hardwarePlanReplicas {
+			if vmNames[replica.Name] {
+				replicas[replica.Name] = failoverReplica{Replica: replica, HardwarePlanID: hardwarePlan.ID}
+			}
+		}
+	}
+
+	return replicas, nil
+}
+
+// failoverCheck represents the result of a failover readiness check
+type failoverCheck struct {
+	Name   string
+	Passed bool
+	Detail string
+}
+
+func checkFailoverReadiness(service draas.DRaaSService, solutionID string, plan draas.FailoverPlan, replicas map[string]failoverReplica) []failoverCheck {
+	return []failoverCheck{
+		checkFailoverReplicas(plan, replicas),
+		checkFailoverIOPSTier(service, solutionID, replicas),
+		checkFailoverComputeHeadroom(service, solutionID, replicas),
+	}
+}
+
+func checkFailoverReplicas(plan draas.FailoverPlan, replicas map[string]failoverReplica) failoverCheck {
+	check := failoverCheck{Name: "replicas"}
+
+	for _, vm := range plan.VMs {
+		replica, ok := replicas[vm.Name]
+		if !ok {
+			check.Detail = fmt.Sprintf("no replica found for VM [%s]", vm.Name)
+			return check
+		}
+		if replica.Power {
+			check.Detail = fmt.Sprintf("replica [%s] is already powered on", vm.Name)
+			return check
+		}
+	}
+
+	check.Passed = true
+	check.Detail = fmt.Sprintf("%d replica(s) present and powered off", len(plan.VMs))
+	return check
+}
+
+func checkFailoverIOPSTier(service draas.DRaaSService, solutionID string, replicas map[string]failoverReplica) failoverCheck {
+	check := failoverCheck{Name: "iops_tier"}
+
+	solution, err := service.GetSolution(solutionID)
+	if err != nil {
+		check.Detail = fmt.Sprintf("error retrieving solution: %s", err)
+		return check
+	}
+
+	tier, err := service.GetIOPSTier(solution.IOPSTierID)
+	if err != nil {
+		check.Detail = fmt.Sprintf("error retrieving IOPS tier [%s]: %s", solution.IOPSTierID, err)
+		return check
+	}
+
+	for _, replica := range replicas {
+		if replica.IOPS > tier.IOPSLimit {
+			check.Detail = fmt.Sprintf("replica [%s] IOPS %d exceeds tier limit %d", replica.Name, replica.IOPS, tier.IOPSLimit)
+			return check
+		}
+	}
+
+	check.Passed = true
+	check.Detail = fmt.Sprintf("IOPS tier [%s] with limit %d", tier.ID, tier.IOPSLimit)
+	return check
+}
+
+func checkFailoverComputeHeadroom(service draas.DRaaSService, solutionID string, replicas map[string]failoverReplica) failoverCheck {
+	check := failoverCheck{Name: "compute_headroom"}
+
+	requiredCPU := make(map[string]int)
+	requiredRAM := make(map[string]int)
+	for _, replica := range replicas {
+		requiredCPU[replica.HardwarePlanID] += replica.CPU
+		requiredRAM[replica.HardwarePlanID] += replica.RAM
+	}
+
+	computeResources, err := service.GetSolutionComputeResources(solutionID, connection.APIRequestParameters{})
+	if err != nil {
+		check.Detail = fmt.Sprintf("error retrieving compute resources: %s", err)
+		return check
+	}
+
+	for _, resource := range computeResources {
+		if requiredCPU[resource.HardwarePlanID] == 0 && requiredRAM[resource.HardwarePlanID] == 0 {
+			continue
+		}
+
+		hardwarePlan, err := service.GetSolutionHardwarePlan(solutionID, resource.HardwarePlanID)
+		if err != nil {
+			check.Detail = fmt.Sprintf("error retrieving hardware plan [%s]: %s", resource.HardwarePlanID, err)
+			return check
+		}
+
+		cpuRequired := requiredCPU[resource.HardwarePlanID]
+		if resource.CPU.Used+cpuRequired > hardwarePlan.Limits.Processor {
+			check.Detail = fmt.Sprintf("compute resource [%s] requires %d CPU with %d of %d used", resource.ID, cpuRequired, resource.CPU.Used, hardwarePlan.Limits.Processor)
+			return check
+		}
+
+		ramRequired := float32(requiredRAM[resource.HardwarePlanID])
+		if resource.Memory.Used+ramRequired > resource.Memory.Limit {
+			check.Detail = fmt.Sprintf("compute resource [%s] requires %.0fGB memory with %.1fGB of %.1fGB used", resource.ID, ramRequired, resource.Memory.Used, resource.Memory.Limit)
+			return check
+		}
+	}
+
+	check.Passed = true
+	check.Detail = "sufficient CPU and memory available"
+	return check
+}
+
+// failoverPlanReplicasPoweredOnWaitFunc returns a WaitFunc which finishes once all replicas for VMs in plan
+// are powered on, recording each replica power on against timeline
+func failoverPlanReplicasPoweredOnWaitFunc(service draas.DRaaSService, solutionID string, plan draas.FailoverPlan, timeline *failoverTimeline) helper.WaitFunc {
+	poweredOn := make(map[string]bool)
+
+	return func() (finished bool, err error) {
+		replicas, err := getFailoverPlanReplicas(service, solutionID, plan)
+		if err != nil {
+			return false, err
+		}
+
+		finished = true
+		for _, vm := range plan.VMs {
+			if poweredOn[vm.Name] {
+				continue
+			}
+
+			if replica, ok := replicas[vm.Name]; ok && replica.Power {
+				poweredOn[vm.Name] = true
+				timeline.Add("Replica [%s] running", vm.Name)
+				continue
+			}
+
+			finished = false
+		}
+
+		return finished, nil
+	}
+}
@@ -0,0 +1,374 @@
+package draas
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ans-group/cli/test/mocks"
+	"github.com/ans-group/cli/test/test_output"
+	"github.com/ans-group/sdk-go/pkg/service/draas"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setFailoverClock(t *testing.T) {
+	oldNow := failoverNow
+	oldSleep := failoverSleep
+
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	failoverNow = func() time.Time {
+		current := now
+		now = now.Add(time.Minute)
+		return current
+	}
+	failoverSleep = func(ctx context.Context, d time.Duration) error { return nil }
+
+	t.Cleanup(func() {
+		failoverNow = oldNow
+		failoverSleep = oldSleep
+	})
+}
+
+func testFailoverPlan() draas.FailoverPlan {
+	plan := draas.FailoverPlan{ID: "fp1", Name: "plan1"}
+	plan.VMs = append(plan.VMs, struct {
+		Name string `json:"name"`
+	}{Name: "vm1"})
+
+	return plan
+}
+
+func testComputeResource(cpuUsed int, memoryUsed float32, memoryLimit float32) draas.ComputeResource {
+	resource := draas.ComputeResource{ID: "cr1", HardwarePlanID: "hp1"}
+	resource.CPU.Used = cpuUsed
+	resource.Memory.Used = memoryUsed
+	resource.Memory.Limit = memoryLimit
+
+	return resource
+}
+
+func testHardwarePlan(processorLimit int) draas.HardwarePlan {
+	hardwarePlan := draas.HardwarePlan{ID: "hp1"}
+	hardwarePlan.Limits.Processor = processorLimit
+
+	return hardwarePlan
+}
+
+func expectFailoverReplicas(service *mocks.MockDRaaSService, power bool) {
+	service.EXPECT().GetSolutionHardwarePlans("sol1", gomock.Any()).Return([]draas.HardwarePlan{{ID: "hp1"}}, nil)
+	service.EXPECT().GetSolutionHardwarePlanReplicas("sol1", "hp1", gomock.Any()).Return([]draas.Replica{
+		{ID: "r1", Name: "vm1", CPU: 2, RAM: 4, IOPS: 300, Power: power},
+		{ID: "r2", Name: "other", CPU: 2, RAM: 4, Power: true},
+	}, nil)
+}
+
+func expectFailoverReadiness(service *mocks.MockDRaaSService) {
+	service.EXPECT().GetSolutionFailoverPlan("sol1", "fp1").Return(testFailoverPlan(), nil)
+	expectFailoverReplicas(service, false)
+	service.EXPECT().GetSolution("sol1").Return(draas.Solution{ID: "sol1", IOPSTierID: "tier1"}, nil)
+	service.EXPECT().GetIOPSTier("tier1").Return(draas.IOPSTier{ID: "tier1", IOPSLimit: 500}, nil)
+	service.EXPECT().GetSolutionComputeResources("sol1", gomock.Any()).Return([]draas.ComputeResource{testComputeResource(4, 8, 16)}, nil)
+	service.EXPECT().GetSolutionHardwarePlan("sol1", "hp1").Return(testHardwarePlan(8), nil)
+}
+
+func Test_draasFailoverRunCmd_Args(t *testing.T) {
+	t.Run("ValidArgs_NoError", func(t *testing.T) {
+		err := draasFailoverRunCmd(nil).Args(nil, []string{"sol1", "fp1"})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("MissingSolution_Error", func(t *testing.T) {
+		err := draasFailoverRunCmd(nil).Args(nil, []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "missing solution", err.Error())
+	})
+
+	t.Run("MissingFailoverPlan_Error", func(t *testing.T) {
+		err := draasFailoverRunCmd(nil).Args(nil, []string{"sol1"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "missing failover plan", err.Error())
+	})
+}
+
+func Test_draasFailoverRun(t *testing.T) {
+	t.Run("ChecksPass_StartsPlanAndOutputsTimeline", func(t *testing.T) {
+		setFailoverClock(t)
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockDRaaSService(mockCtrl)
+		cmd := draasFailoverRunCmd(nil)
+		cmd.Flags().String("output", "", "")
+		cmd.ParseFlags([]string{"--output", "csv"})
+
+		expectFailoverReadiness(service)
+		service.EXPECT().StartSolutionFailoverPlan("sol1", "fp1", gomock.Any()).Return(nil)
+
+		test_output.AssertOutput(t, "time,elapsed,event\n"+
+			"2024-01-01T10:01:00Z,1m0s,Readiness check [replicas] passed: 1 replica(s) present and powered off\n"+
+			"2024-01-01T10:02:00Z,2m0s,Readiness check [iops_tier] passed: IOPS tier [tier1] with limit 500\n"+
+			"2024-01-01T10:03:00Z,3m0s,Readiness check [compute_headroom] passed: sufficient CPU and memory available\n"+
+			"2024-01-01T10:04:00Z,4m0s,Started failover plan [plan1]\n", func() {
+			err := draasFailoverRun(service, cmd, []string{"sol1", "fp1"})
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("TestDuration_WaitsAndStopsPlan", func(t *testing.T) {
+		setFailoverClock(t)
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockDRaaSService(mockCtrl)
+		cmd := draasFailoverRunCmd(nil)
+		cmd.Flags().String("output", "", "")
+		cmd.Flags().StringSlice("property", []string{}, "")
+		cmd.ParseFlags([]string{"--test-duration", "30m", "--output", "value", "--property", "event"})
+
+		gomock.InOrder(
+			service.EXPECT().GetSolutionFailoverPlan("sol1", "fp1").Return(testFailoverPlan(), nil),
+			service.EXPECT().GetSolutionHardwarePlans("sol1", gomock.Any()).Return([]draas.HardwarePlan{{ID: "hp1"}}, nil),
+			service.EXPECT().GetSolutionHardwarePlanReplicas("sol1", "hp1", gomock.Any()).Return([]draas.Replica{{Name: "vm1"}}, nil),
+		)
+		service.EXPECT().GetSolution("sol1").Return(draas.Solution{IOPSTierID: "tier1"}, nil)
+		service.EXPECT().GetIOPSTier("tier1").Return(draas.IOPSTier{ID: "tier1", IOPSLimit: 500}, nil)
+		service.EXPECT().GetSolutionComputeResources("sol1", gomock.Any()).Return([]draas.ComputeResource{}, nil)
+		gomock.InOrder(
+			service.EXPECT().StartSolutionFailoverPlan("sol1", "fp1", gomock.Any()).Return(nil),
+			service.EXPECT().GetSolutionHardwarePlans("sol1", gomock.Any()).Return([]draas.HardwarePlan{{ID: "hp1"}}, nil),
+			service.EXPECT().GetSolutionHardwarePlanReplicas("sol1", "hp1", gomock.Any()).Return([]draas.Replica{{Name: "vm1", Power: true}}, nil),
+			service.EXPECT().StopSolutionFailoverPlan("sol1", "fp1").Return(nil),
+		)
+
+		test_output.AssertOutput(t, "Readiness check [replicas] passed: 1 replica(s) present and powered off\n"+
+			"Readiness check [iops_tier] passed: IOPS tier [tier1] with limit 500\n"+
+			"Readiness check [compute_headroom] passed: sufficient CPU and memory available\n"+
+			"Started failover plan [plan1]\n"+
+			"Replica [vm1] running\n"+
+			"All replicas running\n"+
+			"Test failover running for 30m0s\n"+
+			"Stopped failover plan [plan1]\n", func() {
+			err := draasFailoverRun(service, cmd, []string{"sol1", "fp1"})
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("TestDurationWaitError_StopsPlanAndOutputsTimeline", func(t *testing.T) {
+		setFailoverClock(t)
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockDRaaSService(mockCtrl)
+		cmd := draasFailoverRunCmd(nil)
+		cmd.Flags().String("output", "", "")
+		cmd.Flags().StringSlice("property", []string{}, "")
+		cmd.ParseFlags([]string{"--test-duration", "30m", "--output", "value", "--property", "event"})
+
+		expectFailoverReadiness(service)
+		gomock.InOrder(
+			service.EXPECT().StartSolutionFailoverPlan("sol1", "fp1", gomock.Any()).Return(nil),
+			service.EXPECT().GetSolutionHardwarePlans("sol1", gomock.Any()).Return(nil, errors.New("test error")),
+			service.EXPECT().StopSolutionFailoverPlan("sol1", "fp1").Return(nil),
+		)
+
+		test_output.AssertOutput(t, "Readiness check [replicas] passed: 1 replica(s) present and powered off\n"+
+			"Readiness check [iops_tier] passed: IOPS tier [tier1] with limit 500\n"+
+			"Readiness check [compute_headroom] passed: sufficient CPU and memory available\n"+
+			"Started failover plan [plan1]\n"+
+			"Failed waiting for replicas: error waiting for command: error retrieving solution hardware plans: test error\n"+
+			"Stopped failover plan [plan1]\n", func() {
+			err := draasFailoverRun(service, cmd, []string{"sol1", "fp1"})
+			assert.NotNil(t, err)
+			assert.Equal(t, "error waiting for failover plan replicas: error waiting for command: error retrieving solution hardware plans: test error", err.Error())
+		})
+	})
+
+	t.Run("TestDurationInterrupted_StopsPlan", func(t *testing.T) {
+		setFailoverClock(t)
+		failoverSleep = func(ctx context.Context, d time.Duration) error { return context.Canceled }
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockDRaaSService(mockCtrl)
+		cmd := draasFailoverRunCmd(nil)
+		cmd.Flags().String("output", "", "")
+		cmd.Flags().StringSlice("property", []string{}, "")
+		cmd.ParseFlags([]string{"--test-duration", "30m", "--output", "value", "--property", "event"})
+
+		expectFailoverReadiness(service)
+		gomock.InOrder(
+			service.EXPECT().StartSolutionFailoverPlan("sol1", "fp1", gomock.Any()).Return(nil),
+			service.EXPECT().GetSolutionHardwarePlans("sol1", gomock.Any()).Return([]draas.HardwarePlan{{ID: "hp1"}}, nil),
+			service.EXPECT().GetSolutionHardwarePlanReplicas("sol1", "hp1", gomock.Any()).Return([]draas.Replica{{Name: "vm1", Power: true}}, nil),
+			service.EXPECT().StopSolutionFailoverPlan("sol1", "fp1").Return(errors.New("test error")),
+		)
+
+		test_output.AssertOutputFunc(t, func(stdOut string) {
+			assert.Contains(t, stdOut, "Test failover interrupted\nFailed to stop failover plan [plan1]: test error\n")
+		}, func() {
+			err := draasFailoverRun(service, cmd, []string{"sol1", "fp1"})
+			assert.NotNil(t, err)
+			assert.Equal(t, "test failover interrupted", err.Error())
+		})
+	})
+
+	t.Run("InvalidTestDuration_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockDRaaSService(mockCtrl)
+		cmd := draasFailoverRunCmd(nil)
+		cmd.ParseFlags([]string{"--test-duration", "invalid"})
+
+		err := draasFailoverRun(service, cmd, []string{"sol1", "fp1"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "invalid duration [invalid]", err.Error())
+	})
+
+	t.Run("CheckFails_ReturnsError", func(t *testing.T) {
+		setFailoverClock(t)
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockDRaaSService(mockCtrl)
+		cmd := draasFailoverRunCmd(nil)
+
+		service.EXPECT().GetSolutionFailoverPlan("sol1", "fp1").Return(testFailoverPlan(), nil)
+		expectFailoverReplicas(service, false)
+		service.EXPECT().GetSolution("sol1").Return(draas.Solution{IOPSTierID: "tier1"}, nil)
+		service.EXPECT().GetIOPSTier("tier1").Return(draas.IOPSTier{}, errors.New("test error"))
+		service.EXPECT().GetSolutionComputeResources("sol1", gomock.Any()).Return([]draas.ComputeResource{}, nil)
+
+		test_output.AssertErrorOutput(t, "Readiness check [iops_tier] failed: error retrieving IOPS tier [tier1]: test error\n", func() {
+			err := draasFailoverRun(service, cmd, []string{"sol1", "fp1"})
+			assert.NotNil(t, err)
+			assert.Equal(t, "readiness checks failed, use --force to start failover plan regardless", err.Error())
+		})
+	})
+
+	t.Run("CheckFailsWithForce_StartsPlan", func(t *testing.T) {
+		setFailoverClock(t)
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockDRaaSService(mockCtrl)
+		cmd := draasFailoverRunCmd(nil)
+		cmd.ParseFlags([]string{"--force"})
+
+		service.EXPECT().GetSolutionFailoverPlan("sol1", "fp1").Return(testFailoverPlan(), nil)
+		expectFailoverReplicas(service, true)
+		service.EXPECT().GetSolution("sol1").Return(draas.Solution{IOPSTierID: "tier1"}, nil)
+		service.EXPECT().GetIOPSTier("tier1").Return(draas.IOPSTier{IOPSLimit: 500}, nil)
+		service.EXPECT().GetSolutionComputeResources("sol1", gomock.Any()).Return([]draas.ComputeResource{testComputeResource(4, 8, 16)}, nil)
+		service.EXPECT().GetSolutionHardwarePlan("sol1", "hp1").Return(testHardwarePlan(8), nil)
+		service.EXPECT().StartSolutionFailoverPlan("sol1", "fp1", gomock.Any()).Return(nil)
+
+		test_output.AssertErrorOutput(t, "Readiness check [replicas] failed: replica [vm1] is already powered on\n", func() {
+			err := draasFailoverRun(service, cmd, []string{"sol1", "fp1"})
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("GetSolutionFailoverPlanError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockDRaaSService(mockCtrl)
+
+		service.EXPECT().GetSolutionFailoverPlan("sol1", "fp1").Return(draas.FailoverPlan{}, errors.New("test error"))
+
+		err := draasFailoverRun(service, draasFailoverRunCmd(nil), []string{"sol1", "fp1"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error retrieving solution failover plan: test error", err.Error())
+	})
+
+	t.Run("GetSolutionHardwarePlansError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockDRaaSService(mockCtrl)
+
+		service.EXPECT().GetSolutionFailoverPlan("sol1", "fp1").Return(testFailoverPlan(), nil)
+		service.EXPECT().GetSolutionHardwarePlans("sol1", gomock.Any()).Return(nil, errors.New("test error"))
+
+		err := draasFailoverRun(service, draasFailoverRunCmd(nil), []string{"sol1", "fp1"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error retrieving solution hardware plans: test error", err.Error())
+	})
+
+	t.Run("StartSolutionFailoverPlanError_ReturnsError", func(t *testing.T) {
+		setFailoverClock(t)
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockDRaaSService(mockCtrl)
+
+		expectFailoverReadiness(service)
+		service.EXPECT().StartSolutionFailoverPlan("sol1", "fp1", gomock.Any()).Return(errors.New("test error"))
+
+		err := draasFailoverRun(service, draasFailoverRunCmd(nil), []string{"sol1", "fp1"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error starting solution failover plan: test error", err.Error())
+	})
+}
+
+func Test_checkFailoverComputeHeadroom(t *testing.T) {
+	replicas := map[string]failoverReplica{
+		"vm1": {Replica: draas.Replica{Name: "vm1", CPU: 2, RAM: 4}, HardwarePlanID: "hp1"},
+	}
+
+	t.Run("InsufficientCPU_Fails", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockDRaaSService(mockCtrl)
+
+		service.EXPECT().GetSolutionComputeResources("sol1", gomock.Any()).Return([]draas.ComputeResource{testComputeResource(7, 0, 16)}, nil)
+		service.EXPECT().GetSolutionHardwarePlan("sol1", "hp1").Return(testHardwarePlan(8), nil)
+
+		check := checkFailoverComputeHeadroom(service, "sol1", replicas)
+
+		assert.False(t, check.Passed)
+		assert.Equal(t, "compute resource [cr1] requires 2 CPU with 7 of 8 used", check.Detail)
+	})
+
+	t.Run("InsufficientMemory_Fails", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockDRaaSService(mockCtrl)
+
+		service.EXPECT().GetSolutionComputeResources("sol1", gomock.Any()).Return([]draas.ComputeResource{testComputeResource(0, 14, 16)}, nil)
+		service.EXPECT().GetSolutionHardwarePlan("sol1", "hp1").Return(testHardwarePlan(8), nil)
+
+		check := checkFailoverComputeHeadroom(service, "sol1", replicas)
+
+		assert.False(t, check.Passed)
+		assert.Equal(t, "compute resource [cr1] requires 4GB memory with 14.0GB of 16.0GB used", check.Detail)
+	})
+}
+
+func Test_checkFailoverReplicas(t *testing.T) {
+	t.Run("MissingReplica_Fails", func(t *testing.T) {
+		check := checkFailoverReplicas(testFailoverPlan(), map[string]failoverReplica{})
+
+		assert.False(t, check.Passed)
+		assert.Equal(t, "no replica found for VM [vm1]", check.Detail)
+	})
+}
@@ -0,0 +1,158 @@
+package draas
+
+import (
+	"fmt"
+
+	"github.com/ans-group/cli/internal/pkg/factory"
+	"github.com/ans-group/cli/internal/pkg/output"
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/ans-group/sdk-go/pkg/service/draas"
+	"github.com/spf13/cobra"
+)
+
+func draasStatusCmd(f factory.ClientFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status [solution: id...]",
+		Short: "Shows replication health for solutions",
+		Long: "This command shows replication health for all solutions, or the specified solutions, combining replica, " +
+			"backup service, backup resource, compute resource and hardware plan data.\n\n" +
+			"Problems are flagged for replicas which are powered on (as replication isn't active whilst a replica is running, " +
+			"and the API doesn't expose replica sync times), backup resources at or above the quota threshold, and compute " +
+			"resources using more CPU, memory or storage than their hardware plan allows. Each problem is written to stderr " +
+			"and the command exits with a non-zero status, so it can be used as a monitoring probe",
+		Example: "ans draas status\nans draas status 00000000-0000-0000-0000-000000000000 --quota-threshold 80",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := f.NewClient()
+			if err != nil {
+				return err
+			}
+
+			return draasStatus(c.DRaaSService(), cmd, args)
+		},
+	}
+
+	cmd.Flags().Float64("quota-threshold", 90, "Specifies percentage of backup resource quota used at which a problem is flagged")
+
+	return cmd
+}
+
+func draasStatus(service draas.DRaaSService, cmd *cobra.Command, args []string) error {
+	quotaThreshold, _ := cmd.Flags().GetFloat64("quota-threshold")
+
+	var solutions []draas.Solution
+	if len(args) > 0 {
+		for _, arg := range args {
+			solution, err := service.GetSolution(arg)
+			if err != nil {
+				output.OutputWithErrorLevelf("Error retrieving solution [%s]: %s", arg, err)
+				continue
+			}
+
+			solutions = append(solutions, solution)
+		}
+	} else {
+		var err error
+		solutions, err = service.GetSolutions(connection.APIRequestParameters{})
+		if err != nil {
+			return fmt.Errorf("error retrieving solutions: %s", err)
+		}
+	}
+
+	var statuses []SolutionStatus
+	for _, solution := range solutions {
+		status := getSolutionStatus(service, solution, quotaThreshold)
+		for _, problem := range status.Problems {
+			output.OutputWithErrorLevelf("Solution [%s]: %s", solution.ID, problem)
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return output.CommandOutput(cmd, SolutionStatusCollection(statuses))
+}
+
+// getSolutionStatus collects replication health for solution, recording retrieval failures as problems
+func getSolutionStatus(service draas.DRaaSService, solution draas.Solution, quotaThreshold float64) SolutionStatus {
+	status := SolutionStatus{
+		SolutionID:   solution.ID,
+		SolutionName: solution.Name,
+	}
+
+	backupService, err := service.GetSolutionBackupService(solution.ID)
+	if err != nil {
+		status.Problems = append(status.Problems, fmt.Sprintf("error retrieving backup service: %s", err))
+	} else {
+		status.BackupService = backupService.Service
+	}
+
+	backupResources, err := service.GetSolutionBackupResources(solution.ID, connection.APIRequestParameters{})
+	if err != nil {
+		status.Problems = append(status.Problems, fmt.Sprintf("error retrieving backup resources: %s", err))
+	}
+	status.BackupResources = len(backupResources)
+	for _, resource := range backupResources {
+		if resource.Quota < 1 {
+			continue
+		}
+
+		used := float64(resource.UsedQuota) / float64(resource.Quota) * 100
+		if used >= quotaThreshold {
+			status.Problems = append(status.Problems, fmt.Sprintf("backup resource [%s] at %.1f%% of quota", resource.Name, used))
+		}
+	}
+
+	hardwarePlans, err := service.GetSolutionHardwarePlans(solution.ID, connection.APIRequestParameters{})
+	if err != nil {
+		status.Problems = append(status.Problems, fmt.Sprintf("error retrieving hardware plans: %s", err))
+	}
+
+	hardwarePlanProcessorLimits := make(map[string]int)
+	for _, hardwarePlan := range hardwarePlans {
+		hardwarePlanProcessorLimits[hardwarePlan.ID] = hardwarePlan.Limits.Processor
+
+		replicas, err := service.GetSolutionHardwarePlanReplicas(solution.ID, hardwarePlan.ID, connection.APIRequestParameters{})
+		if err != nil {
+			status.Problems = append(status.Problems, fmt.Sprintf("error retrieving replicas for hardware plan [%s]: %s", hardwarePlan.ID, err))
+			continue
+		}
+
+		status.Replicas += len(replicas)
+		for _, replica := range replicas {
+			if replica.Power {
+				status.Problems = append(status.Problems, fmt.Sprintf("replica [%s] is powered on, replication not active", replica.Name))
+			}
+		}
+	}
+
+	computeResources, err := service.GetSolutionComputeResources(solution.ID, connection.APIRequestParameters{})
+	if err != nil {
+		status.Problems = append(status.Problems, fmt.Sprintf("error retrieving compute resources: %s", err))
+	}
+	status.ComputeResources = len(computeResources)
+	for _, resource := range computeResources {
+		status.Problems = append(status.Problems, getComputeResourceProblems(resource, hardwarePlanProcessorLimits)...)
+	}
+
+	return status
+}
+
+// getComputeResourceProblems returns problems for a compute resource using more CPU, memory or storage than its limits
+func getComputeResourceProblems(resource draas.ComputeResource, processorLimits map[string]int) []string {
+	var problems []string
+
+	if limit, ok := processorLimits[resource.HardwarePlanID]; ok && resource.CPU.Used > limit {
+		problems = append(problems, fmt.Sprintf("compute resource [%s] oversubscribed: %d of %d CPU used", resource.ID, resource.CPU.Used, limit))
+	}
+
+	if resource.Memory.Used > resource.Memory.Limit {
+		problems = append(problems, fmt.Sprintf("compute resource [%s] oversubscribed: %.1fGB of %.1fGB memory used", resource.ID, resource.Memory.Used, resource.Memory.Limit))
+	}
+
+	for _, storage := range resource.Storage {
+		if storage.Used > storage.Limit {
+			problems = append(problems, fmt.Sprintf("compute resource [%s] oversubscribed: %dGB of %dGB storage [%s] used", resource.ID, storage.Used, storage.Limit, storage.Name))
+		}
+	}
+
+	return problems
+}
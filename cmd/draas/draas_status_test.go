@@ -0,0 +1,149 @@
+package draas
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ans-group/cli/test/mocks"
+	"github.com/ans-group/cli/test/test_output"
+	"github.com/ans-group/sdk-go/pkg/service/draas"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func expectSolutionStatus(service *mocks.MockDRaaSService, solutionID string, usedQuota float32, replicaPower bool) {
+	service.EXPECT().GetSolutionBackupService(solutionID).Return(draas.BackupService{Service: "veeam"}, nil)
+	service.EXPECT().GetSolutionBackupResources(solutionID, gomock.Any()).Return([]draas.BackupResource{{Name: "br1", Quota: 100, UsedQuota: usedQuota}}, nil)
+	service.EXPECT().GetSolutionHardwarePlans(solutionID, gomock.Any()).Return([]draas.HardwarePlan{testHardwarePlan(8)}, nil)
+	service.EXPECT().GetSolutionHardwarePlanReplicas(solutionID, "hp1", gomock.Any()).Return([]draas.Replica{{Name: "vm1", Power: replicaPower}}, nil)
+	service.EXPECT().GetSolutionComputeResources(solutionID, gomock.Any()).Return([]draas.ComputeResource{testComputeResource(4, 8, 16)}, nil)
+}
+
+func Test_draasStatus(t *testing.T) {
+	t.Run("AllSolutionsHealthy_OutputsStatus", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockDRaaSService(mockCtrl)
+		cmd := draasStatusCmd(nil)
+		cmd.Flags().String("output", "", "")
+		cmd.ParseFlags([]string{"--output", "csv"})
+
+		service.EXPECT().GetSolutions(gomock.Any()).Return([]draas.Solution{{ID: "sol1", Name: "solution1"}}, nil)
+		expectSolutionStatus(service, "sol1", 50, false)
+
+		test_output.AssertOutput(t, "solution_id,solution_name,replicas,backup_resources,compute_resources,status,problems\n"+
+			"sol1,solution1,1,1,1,OK,\n", func() {
+			err := draasStatus(service, cmd, []string{})
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("Problems_OutputsErrors", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockDRaaSService(mockCtrl)
+		cmd := draasStatusCmd(nil)
+
+		service.EXPECT().GetSolution("sol1").Return(draas.Solution{ID: "sol1"}, nil)
+		expectSolutionStatus(service, "sol1", 95, true)
+
+		test_output.AssertErrorOutput(t, "Solution [sol1]: backup resource [br1] at 95.0% of quota\n"+
+			"Solution [sol1]: replica [vm1] is powered on, replication not active\n", func() {
+			err := draasStatus(service, cmd, []string{"sol1"})
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("QuotaThreshold_FlagsBackupResource", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockDRaaSService(mockCtrl)
+		cmd := draasStatusCmd(nil)
+		cmd.ParseFlags([]string{"--quota-threshold", "50"})
+
+		service.EXPECT().GetSolution("sol1").Return(draas.Solution{ID: "sol1"}, nil)
+		expectSolutionStatus(service, "sol1", 50, false)
+
+		test_output.AssertErrorOutput(t, "Solution [sol1]: backup resource [br1] at 50.0% of quota\n", func() {
+			draasStatus(service, cmd, []string{"sol1"})
+		})
+	})
+
+	t.Run("GetSolutionError_OutputsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockDRaaSService(mockCtrl)
+
+		service.EXPECT().GetSolution("sol1").Return(draas.Solution{}, errors.New("test error"))
+
+		test_output.AssertErrorOutput(t, "Error retrieving solution [sol1]: test error\n", func() {
+			draasStatus(service, draasStatusCmd(nil), []string{"sol1"})
+		})
+	})
+
+	t.Run("GetSolutionsError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockDRaaSService(mockCtrl)
+
+		service.EXPECT().GetSolutions(gomock.Any()).Return(nil, errors.New("test error"))
+
+		err := draasStatus(service, draasStatusCmd(nil), []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error retrieving solutions: test error", err.Error())
+	})
+}
+
+func Test_getSolutionStatus(t *testing.T) {
+	t.Run("RetrievalErrors_RecordedAsProblems", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockDRaaSService(mockCtrl)
+
+		service.EXPECT().GetSolutionBackupService("sol1").Return(draas.BackupService{}, errors.New("test error 1"))
+		service.EXPECT().GetSolutionBackupResources("sol1", gomock.Any()).Return(nil, errors.New("test error 2"))
+		service.EXPECT().GetSolutionHardwarePlans("sol1", gomock.Any()).Return(nil, errors.New("test error 3"))
+		service.EXPECT().GetSolutionComputeResources("sol1", gomock.Any()).Return(nil, errors.New("test error 4"))
+
+		status := getSolutionStatus(service, draas.Solution{ID: "sol1"}, 90)
+
+		assert.Equal(t, []string{
+			"error retrieving backup service: test error 1",
+			"error retrieving backup resources: test error 2",
+			"error retrieving hardware plans: test error 3",
+			"error retrieving compute resources: test error 4",
+		}, status.Problems)
+	})
+}
+
+func Test_getComputeResourceProblems(t *testing.T) {
+	t.Run("Oversubscribed_ReturnsProblems", func(t *testing.T) {
+		resource := testComputeResource(10, 20, 16)
+		resource.Storage = append(resource.Storage, struct {
+			Name  string `json:"name"`
+			Used  int    `json:"used"`
+			Limit int    `json:"limit"`
+		}{Name: "datastore1", Used: 600, Limit: 500})
+
+		problems := getComputeResourceProblems(resource, map[string]int{"hp1": 8})
+
+		assert.Equal(t, []string{
+			"compute resource [cr1] oversubscribed: 10 of 8 CPU used",
+			"compute resource [cr1] oversubscribed: 20.0GB of 16.0GB memory used",
+			"compute resource [cr1] oversubscribed: 600GB of 500GB storage [datastore1] used",
+		}, problems)
+	})
+
+	t.Run("WithinLimits_ReturnsNoProblems", func(t *testing.T) {
+		problems := getComputeResourceProblems(testComputeResource(4, 8, 16), map[string]int{"hp1": 8})
+
+		assert.Empty(t, problems)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ans-group/cli/internal/pkg/output"
 	"github.com/ans-group/sdk-go/pkg/service/draas"
@@ -221,3 +222,71 @@ func (b BillingTypeCollection) Fields() []*output.OrderedFields {
 
 	return data
 }
+
+// FailoverTimelineEvent represents an event occurring during a failover run
+type FailoverTimelineEvent struct {
+	Time    time.Time     `json:"time"`
+	Elapsed time.Duration `json:"elapsed"`
+	Event   string        `json:"event"`
+}
+
+type FailoverTimelineEventCollection []FailoverTimelineEvent
+
+func (e FailoverTimelineEventCollection) DefaultColumns() []string {
+	return []string{"time", "elapsed", "event"}
+}
+
+func (e FailoverTimelineEventCollection) Fields() []*output.OrderedFields {
+	var data []*output.OrderedFields
+	for _, event := range e {
+		fields := output.NewOrderedFields()
+		fields.Set("time", event.Time.Format(time.RFC3339))
+		fields.Set("elapsed", event.Elapsed.Round(time.Second).String())
+		fields.Set("event", event.Event)
+
+		data = append(data, fields)
+	}
+
+	return data
+}
+
+// SolutionStatus represents replication health for a solution
+type SolutionStatus struct {
+	SolutionID       string   `json:"solution_id"`
+	SolutionName     string   `json:"solution_name"`
+	BackupService    string   `json:"backup_service"`
+	Replicas         int      `json:"replicas"`
+	BackupResources  int      `json:"backup_resources"`
+	ComputeResources int      `json:"compute_resources"`
+	Problems         []string `json:"problems"`
+}
+
+type SolutionStatusCollection []SolutionStatus
+
+func (s SolutionStatusCollection) DefaultColumns() []string {
+	return []string{"solution_id", "solution_name", "replicas", "backup_resources", "compute_resources", "status", "problems"}
+}
+
+func (s SolutionStatusCollection) Fields() []*output.OrderedFields {
+	var data []*output.OrderedFields
+	for _, status := range s {
+		state := "OK"
+		if len(status.Problems) > 0 {
+			state = "PROBLEM"
+		}
+
+		fields := output.NewOrderedFields()
+		fields.Set("solution_id", status.SolutionID)
+		fields.Set("solution_name", status.SolutionName)
+		fields.Set("backup_service", status.BackupService)
+		fields.Set("replicas", strconv.Itoa(status.Replicas))
+		fields.Set("backup_resources", strconv.Itoa(status.BackupResources))
+		fields.Set("compute_resources", strconv.Itoa(status.ComputeResources))
+		fields.Set("status", state)
+		fields.Set("problems", strings.Join(status.Problems, "; "))
+
+		data = append(data, fields)
+	}
+
+	return data
+}
@@ -47,6 +47,7 @@ func ECloudRootCmd(f factory.ClientFactory, fs afero.Fs) *cobra.Command {
 		cmd.AddCommand(ecloudHostSpecRootCmd(f))
 		cmd.AddCommand(ecloudImageRootCmd(f))
 		cmd.AddCommand(ecloudInstanceRootCmd(f))
+		cmd.AddCommand(ecloudInventoryRootCmd(f))
 		cmd.AddCommand(ecloudIOPSRootCmd(f))
 		cmd.AddCommand(ecloudIPAddressRootCmd(f))
 		cmd.AddCommand(ecloudLoadBalancerRootCmd(f))
@@ -60,6 +61,7 @@ func ECloudRootCmd(f factory.ClientFactory, fs afero.Fs) *cobra.Command {
 		cmd.AddCommand(ecloudRegionRootCmd(f))
 		cmd.AddCommand(ecloudRouterRootCmd(f))
 		cmd.AddCommand(ecloudRouterThroughputRootCmd(f))
+		cmd.AddCommand(ecloudSSHConfigCmd(f))
 		cmd.AddCommand(ecloudSSHKeyPairRootCmd(f, fs))
 		cmd.AddCommand(ecloudTagRootCmd(f))
 		cmd.AddCommand(ecloudTaskRootCmd(f))
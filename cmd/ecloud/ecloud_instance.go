@@ -3,11 +3,7 @@ package ecloud
 import (
 	"errors"
 	"fmt"
-	"os"
-	"os/exec"
-	"runtime"
 	"sort"
-	"strconv"
 	"strings"
 
 	"github.com/ans-group/cli/internal/pkg/factory"
@@ -37,6 +33,8 @@ func ecloudInstanceRootCmd(f factory.ClientFactory) *cobra.Command {
 	cmd.AddCommand(ecloudInstanceStopCmd(f))
 	cmd.AddCommand(ecloudInstanceRestartCmd(f))
 	cmd.AddCommand(ecloudInstanceSSHCmd(f))
+	cmd.AddCommand(ecloudInstanceExecCmd(f))
+	cmd.AddCommand(ecloudInstanceCpCmd(f))
 	cmd.AddCommand(ecloudInstanceMigrateCmd(f))
 	cmd.AddCommand(ecloudInstanceEncryptCmd(f))
 	cmd.AddCommand(ecloudInstanceDecryptCmd(f))
@@ -640,16 +638,13 @@ func ecloudInstanceRestart(service ecloud.ECloudService, cmd *cobra.Command, arg
 }
 
 func ecloudInstanceSSHCmd(f factory.ClientFactory) *cobra.Command {
-	example := "ans ecloud instance ssh i-abcdef12"
-	if runtime.GOOS != "windows" {
-		example += "\nans ecloud instance ssh i-abcdef12 --auto\nans ecloud instance ssh i-abcdef12 --auto --credential-name \"production\""
-	}
-
 	cmd := &cobra.Command{
-		Use:     "ssh <instance: id>",
-		Short:   "Invokes SSH for an instance",
-		Long:    "This command invokes SSH for an instance",
-		Example: example,
+		Use:   "ssh <instance: id>",
+		Short: "Invokes SSH for an instance",
+		Long: "This command invokes SSH for an instance. With --auto, the built-in SSH client is used to authenticate " +
+			"with instance credentials, pinning the instance host key on first use",
+		Example: "ans ecloud instance ssh i-abcdef12\nans ecloud instance ssh i-abcdef12 --auto\n" +
+			"ans ecloud instance ssh i-abcdef12 --auto --credential-name \"production\"",
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) < 1 {
 				return errors.New("missing instance")
@@ -660,65 +655,18 @@ func ecloudInstanceSSHCmd(f factory.ClientFactory) *cobra.Command {
 		RunE: ecloudCobraRunEFunc(f, ecloudInstanceSSH),
 	}
 
-	cmd.Flags().Int("port", 2020, "Specifies port to connect to")
-	cmd.Flags().Bool("internal", false, "Specifies internal IP should be used")
-	cmd.Flags().String("user", "root", "Specifies user to connect with")
-	cmd.Flags().String("args", "", "Specifies additional arguments to pass to SSH")
-
-	// Auto-auth flags only available on non-Windows platforms
-	if runtime.GOOS != "windows" {
-		cmd.Flags().Bool("auto", false, "Automatically authenticate using instance credentials (requires sshpass)")
-		cmd.Flags().String("credential-name", "", "Credential name to use with --auto (uses first available if not specified)")
-	}
+	addInstanceSSHFlags(cmd)
 
 	return cmd
 }
 
 func ecloudInstanceSSH(service ecloud.ECloudService, cmd *cobra.Command, args []string) error {
-	var ipAddress string
-	internal, _ := cmd.Flags().GetBool("internal")
-	if internal {
-		nics, err := service.GetInstanceNICs(args[0], connection.APIRequestParameters{})
-		if err != nil {
-			return fmt.Errorf("error retrieving instance NICs: %s", err)
-		}
-
-		if len(nics) < 1 {
-			return fmt.Errorf("no floating IPs found for instance")
-		}
-
-		ipAddress = nics[0].IPAddress
-	} else {
-		fips, err := service.GetInstanceFloatingIPs(args[0], connection.APIRequestParameters{})
-		if err != nil {
-			return fmt.Errorf("error retrieving instance floating IPs: %s", err)
-		}
-
-		if len(fips) < 1 {
-			return fmt.Errorf("no floating IPs found for instance")
-		}
-
-		ipAddress = fips[0].IPAddress
-	}
-
-	// Check if auto-auth is enabled
-	autoAuth, _ := cmd.Flags().GetBool("auto")
-	if autoAuth {
-		return ecloudInstanceSSHWithAuth(service, cmd, args[0], ipAddress)
+	target, err := getInstanceSSHTarget(service, cmd, args[0])
+	if err != nil {
+		return err
 	}
 
-	// Standard SSH exec path (existing behavior)
-	user, _ := cmd.Flags().GetString("user")
-	port, _ := cmd.Flags().GetInt("port")
-	sshArgs, _ := cmd.Flags().GetString("args")
-
-	sshCmd := exec.Command("ssh", fmt.Sprintf("%s@%s", user, ipAddress), "-p", strconv.Itoa(port), sshArgs)
-	sshCmd.Stdout = os.Stdout
-	sshCmd.Stdin = os.Stdin
-	sshCmd.Stderr = os.Stderr
-
-	_ = sshCmd.Start()
-	return sshCmd.Wait()
+	return target.Shell()
 }
 
 func ecloudInstanceMigrateCmd(f factory.ClientFactory) *cobra.Command {
@@ -0,0 +1,56 @@
+package ecloud
+
+import (
+	"fmt"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/ans-group/sdk-go/pkg/service/ecloud"
+)
+
+// instanceAddresses holds the private (NIC) and floating IP addresses of an instance
+type instanceAddresses struct {
+	Private  []string
+	Floating []string
+}
+
+// Preferred returns the first floating IP address, or the first private IP address where internal is true or
+// the instance has no floating IP. An empty string is returned if the instance has no suitable address
+func (a instanceAddresses) Preferred(internal bool) string {
+	if !internal && len(a.Floating) > 0 {
+		return a.Floating[0]
+	}
+	if len(a.Private) > 0 {
+		return a.Private[0]
+	}
+
+	return ""
+}
+
+func getInstanceAddresses(service ecloud.ECloudService, instanceID string) (instanceAddresses, error) {
+	addresses := instanceAddresses{
+		Private:  []string{},
+		Floating: []string{},
+	}
+
+	nics, err := service.GetInstanceNICs(instanceID, connection.APIRequestParameters{})
+	if err != nil {
+		return instanceAddresses{}, fmt.Errorf("error retrieving instance NICs: %s", err)
+	}
+	for _, nic := range nics {
+		if nic.IPAddress != "" {
+			addresses.Private = append(addresses.Private, nic.IPAddress)
+		}
+	}
+
+	fips, err := service.GetInstanceFloatingIPs(instanceID, connection.APIRequestParameters{})
+	if err != nil {
+		return instanceAddresses{}, fmt.Errorf("error retrieving instance floating IPs: %s", err)
+	}
+	for _, fip := range fips {
+		if fip.IPAddress != "" {
+			addresses.Floating = append(addresses.Floating, fip.IPAddress)
+		}
+	}
+
+	return addresses, nil
+}
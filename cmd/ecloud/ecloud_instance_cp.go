@@ -0,0 +1,81 @@
+package ecloud
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ans-group/cli/internal/pkg/factory"
+	"github.com/ans-group/sdk-go/pkg/service/ecloud"
+	"github.com/spf13/cobra"
+)
+
+func ecloudInstanceCpCmd(f factory.ClientFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cp <source> <destination>",
+		Short: "Copies files to or from an instance",
+		Long: "This command copies files to or from an instance via SCP, or the built-in SSH client with --auto. Exactly one " +
+			"of source or destination must be a path on an instance, in format <instance: id>:<path>",
+		Example: "ans ecloud instance cp ./app.conf i-abcdef12:/etc/app/app.conf\n" +
+			"ans ecloud instance cp i-abcdef12:/var/log/app.log ./app.log\n" +
+			"ans ecloud instance cp -r ./site i-abcdef12:/var/www --auto",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("missing source")
+			}
+			if len(args) < 2 {
+				return errors.New("missing destination")
+			}
+
+			return nil
+		},
+		RunE: ecloudCobraRunEFunc(f, ecloudInstanceCp),
+	}
+
+	addInstanceSSHFlags(cmd)
+	cmd.Flags().BoolP("recursive", "r", false, "Specifies directories should be copied recursively")
+
+	return cmd
+}
+
+func ecloudInstanceCp(service ecloud.ECloudService, cmd *cobra.Command, args []string) error {
+	source, destination := args[0], args[1]
+
+	sourceInstanceID, sourcePath, sourceRemote := parseInstancePath(source)
+	destinationInstanceID, destinationPath, destinationRemote := parseInstancePath(destination)
+	if sourceRemote == destinationRemote {
+		return errors.New("exactly one of source or destination must be an instance path, in format <instance: id>:<path>")
+	}
+
+	instanceID := sourceInstanceID
+	if destinationRemote {
+		instanceID = destinationInstanceID
+	}
+
+	target, err := getInstanceSSHTarget(service, cmd, instanceID)
+	if err != nil {
+		return err
+	}
+
+	recursive, _ := cmd.Flags().GetBool("recursive")
+	if sourceRemote {
+		err = target.Download(recursive, sourcePath, destination)
+	} else {
+		err = target.Upload(recursive, source, destinationPath)
+	}
+	if err != nil {
+		return fmt.Errorf("error copying files: %w", err)
+	}
+
+	return nil
+}
+
+// parseInstancePath parses path in format <instance: id>:<path>, returning false if path isn't an instance path
+func parseInstancePath(path string) (string, string, bool) {
+	instanceID, instancePath, found := strings.Cut(path, ":")
+	if !found || !strings.HasPrefix(instanceID, "i-") || strings.ContainsAny(instanceID, `/\`) {
+		return "", "", false
+	}
+
+	return instanceID, instancePath, true
+}
@@ -0,0 +1,76 @@
+package ecloud
+
+import (
+	"testing"
+
+	"github.com/ans-group/cli/test/mocks"
+	"github.com/ans-group/cli/test/test_output"
+	"github.com/ans-group/sdk-go/pkg/service/ecloud"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ecloudInstanceCpCmd_Args(t *testing.T) {
+	t.Run("ValidArgs_NoError", func(t *testing.T) {
+		err := ecloudInstanceCpCmd(nil).Args(nil, []string{"./file", "i-abcdef12:/tmp/file"})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("MissingDestination_Error", func(t *testing.T) {
+		err := ecloudInstanceCpCmd(nil).Args(nil, []string{"./file"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "missing destination", err.Error())
+	})
+}
+
+func Test_ecloudInstanceCp(t *testing.T) {
+	t.Run("Upload_InvokesSCP", func(t *testing.T) {
+		setEchoInstanceCommand(t)
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		cmd := ecloudInstanceCpCmd(nil)
+		cmd.ParseFlags([]string{"-r"})
+
+		service.EXPECT().GetInstanceNICs("i-abcdef12", gomock.Any()).Return([]ecloud.NIC{}, nil)
+		service.EXPECT().GetInstanceFloatingIPs("i-abcdef12", gomock.Any()).Return([]ecloud.FloatingIP{{IPAddress: "203.0.113.5"}}, nil)
+
+		test_output.AssertOutput(t, "scp -P 2020 -r ./site root@203.0.113.5:/var/www\n", func() {
+			err := ecloudInstanceCp(service, cmd, []string{"./site", "i-abcdef12:/var/www"})
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("Download_InvokesSCP", func(t *testing.T) {
+		setEchoInstanceCommand(t)
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+
+		service.EXPECT().GetInstanceNICs("i-abcdef12", gomock.Any()).Return([]ecloud.NIC{}, nil)
+		service.EXPECT().GetInstanceFloatingIPs("i-abcdef12", gomock.Any()).Return([]ecloud.FloatingIP{{IPAddress: "203.0.113.5"}}, nil)
+
+		test_output.AssertOutput(t, "scp -P 2020 root@203.0.113.5:/var/log/app.log ./app.log\n", func() {
+			err := ecloudInstanceCp(service, ecloudInstanceCpCmd(nil), []string{"i-abcdef12:/var/log/app.log", "./app.log"})
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("NoInstancePath_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+
+		err := ecloudInstanceCp(service, ecloudInstanceCpCmd(nil), []string{"./a", "./b"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "exactly one of source or destination must be an instance path, in format <instance: id>:<path>", err.Error())
+	})
+}
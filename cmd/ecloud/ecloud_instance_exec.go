@@ -0,0 +1,222 @@
+package ecloud
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ans-group/cli/internal/pkg/factory"
+	"github.com/ans-group/cli/internal/pkg/output"
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/ans-group/sdk-go/pkg/service/ecloud"
+	"github.com/spf13/cobra"
+)
+
+func ecloudInstanceExecCmd(f factory.ClientFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "exec [instance: id...] -- <command>",
+		Short: "Executes a command on instances via SSH",
+		Long: "This command executes a command on one or more instances via SSH, in parallel. Instances can be specified " +
+			"by ID, or selected with --tag. Where the command is executed on more than one instance, each line of output " +
+			"is prefixed with the instance ID",
+		Example: "ans ecloud instance exec i-abcdef12 -- uptime\n" +
+			"ans ecloud instance exec i-abcdef12 i-abcdef13 --auto -- systemctl restart nginx\n" +
+			"ans ecloud instance exec --tag env:prod --parallel 5 -- df -h",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if cmd.ArgsLenAtDash() < 0 || cmd.ArgsLenAtDash() == len(args) {
+				return errors.New("missing command, specify command after --")
+			}
+
+			return nil
+		},
+		RunE: ecloudCobraRunEFunc(f, ecloudInstanceExec),
+	}
+
+	addInstanceSSHFlags(cmd)
+	cmd.Flags().StringSlice("tag", []string{}, "Selects instances with tag, in format scope:name or name. Can be repeated, with instances required to have all tags")
+	cmd.Flags().Int("parallel", 10, "Specifies maximum number of instances to execute command on concurrently")
+
+	return cmd
+}
+
+func ecloudInstanceExec(service ecloud.ECloudService, cmd *cobra.Command, args []string) error {
+	dash := cmd.ArgsLenAtDash()
+	if dash < 0 {
+		return errors.New("missing command, specify command after --")
+	}
+
+	instanceIDs := slices.Clone(args[:dash])
+	remoteCommand := args[dash:]
+
+	tags, _ := cmd.Flags().GetStringSlice("tag")
+	if len(tags) > 0 {
+		taggedInstanceIDs, err := getInstanceIDsByTags(service, tags)
+		if err != nil {
+			return err
+		}
+		for _, instanceID := range taggedInstanceIDs {
+			if !slices.Contains(instanceIDs, instanceID) {
+				instanceIDs = append(instanceIDs, instanceID)
+			}
+		}
+	}
+
+	if len(instanceIDs) < 1 {
+		return errors.New("missing instance")
+	}
+
+	var targets []instanceSSHTarget
+	for _, instanceID := range instanceIDs {
+		target, err := getInstanceSSHTarget(service, cmd, instanceID)
+		if err != nil {
+			output.OutputWithErrorLevelf("Error resolving instance [%s]: %s", instanceID, err)
+			continue
+		}
+
+		targets = append(targets, target)
+	}
+
+	parallel, _ := cmd.Flags().GetInt("parallel")
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	prefix := len(targets) > 1
+	outputMutex := &sync.Mutex{}
+	errs := make([]error, len(targets))
+	semaphore := make(chan struct{}, parallel)
+
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		semaphore <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			errs[i] = execInstanceCommand(target, remoteCommand, prefix, outputMutex)
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			output.OutputWithErrorLevelf("Error executing command on instance [%s]: %s", targets[i].InstanceID, err)
+		}
+	}
+
+	return nil
+}
+
+func execInstanceCommand(target instanceSSHTarget, remoteCommand []string, prefix bool, outputMutex *sync.Mutex) error {
+	if !prefix {
+		return target.Run(remoteCommand, os.Stdout, os.Stderr)
+	}
+
+	stdout := newPrefixWriter(os.Stdout, fmt.Sprintf("[%s] ", target.InstanceID), outputMutex)
+	stderr := newPrefixWriter(os.Stderr, fmt.Sprintf("[%s] ", target.InstanceID), outputMutex)
+
+	err := target.Run(remoteCommand, stdout, stderr)
+	stdout.Flush()
+	stderr.Flush()
+
+	return err
+}
+
+// getInstanceIDsByTags returns the IDs of instances which have all tags, each in format scope:name or name
+func getInstanceIDsByTags(service ecloud.ECloudService, tags []string) ([]string, error) {
+	instances, err := service.GetInstances(connection.APIRequestParameters{})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving instances: %s", err)
+	}
+
+	var instanceIDs []string
+	for _, instance := range instances {
+		if instanceHasTags(instance, tags) {
+			instanceIDs = append(instanceIDs, instance.ID)
+		}
+	}
+
+	if len(instanceIDs) < 1 {
+		return nil, fmt.Errorf("no instances found with tags [%s]", strings.Join(tags, ", "))
+	}
+
+	return instanceIDs, nil
+}
+
+func instanceHasTags(instance ecloud.Instance, tags []string) bool {
+	for _, tag := range tags {
+		scope, name, scoped := strings.Cut(tag, ":")
+		if !scoped {
+			name = tag
+		}
+
+		found := false
+		for _, instanceTag := range instance.Tags {
+			if instanceTag.Name == name && (!scoped || instanceTag.Scope == scope) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// prefixWriter writes each line written to it to w with a prefix. Lines are written whole whilst holding mutex, so
+// multiple prefixWriters can share w without interleaving
+type prefixWriter struct {
+	w      io.Writer
+	prefix string
+	mutex  *sync.Mutex
+	buf    bytes.Buffer
+}
+
+func newPrefixWriter(w io.Writer, prefix string, mutex *sync.Mutex) *prefixWriter {
+	return &prefixWriter{
+		w:      w,
+		prefix: prefix,
+		mutex:  mutex,
+	}
+}
+
+func (p *prefixWriter) Write(b []byte) (int, error) {
+	p.buf.Write(b)
+
+	for {
+		line, err := p.buf.ReadBytes('\n')
+		if err != nil {
+			// Retain partial line until it's completed or flushed
+			p.buf.Write(line)
+			break
+		}
+
+		p.writeLine(line)
+	}
+
+	return len(b), nil
+}
+
+// Flush writes any remaining partial line
+func (p *prefixWriter) Flush() {
+	if p.buf.Len() > 0 {
+		p.writeLine(append(p.buf.Bytes(), '\n'))
+		p.buf.Reset()
+	}
+}
+
+func (p *prefixWriter) writeLine(line []byte) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	fmt.Fprintf(p.w, "%s%s", p.prefix, line)
+}
@@ -0,0 +1,146 @@
+package ecloud
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/ans-group/cli/test/mocks"
+	"github.com/ans-group/cli/test/test_output"
+	"github.com/ans-group/sdk-go/pkg/service/ecloud"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ecloudInstanceExecCmd_Args(t *testing.T) {
+	t.Run("ValidArgs_NoError", func(t *testing.T) {
+		cmd := ecloudInstanceExecCmd(nil)
+		cmd.Flags().Parse([]string{"i-abcdef12", "--", "uptime"})
+
+		err := cmd.Args(cmd, cmd.Flags().Args())
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("MissingCommand_Error", func(t *testing.T) {
+		cmd := ecloudInstanceExecCmd(nil)
+		cmd.Flags().Parse([]string{"i-abcdef12"})
+
+		err := cmd.Args(cmd, cmd.Flags().Args())
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "missing command, specify command after --", err.Error())
+	})
+}
+
+func Test_ecloudInstanceExec(t *testing.T) {
+	t.Run("SingleInstance_OutputsWithoutPrefix", func(t *testing.T) {
+		setEchoInstanceCommand(t)
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		cmd := ecloudInstanceExecCmd(nil)
+		cmd.Flags().Parse([]string{"i-abcdef12", "--", "uptime"})
+
+		service.EXPECT().GetInstanceNICs("i-abcdef12", gomock.Any()).Return([]ecloud.NIC{}, nil)
+		service.EXPECT().GetInstanceFloatingIPs("i-abcdef12", gomock.Any()).Return([]ecloud.FloatingIP{{IPAddress: "203.0.113.5"}}, nil)
+
+		test_output.AssertOutput(t, "ssh -p 2020 root@203.0.113.5 uptime\n", func() {
+			err := ecloudInstanceExec(service, cmd, cmd.Flags().Args())
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("Tag_OutputsWithPrefix", func(t *testing.T) {
+		setEchoInstanceCommand(t)
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		cmd := ecloudInstanceExecCmd(nil)
+		cmd.Flags().Parse([]string{"i-abcdef12", "--tag", "env:prod", "--parallel", "1", "--", "df", "-h"})
+
+		service.EXPECT().GetInstances(gomock.Any()).Return([]ecloud.Instance{
+			{ID: "i-abcdef12", Tags: []ecloud.ResourceTag{{Scope: "env", Name: "prod"}}},
+			{ID: "i-abcdef13", Tags: []ecloud.ResourceTag{{Scope: "env", Name: "prod"}}},
+			{ID: "i-abcdef14", Tags: []ecloud.ResourceTag{{Scope: "env", Name: "dev"}}},
+		}, nil)
+		service.EXPECT().GetInstanceNICs("i-abcdef12", gomock.Any()).Return([]ecloud.NIC{}, nil)
+		service.EXPECT().GetInstanceFloatingIPs("i-abcdef12", gomock.Any()).Return([]ecloud.FloatingIP{{IPAddress: "203.0.113.5"}}, nil)
+		service.EXPECT().GetInstanceNICs("i-abcdef13", gomock.Any()).Return([]ecloud.NIC{}, nil)
+		service.EXPECT().GetInstanceFloatingIPs("i-abcdef13", gomock.Any()).Return([]ecloud.FloatingIP{{IPAddress: "203.0.113.6"}}, nil)
+
+		test_output.AssertOutput(t, "[i-abcdef12] ssh -p 2020 root@203.0.113.5 df -h\n[i-abcdef13] ssh -p 2020 root@203.0.113.6 df -h\n", func() {
+			err := ecloudInstanceExec(service, cmd, cmd.Flags().Args())
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("ResolveError_OutputsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		cmd := ecloudInstanceExecCmd(nil)
+		cmd.Flags().Parse([]string{"i-abcdef12", "--", "uptime"})
+
+		service.EXPECT().GetInstanceNICs("i-abcdef12", gomock.Any()).Return(nil, errors.New("test error"))
+
+		test_output.AssertErrorOutput(t, "Error resolving instance [i-abcdef12]: error retrieving instance NICs: test error\n", func() {
+			ecloudInstanceExec(service, cmd, cmd.Flags().Args())
+		})
+	})
+
+	t.Run("NoTaggedInstances_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		cmd := ecloudInstanceExecCmd(nil)
+		cmd.Flags().Parse([]string{"--tag", "prod", "--", "uptime"})
+
+		service.EXPECT().GetInstances(gomock.Any()).Return([]ecloud.Instance{{ID: "i-abcdef12"}}, nil)
+
+		err := ecloudInstanceExec(service, cmd, cmd.Flags().Args())
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "no instances found with tags [prod]", err.Error())
+	})
+
+	t.Run("MissingInstance_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		cmd := ecloudInstanceExecCmd(nil)
+		cmd.Flags().Parse([]string{"--", "uptime"})
+
+		err := ecloudInstanceExec(service, cmd, cmd.Flags().Args())
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "missing instance", err.Error())
+	})
+}
+
+func Test_instanceHasTags(t *testing.T) {
+	instance := ecloud.Instance{Tags: []ecloud.ResourceTag{{Scope: "env", Name: "prod"}, {Scope: "role", Name: "web"}}}
+
+	assert.True(t, instanceHasTags(instance, []string{"env:prod", "web"}))
+	assert.False(t, instanceHasTags(instance, []string{"env:prod", "role:db"}))
+	assert.False(t, instanceHasTags(instance, []string{"other:prod"}))
+}
+
+func Test_prefixWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := newPrefixWriter(&buf, "[i-abcdef12] ", &sync.Mutex{})
+
+	w.Write([]byte("line 1\nline"))
+	w.Write([]byte(" 2\npartial"))
+	w.Flush()
+
+	assert.Equal(t, "[i-abcdef12] line 1\n[i-abcdef12] line 2\n[i-abcdef12] partial\n", buf.String())
+}
@@ -0,0 +1,308 @@
+package ecloud
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// This file implements the SCP protocol over the built-in SSH client, so files can be copied with --auto without
+// an scp binary. Only the file (C), directory (D/E) and timestamp (T) messages are supported
+
+// uploadInstanceSSHClientFiles copies localPath to remotePath on target with the built-in SSH client
+func uploadInstanceSSHClientFiles(target instanceSSHTarget, recursive bool, localPath string, remotePath string) error {
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return fmt.Errorf("scp: %w", err)
+	}
+	if info.IsDir() && !recursive {
+		return fmt.Errorf("scp: %s is a directory, use --recursive", localPath)
+	}
+
+	return runInstanceSCP(target, scpCommand("-t", recursive, remotePath), func(w io.Writer, r *bufio.Reader) error {
+		return sendSCP(w, r, localPath, info)
+	})
+}
+
+// downloadInstanceSSHClientFiles copies remotePath on target to localPath with the built-in SSH client
+func downloadInstanceSSHClientFiles(target instanceSSHTarget, recursive bool, remotePath string, localPath string) error {
+	return runInstanceSCP(target, scpCommand("-f", recursive, remotePath), func(w io.Writer, r *bufio.Reader) error {
+		return receiveSCP(w, r, localPath)
+	})
+}
+
+func runInstanceSCP(target instanceSSHTarget, command string, transfer func(w io.Writer, r *bufio.Reader) error) error {
+	client, err := newInstanceSSHClient(target)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return fmt.Errorf("ssh: failed to create session: %w", err)
+	}
+	defer session.Close()
+
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("ssh: failed to open session stdin: %w", err)
+	}
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("ssh: failed to open session stdout: %w", err)
+	}
+	session.Stderr = os.Stderr
+
+	if err := session.Start(command); err != nil {
+		return fmt.Errorf("scp: failed to start remote scp: %w", err)
+	}
+
+	transferErr := transfer(stdin, bufio.NewReader(stdout))
+	stdin.Close()
+	waitErr := session.Wait()
+
+	if transferErr != nil {
+		return transferErr
+	}
+
+	return waitErr
+}
+
+func scpCommand(mode string, recursive bool, remotePath string) string {
+	if recursive {
+		mode = "-r " + mode
+	}
+
+	return fmt.Sprintf("scp %s %s", mode, shellQuote(remotePath))
+}
+
+// shellQuote quotes s for use as a single argument in a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// readSCPAck reads a response from the remote scp, returning an error for warning (1) and error (2) responses
+func readSCPAck(r *bufio.Reader) error {
+	b, err := r.ReadByte()
+	if err != nil {
+		return fmt.Errorf("scp: failed to read response: %w", err)
+	}
+
+	switch b {
+	case 0:
+		return nil
+	case 1, 2:
+		message, _ := r.ReadString('\n')
+		return fmt.Errorf("scp: %s", strings.TrimSpace(message))
+	default:
+		return fmt.Errorf("scp: unexpected response [%d]", b)
+	}
+}
+
+// sendSCP sends path to the remote scp sink, recursing into directories
+func sendSCP(w io.Writer, r *bufio.Reader, path string, info fs.FileInfo) error {
+	err := readSCPAck(r)
+	if err != nil {
+		return err
+	}
+
+	return sendSCPEntry(w, r, path, info)
+}
+
+func sendSCPEntry(w io.Writer, r *bufio.Reader, path string, info fs.FileInfo) error {
+	if info.IsDir() {
+		fmt.Fprintf(w, "D%04o 0 %s\n", info.Mode().Perm(), info.Name())
+		err := readSCPAck(r)
+		if err != nil {
+			return err
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return fmt.Errorf("scp: %w", err)
+		}
+
+		for _, entry := range entries {
+			entryInfo, err := entry.Info()
+			if err != nil {
+				return fmt.Errorf("scp: %w", err)
+			}
+
+			err = sendSCPEntry(w, r, filepath.Join(path, entry.Name()), entryInfo)
+			if err != nil {
+				return err
+			}
+		}
+
+		fmt.Fprint(w, "E\n")
+		return readSCPAck(r)
+	}
+
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("scp: %s is not a regular file", path)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("scp: %w", err)
+	}
+	defer file.Close()
+
+	fmt.Fprintf(w, "C%04o %d %s\n", info.Mode().Perm(), info.Size(), info.Name())
+	err = readSCPAck(r)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.CopyN(w, file, info.Size())
+	if err != nil {
+		return fmt.Errorf("scp: failed to send %s: %w", path, err)
+	}
+
+	_, err = w.Write([]byte{0})
+	if err != nil {
+		return fmt.Errorf("scp: failed to send %s: %w", path, err)
+	}
+
+	return readSCPAck(r)
+}
+
+// receiveSCP receives files from the remote scp source into localPath. Where localPath is an existing directory,
+// entries are created within it, otherwise the first entry is created at localPath
+func receiveSCP(w io.Writer, r *bufio.Reader, localPath string) error {
+	var directories []string
+
+	entryPath := func(name string) (string, error) {
+		if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+			return "", fmt.Errorf("scp: invalid file name [%s] received", name)
+		}
+
+		if len(directories) > 0 {
+			return filepath.Join(directories[len(directories)-1], name), nil
+		}
+
+		info, err := os.Stat(localPath)
+		if err == nil && info.IsDir() {
+			return filepath.Join(localPath, name), nil
+		}
+
+		return localPath, nil
+	}
+
+	ack := func() error {
+		_, err := w.Write([]byte{0})
+		return err
+	}
+
+	err := ack()
+	if err != nil {
+		return fmt.Errorf("scp: %w", err)
+	}
+
+	for {
+		line, err := r.ReadString('\n')
+		if err == io.EOF && line == "" {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("scp: failed to read message: %w", err)
+		}
+
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return errors.New("scp: empty message received")
+		}
+
+		switch line[0] {
+		case 1, 2:
+			return fmt.Errorf("scp: %s", line[1:])
+		case 'T':
+			// Timestamps aren't preserved
+		case 'E':
+			if len(directories) < 1 {
+				return errors.New("scp: unexpected end of directory received")
+			}
+			directories = directories[:len(directories)-1]
+		case 'C', 'D':
+			mode, size, name, err := parseSCPEntry(line)
+			if err != nil {
+				return err
+			}
+
+			path, err := entryPath(name)
+			if err != nil {
+				return err
+			}
+
+			if line[0] == 'D' {
+				err = os.MkdirAll(path, mode)
+				if err != nil {
+					return fmt.Errorf("scp: %w", err)
+				}
+				directories = append(directories, path)
+				break
+			}
+
+			err = ack()
+			if err != nil {
+				return fmt.Errorf("scp: %w", err)
+			}
+
+			err = receiveSCPFile(r, path, mode, size)
+			if err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("scp: unexpected message [%s] received", line)
+		}
+
+		err = ack()
+		if err != nil {
+			return fmt.Errorf("scp: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func receiveSCPFile(r *bufio.Reader, path string, mode fs.FileMode, size int64) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return fmt.Errorf("scp: %w", err)
+	}
+	defer file.Close()
+
+	_, err = io.CopyN(file, r, size)
+	if err != nil {
+		return fmt.Errorf("scp: failed to receive %s: %w", path, err)
+	}
+
+	return readSCPAck(r)
+}
+
+// parseSCPEntry parses a file (C) or directory (D) message, in format <type><mode> <size> <name>
+func parseSCPEntry(line string) (fs.FileMode, int64, string, error) {
+	parts := strings.SplitN(line[1:], " ", 3)
+	if len(parts) != 3 {
+		return 0, 0, "", fmt.Errorf("scp: invalid message [%s] received", line)
+	}
+
+	mode, err := strconv.ParseUint(parts[0], 8, 32)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("scp: invalid mode in message [%s] received", line)
+	}
+
+	size, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || size < 0 {
+		return 0, 0, "", fmt.Errorf("scp: invalid size in message [%s] received", line)
+	}
+
+	return fs.FileMode(mode).Perm(), size, parts[2], nil
+}
@@ -0,0 +1,94 @@
+package ecloud
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_sendSCP(t *testing.T) {
+	t.Run("Directory_SendsEntries", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "site")
+		os.MkdirAll(filepath.Join(dir, "css"), 0755)
+		os.WriteFile(filepath.Join(dir, "index.html"), []byte("hello"), 0644)
+		os.WriteFile(filepath.Join(dir, "css", "app.css"), []byte("body"), 0600)
+		info, _ := os.Stat(dir)
+
+		var w bytes.Buffer
+		acks := bufio.NewReader(bytes.NewReader(make([]byte, 10)))
+
+		err := sendSCP(&w, acks, dir, info)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "D0755 0 site\nD0755 0 css\nC0600 4 app.css\nbody\x00E\nC0644 5 index.html\nhello\x00E\n", w.String())
+	})
+
+	t.Run("ErrorResponse_ReturnsError", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "file")
+		os.WriteFile(path, []byte("hello"), 0644)
+		info, _ := os.Stat(path)
+
+		acks := bufio.NewReader(strings.NewReader("\x00\x02scp: /etc/file: Permission denied\n"))
+
+		err := sendSCP(&bytes.Buffer{}, acks, path, info)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "scp: scp: /etc/file: Permission denied", err.Error())
+	})
+}
+
+func Test_receiveSCP(t *testing.T) {
+	t.Run("Directory_CreatesEntries", func(t *testing.T) {
+		dir := t.TempDir()
+		var w bytes.Buffer
+		r := bufio.NewReader(strings.NewReader("D0755 0 site\nT0 0 0 0\nC0644 5 index.html\nhello\x00D0700 0 css\nC0600 4 app.css\nbody\x00E\nE\n"))
+
+		err := receiveSCP(&w, r, dir)
+
+		assert.Nil(t, err)
+
+		content, _ := os.ReadFile(filepath.Join(dir, "site", "index.html"))
+		assert.Equal(t, "hello", string(content))
+		content, _ = os.ReadFile(filepath.Join(dir, "site", "css", "app.css"))
+		assert.Equal(t, "body", string(content))
+	})
+
+	t.Run("File_CreatesFileAtPath", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "renamed.log")
+		r := bufio.NewReader(strings.NewReader("C0644 5 app.log\nhello\x00"))
+
+		err := receiveSCP(&bytes.Buffer{}, r, path)
+
+		assert.Nil(t, err)
+
+		content, _ := os.ReadFile(path)
+		assert.Equal(t, "hello", string(content))
+	})
+
+	t.Run("PathTraversal_ReturnsError", func(t *testing.T) {
+		r := bufio.NewReader(strings.NewReader("D0755 0 site\nC0644 5 ../escape\nhello\x00"))
+
+		err := receiveSCP(&bytes.Buffer{}, r, t.TempDir())
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "scp: invalid file name [../escape] received", err.Error())
+	})
+
+	t.Run("ErrorMessage_ReturnsError", func(t *testing.T) {
+		r := bufio.NewReader(strings.NewReader("\x01scp: /missing: No such file or directory\n"))
+
+		err := receiveSCP(&bytes.Buffer{}, r, t.TempDir())
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "scp: scp: /missing: No such file or directory", err.Error())
+	})
+}
+
+func Test_shellQuote(t *testing.T) {
+	assert.Equal(t, `'/tmp/it'\''s here'`, shellQuote("/tmp/it's here"))
+}
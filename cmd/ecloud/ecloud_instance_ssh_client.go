@@ -0,0 +1,185 @@
+package ecloud
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ans-group/cli/internal/pkg/output"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// instanceSSHDialTimeout is the maximum time to wait for the built-in SSH client to connect
+var instanceSSHDialTimeout = 30 * time.Second
+
+// knownHostsMutex serialises access to known hosts files, as connections may be made concurrently
+var knownHostsMutex sync.Mutex
+
+// newInstanceSSHClient connects to target with the built-in SSH client, authenticating with the target credential
+// via password or keyboard-interactive authentication
+func newInstanceSSHClient(target instanceSSHTarget) (*ssh.Client, error) {
+	password := target.Credential.Password
+
+	config := &ssh.ClientConfig{
+		User: target.User,
+		Auth: []ssh.AuthMethod{
+			ssh.Password(password),
+			ssh.KeyboardInteractive(func(name, instruction string, questions []string, echos []bool) ([]string, error) {
+				answers := make([]string, len(questions))
+				for i := range questions {
+					answers[i] = password
+				}
+
+				return answers, nil
+			}),
+		},
+		HostKeyCallback: pinnedHostKeyCallback(target.KnownHostsPath),
+		Timeout:         instanceSSHDialTimeout,
+	}
+
+	client, err := ssh.Dial("tcp", net.JoinHostPort(target.Address, strconv.Itoa(target.Port)), config)
+	if err != nil {
+		return nil, fmt.Errorf("ssh: failed to connect: %w", err)
+	}
+
+	return client, nil
+}
+
+// pinnedHostKeyCallback returns a host key callback which verifies host keys against knownHostsPath, adding the
+// host key for hosts which aren't yet present (trust on first use)
+func pinnedHostKeyCallback(knownHostsPath string) ssh.HostKeyCallback {
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		knownHostsMutex.Lock()
+		defer knownHostsMutex.Unlock()
+
+		knownHostsFile, err := os.OpenFile(knownHostsPath, os.O_RDONLY|os.O_CREATE, 0600)
+		if err != nil {
+			return fmt.Errorf("ssh: failed to open known hosts file: %w", err)
+		}
+		knownHostsFile.Close()
+
+		callback, err := knownhosts.New(knownHostsPath)
+		if err != nil {
+			return fmt.Errorf("ssh: failed to read known hosts file: %w", err)
+		}
+
+		err = callback(hostname, remote, key)
+
+		var keyErr *knownhosts.KeyError
+		if errors.As(err, &keyErr) {
+			if len(keyErr.Want) > 0 {
+				return fmt.Errorf("ssh: host key for %s doesn't match key pinned at %s:%d, the instance may have been "+
+					"rebuilt or the connection intercepted. Remove the pinned key to trust the new key",
+					hostname, keyErr.Want[0].Filename, keyErr.Want[0].Line)
+			}
+
+			return pinHostKey(knownHostsPath, hostname, key)
+		}
+
+		return err
+	}
+}
+
+func pinHostKey(knownHostsPath string, hostname string, key ssh.PublicKey) error {
+	knownHostsFile, err := os.OpenFile(knownHostsPath, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return fmt.Errorf("ssh: failed to open known hosts file: %w", err)
+	}
+	defer knownHostsFile.Close()
+
+	_, err = fmt.Fprintln(knownHostsFile, knownhosts.Line([]string{knownhosts.Normalize(hostname)}, key))
+	if err != nil {
+		return fmt.Errorf("ssh: failed to write known hosts file: %w", err)
+	}
+
+	output.Errorf("Permanently added %s host key for %s (%s) to %s", key.Type(), hostname, ssh.FingerprintSHA256(key), knownHostsPath)
+
+	return nil
+}
+
+// runInstanceSSHClientCommand executes remoteCommand on target with the built-in SSH client
+func runInstanceSSHClientCommand(target instanceSSHTarget, remoteCommand []string, stdout io.Writer, stderr io.Writer) error {
+	client, err := newInstanceSSHClient(target)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return fmt.Errorf("ssh: failed to create session: %w", err)
+	}
+	defer session.Close()
+
+	session.Stdout = stdout
+	session.Stderr = stderr
+
+	return session.Run(strings.Join(remoteCommand, " "))
+}
+
+// runInstanceSSHClientShell starts an interactive shell on target with the built-in SSH client. Where stdin is a
+// terminal, a pseudo-terminal is requested and the local terminal is placed into raw mode for the session
+func runInstanceSSHClientShell(target instanceSSHTarget) error {
+	client, err := newInstanceSSHClient(target)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return fmt.Errorf("ssh: failed to create session: %w", err)
+	}
+	defer session.Close()
+
+	session.Stdin = os.Stdin
+	session.Stdout = os.Stdout
+	session.Stderr = os.Stderr
+
+	stdinFd := int(os.Stdin.Fd())
+	if isTerminal(stdinFd) {
+		stdoutFd := int(os.Stdout.Fd())
+		width, height, err := getTerminalSize(stdoutFd)
+		if err != nil {
+			width, height = 80, 24
+		}
+
+		termType := os.Getenv("TERM")
+		if termType == "" {
+			termType = "xterm-256color"
+		}
+
+		err = session.RequestPty(termType, height, width, ssh.TerminalModes{
+			ssh.ECHO:          1,
+			ssh.TTY_OP_ISPEED: 14400,
+			ssh.TTY_OP_OSPEED: 14400,
+		})
+		if err != nil {
+			return fmt.Errorf("ssh: failed to request pseudo-terminal: %w", err)
+		}
+
+		restore, err := makeTerminalRaw(stdinFd)
+		if err != nil {
+			return fmt.Errorf("ssh: failed to set terminal to raw mode: %w", err)
+		}
+		defer restore()
+
+		stop := watchTerminalSize(stdoutFd, func(width, height int) {
+			session.WindowChange(height, width)
+		})
+		defer stop()
+	}
+
+	if err := session.Shell(); err != nil {
+		return fmt.Errorf("ssh: failed to start shell: %w", err)
+	}
+
+	return session.Wait()
+}
@@ -0,0 +1,194 @@
+package ecloud
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/ans-group/cli/test/test_output"
+	"github.com/ans-group/sdk-go/pkg/service/ecloud"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+// newTestHostKey returns a deterministic host key signer generated from seed
+func newTestHostKey(t *testing.T, seed byte) ssh.Signer {
+	signer, err := ssh.NewSignerFromKey(ed25519.NewKeyFromSeed(bytes.Repeat([]byte{seed}, ed25519.SeedSize)))
+	assert.Nil(t, err)
+
+	return signer
+}
+
+// startTestSSHServer starts an SSH server accepting config, which responds to exec requests by echoing the
+// command. The server address and port are returned
+func startTestSSHServer(t *testing.T, config *ssh.ServerConfig) (string, int) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go serveTestSSHConn(conn, config)
+		}
+	}()
+
+	addr := listener.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port
+}
+
+func serveTestSSHConn(conn net.Conn, config *ssh.ServerConfig) {
+	defer conn.Close()
+
+	_, channels, requests, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(requests)
+
+	for newChannel := range channels {
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			return
+		}
+
+		for request := range channelRequests {
+			if request.Type != "exec" {
+				request.Reply(false, nil)
+				continue
+			}
+
+			var payload struct{ Command string }
+			ssh.Unmarshal(request.Payload, &payload)
+			request.Reply(true, nil)
+
+			fmt.Fprintf(channel, "executed: %s\n", payload.Command)
+			channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{0}))
+			channel.Close()
+		}
+	}
+}
+
+func newTestSSHServerConfig(t *testing.T, hostKeySeed byte) *ssh.ServerConfig {
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if conn.User() == "admin" && string(password) == "testpassword" {
+				return nil, nil
+			}
+
+			return nil, fmt.Errorf("invalid password")
+		},
+	}
+	config.AddHostKey(newTestHostKey(t, hostKeySeed))
+
+	return config
+}
+
+func newTestSSHTarget(t *testing.T, address string, port int, password string) instanceSSHTarget {
+	return instanceSSHTarget{
+		InstanceID:     "i-abcdef12",
+		Address:        address,
+		User:           "admin",
+		Port:           port,
+		Credential:     &ecloud.Credential{Username: "admin", Password: password},
+		KnownHostsPath: filepath.Join(t.TempDir(), "known_hosts"),
+	}
+}
+
+func Test_runInstanceSSHClientCommand(t *testing.T) {
+	t.Run("FirstUse_PinsHostKey", func(t *testing.T) {
+		address, port := startTestSSHServer(t, newTestSSHServerConfig(t, 1))
+		target := newTestSSHTarget(t, address, port, "testpassword")
+		hostname := net.JoinHostPort(address, strconv.Itoa(port))
+		hostKey := newTestHostKey(t, 1).PublicKey()
+
+		var stdout bytes.Buffer
+		test_output.AssertErrorOutput(t, fmt.Sprintf("Permanently added ssh-ed25519 host key for %s (%s) to %s\n", hostname, ssh.FingerprintSHA256(hostKey), target.KnownHostsPath), func() {
+			err := runInstanceSSHClientCommand(target, []string{"uptime"}, &stdout, &bytes.Buffer{})
+			assert.Nil(t, err)
+		})
+
+		assert.Equal(t, "executed: uptime\n", stdout.String())
+
+		knownHosts, err := os.ReadFile(target.KnownHostsPath)
+		assert.Nil(t, err)
+		assert.Contains(t, string(knownHosts), fmt.Sprintf("[%s]:%d ssh-ed25519 ", address, port))
+	})
+
+	t.Run("PinnedHostKey_Connects", func(t *testing.T) {
+		address, port := startTestSSHServer(t, newTestSSHServerConfig(t, 1))
+		target := newTestSSHTarget(t, address, port, "testpassword")
+
+		test_output.AssertErrorOutputFunc(t, func(stdErr string) {}, func() {
+			err := runInstanceSSHClientCommand(target, []string{"uptime"}, &bytes.Buffer{}, &bytes.Buffer{})
+			assert.Nil(t, err)
+		})
+
+		var stdout bytes.Buffer
+		test_output.AssertErrorOutput(t, "", func() {
+			err := runInstanceSSHClientCommand(target, []string{"df", "-h"}, &stdout, &bytes.Buffer{})
+			assert.Nil(t, err)
+		})
+
+		assert.Equal(t, "executed: df -h\n", stdout.String())
+	})
+
+	t.Run("ChangedHostKey_ReturnsError", func(t *testing.T) {
+		address, port := startTestSSHServer(t, newTestSSHServerConfig(t, 2))
+		target := newTestSSHTarget(t, address, port, "testpassword")
+
+		pinnedKey := newTestHostKey(t, 1).PublicKey()
+		err := pinHostKey(target.KnownHostsPath, net.JoinHostPort(address, strconv.Itoa(port)), pinnedKey)
+		assert.Nil(t, err)
+
+		err = runInstanceSSHClientCommand(target, []string{"uptime"}, &bytes.Buffer{}, &bytes.Buffer{})
+
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), fmt.Sprintf("doesn't match key pinned at %s:1", target.KnownHostsPath))
+	})
+
+	t.Run("KeyboardInteractive_Connects", func(t *testing.T) {
+		config := &ssh.ServerConfig{
+			KeyboardInteractiveCallback: func(conn ssh.ConnMetadata, client ssh.KeyboardInteractiveChallenge) (*ssh.Permissions, error) {
+				answers, err := client("", "", []string{"Password: "}, []bool{false})
+				if err != nil || len(answers) != 1 || answers[0] != "testpassword" {
+					return nil, fmt.Errorf("invalid password")
+				}
+
+				return nil, nil
+			},
+		}
+		config.AddHostKey(newTestHostKey(t, 1))
+		address, port := startTestSSHServer(t, config)
+		target := newTestSSHTarget(t, address, port, "testpassword")
+
+		var stdout bytes.Buffer
+		test_output.AssertErrorOutputFunc(t, func(stdErr string) {}, func() {
+			err := runInstanceSSHClientCommand(target, []string{"uptime"}, &stdout, &bytes.Buffer{})
+			assert.Nil(t, err)
+		})
+
+		assert.Equal(t, "executed: uptime\n", stdout.String())
+	})
+
+	t.Run("InvalidPassword_ReturnsError", func(t *testing.T) {
+		address, port := startTestSSHServer(t, newTestSSHServerConfig(t, 1))
+		target := newTestSSHTarget(t, address, port, "invalid")
+
+		test_output.AssertErrorOutputFunc(t, func(stdErr string) {}, func() {
+			err := runInstanceSSHClientCommand(target, []string{"uptime"}, &bytes.Buffer{}, &bytes.Buffer{})
+
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), "ssh: failed to connect: ssh: handshake failed")
+		})
+	})
+}
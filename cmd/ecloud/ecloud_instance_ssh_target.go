@@ -0,0 +1,239 @@
+package ecloud
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/ans-group/sdk-go/pkg/service/ecloud"
+	"github.com/spf13/cobra"
+)
+
+// instanceKnownHostsFileName is the name of the file within the user's home directory used for pinning instance
+// host keys when connecting with --auto
+const instanceKnownHostsFileName = ".ans_known_hosts"
+
+// newInstanceCommand is used to create SSH/SCP commands, and is overridden in tests
+var newInstanceCommand = exec.Command
+
+// addInstanceSSHFlags adds the flags used to connect to instances via SSH
+func addInstanceSSHFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("port", 2020, "Specifies port to connect to")
+	cmd.Flags().Bool("internal", false, "Specifies internal IP should be used")
+	cmd.Flags().String("user", "root", "Specifies user to connect with")
+	cmd.Flags().String("args", "", "Specifies additional arguments to pass to SSH. Not supported with --auto")
+	cmd.Flags().Bool("auto", false, "Automatically authenticate using instance credentials, with the built-in SSH client")
+	cmd.Flags().String("credential-name", "", "Credential name to use with --auto (uses first available if not specified)")
+	cmd.Flags().String("known-hosts-file", "", fmt.Sprintf("Specifies file for pinning instance host keys with --auto (default is $HOME/%s)", instanceKnownHostsFileName))
+}
+
+// instanceSSHTarget holds the details required to connect to an instance via SSH
+type instanceSSHTarget struct {
+	InstanceID string
+	Address    string
+	User       string
+	Port       int
+	Args       []string
+	// Credential is set when authenticating automatically using instance credentials, in which case the
+	// built-in SSH client is used rather than invoking ssh/scp
+	Credential     *ecloud.Credential
+	KnownHostsPath string
+}
+
+// getInstanceSSHTarget resolves the address of instance instanceID, and its credential where --auto is specified
+func getInstanceSSHTarget(service ecloud.ECloudService, cmd *cobra.Command, instanceID string) (instanceSSHTarget, error) {
+	internal, _ := cmd.Flags().GetBool("internal")
+	user, _ := cmd.Flags().GetString("user")
+	port, _ := cmd.Flags().GetInt("port")
+	sshArgs, _ := cmd.Flags().GetString("args")
+	autoAuth, _ := cmd.Flags().GetBool("auto")
+
+	if autoAuth && sshArgs != "" {
+		return instanceSSHTarget{}, errors.New("--args isn't supported with --auto")
+	}
+
+	addresses, err := getInstanceAddresses(service, instanceID)
+	if err != nil {
+		return instanceSSHTarget{}, err
+	}
+
+	target := instanceSSHTarget{
+		InstanceID: instanceID,
+		User:       user,
+		Port:       port,
+		Args:       strings.Fields(sshArgs),
+	}
+
+	if internal {
+		if len(addresses.Private) < 1 {
+			return instanceSSHTarget{}, fmt.Errorf("no internal IPs found for instance")
+		}
+		target.Address = addresses.Private[0]
+	} else {
+		if len(addresses.Floating) < 1 {
+			return instanceSSHTarget{}, fmt.Errorf("no floating IPs found for instance")
+		}
+		target.Address = addresses.Floating[0]
+	}
+
+	if autoAuth {
+		target.Credential, err = selectCredential(service, cmd, instanceID)
+		if err != nil {
+			return instanceSSHTarget{}, err
+		}
+
+		if target.Credential.Username != "" {
+			target.User = target.Credential.Username
+		}
+
+		target.KnownHostsPath, err = getInstanceKnownHostsPath(cmd)
+		if err != nil {
+			return instanceSSHTarget{}, err
+		}
+	}
+
+	return target, nil
+}
+
+func getInstanceKnownHostsPath(cmd *cobra.Command) (string, error) {
+	knownHostsPath, _ := cmd.Flags().GetString("known-hosts-file")
+	if knownHostsPath != "" {
+		return knownHostsPath, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("ssh: failed to determine home directory for known hosts file: %s", err)
+	}
+
+	return filepath.Join(home, instanceKnownHostsFileName), nil
+}
+
+// Destination returns the user@address destination for the target
+func (t instanceSSHTarget) Destination() string {
+	return fmt.Sprintf("%s@%s", t.User, t.Address)
+}
+
+// Shell starts an interactive shell on the target, attached to the terminal
+func (t instanceSSHTarget) Shell() error {
+	if t.Credential != nil {
+		return runInstanceSSHClientShell(t)
+	}
+
+	sshCmd := newInstanceCommand("ssh", t.sshArgs()...)
+	sshCmd.Stdout = os.Stdout
+	sshCmd.Stdin = os.Stdin
+	sshCmd.Stderr = os.Stderr
+
+	if err := sshCmd.Start(); err != nil {
+		return fmt.Errorf("ssh: failed to start ssh command: %w", err)
+	}
+
+	return sshCmd.Wait()
+}
+
+// Run executes remoteCommand on the target, writing its output to stdout and stderr
+func (t instanceSSHTarget) Run(remoteCommand []string, stdout io.Writer, stderr io.Writer) error {
+	if t.Credential != nil {
+		return runInstanceSSHClientCommand(t, remoteCommand, stdout, stderr)
+	}
+
+	sshCmd := newInstanceCommand("ssh", t.sshArgs(remoteCommand...)...)
+	sshCmd.Stdout = stdout
+	sshCmd.Stderr = stderr
+
+	return sshCmd.Run()
+}
+
+// Upload copies localPath to remotePath on the target
+func (t instanceSSHTarget) Upload(recursive bool, localPath string, remotePath string) error {
+	if t.Credential != nil {
+		return uploadInstanceSSHClientFiles(t, recursive, localPath, remotePath)
+	}
+
+	return t.scp(recursive, localPath, t.scpPath(remotePath))
+}
+
+// Download copies remotePath on the target to localPath
+func (t instanceSSHTarget) Download(recursive bool, remotePath string, localPath string) error {
+	if t.Credential != nil {
+		return downloadInstanceSSHClientFiles(t, recursive, remotePath, localPath)
+	}
+
+	return t.scp(recursive, t.scpPath(remotePath), localPath)
+}
+
+func (t instanceSSHTarget) sshArgs(remoteCommand ...string) []string {
+	args := append([]string{"-p", strconv.Itoa(t.Port)}, t.Args...)
+	args = append(args, t.Destination())
+
+	return append(args, remoteCommand...)
+}
+
+func (t instanceSSHTarget) scp(recursive bool, source string, destination string) error {
+	args := append([]string{"-P", strconv.Itoa(t.Port)}, t.Args...)
+	if recursive {
+		args = append(args, "-r")
+	}
+	args = append(args, source, destination)
+
+	scpCmd := newInstanceCommand("scp", args...)
+	scpCmd.Stdout = os.Stdout
+	scpCmd.Stdin = os.Stdin
+	scpCmd.Stderr = os.Stderr
+
+	return scpCmd.Run()
+}
+
+func (t instanceSSHTarget) scpPath(path string) string {
+	return fmt.Sprintf("%s:%s", t.Destination(), path)
+}
+
+func selectCredential(service ecloud.ECloudService, cmd *cobra.Command, instanceID string) (*ecloud.Credential, error) {
+	credentialName, _ := cmd.Flags().GetString("credential-name")
+	user, _ := cmd.Flags().GetString("user")
+
+	params := connection.APIRequestParameters{}
+	if credentialName != "" {
+		params.WithFilter(connection.APIRequestFiltering{
+			Property: "name",
+			Operator: connection.EQOperator,
+			Value:    []string{credentialName},
+		})
+	}
+
+	credentials, err := service.GetInstanceCredentials(instanceID, params)
+	if err != nil {
+		return nil, fmt.Errorf("ssh: failed to retrieve credentials: %w", err)
+	}
+
+	if len(credentials) == 0 {
+		if credentialName != "" {
+			return nil, fmt.Errorf("ssh: credential '%s' not found for instance %s", credentialName, instanceID)
+		}
+		return nil, fmt.Errorf("ssh: no credentials found for instance %s", instanceID)
+	}
+
+	// Selection logic:
+	// 1. If credential-name flag provided, already filtered - use first result
+	// 2. Otherwise, match username from --user flag
+	// 3. Fallback to first available credential
+
+	if credentialName != "" {
+		return &credentials[0], nil
+	}
+
+	for i := range credentials {
+		if credentials[i].Username == user {
+			return &credentials[i], nil
+		}
+	}
+
+	return &credentials[0], nil
+}
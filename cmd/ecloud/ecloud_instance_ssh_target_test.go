@@ -0,0 +1,128 @@
+package ecloud
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+
+	"github.com/ans-group/cli/test/mocks"
+	"github.com/ans-group/sdk-go/pkg/service/ecloud"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+// setEchoInstanceCommand replaces SSH/SCP commands with echo, so the command line is output rather than executed
+func setEchoInstanceCommand(t *testing.T) {
+	oldNewInstanceCommand := newInstanceCommand
+	newInstanceCommand = func(name string, args ...string) *exec.Cmd {
+		return exec.Command("echo", append([]string{name}, args...)...)
+	}
+	t.Cleanup(func() { newInstanceCommand = oldNewInstanceCommand })
+}
+
+func newInstanceSSHFlagsCmd(args ...string) *cobra.Command {
+	cmd := &cobra.Command{}
+	addInstanceSSHFlags(cmd)
+	cmd.ParseFlags(args)
+
+	return cmd
+}
+
+func Test_getInstanceSSHTarget(t *testing.T) {
+	t.Run("FloatingIP_ReturnsTarget", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+
+		service.EXPECT().GetInstanceNICs("i-abcdef12", gomock.Any()).Return([]ecloud.NIC{{IPAddress: "10.0.0.5"}}, nil)
+		service.EXPECT().GetInstanceFloatingIPs("i-abcdef12", gomock.Any()).Return([]ecloud.FloatingIP{{IPAddress: "203.0.113.5"}}, nil)
+
+		target, err := getInstanceSSHTarget(service, newInstanceSSHFlagsCmd("--args", "-v -A"), "i-abcdef12")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "root@203.0.113.5", target.Destination())
+		assert.Equal(t, 2020, target.Port)
+		assert.Equal(t, []string{"-v", "-A"}, target.Args)
+	})
+
+	t.Run("Internal_ReturnsTargetWithInternalIP", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+
+		service.EXPECT().GetInstanceNICs("i-abcdef12", gomock.Any()).Return([]ecloud.NIC{{IPAddress: "10.0.0.5"}}, nil)
+		service.EXPECT().GetInstanceFloatingIPs("i-abcdef12", gomock.Any()).Return([]ecloud.FloatingIP{{IPAddress: "203.0.113.5"}}, nil)
+
+		target, err := getInstanceSSHTarget(service, newInstanceSSHFlagsCmd("--internal", "--user", "admin"), "i-abcdef12")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "admin@10.0.0.5", target.Destination())
+	})
+
+	t.Run("Auto_ReturnsTargetWithCredential", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		cmd := newInstanceSSHFlagsCmd("--auto", "--user", "admin", "--known-hosts-file", "/tmp/known_hosts")
+
+		service.EXPECT().GetInstanceNICs("i-abcdef12", gomock.Any()).Return([]ecloud.NIC{}, nil)
+		service.EXPECT().GetInstanceFloatingIPs("i-abcdef12", gomock.Any()).Return([]ecloud.FloatingIP{{IPAddress: "203.0.113.5"}}, nil)
+		service.EXPECT().GetInstanceCredentials("i-abcdef12", gomock.Any()).Return([]ecloud.Credential{
+			{Username: "root", Password: "rootpass"},
+			{Username: "admin", Password: "adminpass"},
+		}, nil)
+
+		target, err := getInstanceSSHTarget(service, cmd, "i-abcdef12")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "admin@203.0.113.5", target.Destination())
+		assert.Equal(t, "adminpass", target.Credential.Password)
+		assert.Equal(t, "/tmp/known_hosts", target.KnownHostsPath)
+	})
+
+	t.Run("AutoWithArgs_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+
+		_, err := getInstanceSSHTarget(service, newInstanceSSHFlagsCmd("--auto", "--args", "-v"), "i-abcdef12")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "--args isn't supported with --auto", err.Error())
+	})
+
+	t.Run("NoFloatingIP_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+
+		service.EXPECT().GetInstanceNICs("i-abcdef12", gomock.Any()).Return([]ecloud.NIC{{IPAddress: "10.0.0.5"}}, nil)
+		service.EXPECT().GetInstanceFloatingIPs("i-abcdef12", gomock.Any()).Return([]ecloud.FloatingIP{}, nil)
+
+		_, err := getInstanceSSHTarget(service, newInstanceSSHFlagsCmd(), "i-abcdef12")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "no floating IPs found for instance", err.Error())
+	})
+
+	t.Run("GetInstanceFloatingIPsError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+
+		service.EXPECT().GetInstanceNICs("i-abcdef12", gomock.Any()).Return([]ecloud.NIC{}, nil)
+		service.EXPECT().GetInstanceFloatingIPs("i-abcdef12", gomock.Any()).Return(nil, errors.New("test error"))
+
+		_, err := getInstanceSSHTarget(service, newInstanceSSHFlagsCmd(), "i-abcdef12")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error retrieving instance floating IPs: test error", err.Error())
+	})
+}
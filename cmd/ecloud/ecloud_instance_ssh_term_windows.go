@@ -0,0 +1,49 @@
+//go:build windows
+
+package ecloud
+
+import (
+	"golang.org/x/sys/windows"
+)
+
+func isTerminal(fd int) bool {
+	var mode uint32
+	err := windows.GetConsoleMode(windows.Handle(fd), &mode)
+	return err == nil
+}
+
+// makeTerminalRaw places console fd into raw mode, returning a function which restores its previous state
+func makeTerminalRaw(fd int) (func(), error) {
+	var oldMode uint32
+	err := windows.GetConsoleMode(windows.Handle(fd), &oldMode)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := oldMode &^ (windows.ENABLE_ECHO_INPUT | windows.ENABLE_PROCESSED_INPUT | windows.ENABLE_LINE_INPUT | windows.ENABLE_PROCESSED_OUTPUT)
+	mode |= windows.ENABLE_VIRTUAL_TERMINAL_INPUT
+
+	err = windows.SetConsoleMode(windows.Handle(fd), mode)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() {
+		windows.SetConsoleMode(windows.Handle(fd), oldMode)
+	}, nil
+}
+
+func getTerminalSize(fd int) (int, int, error) {
+	var info windows.ConsoleScreenBufferInfo
+	err := windows.GetConsoleScreenBufferInfo(windows.Handle(fd), &info)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return int(info.Window.Right - info.Window.Left + 1), int(info.Window.Bottom - info.Window.Top + 1), nil
+}
+
+// watchTerminalSize is a no-op on Windows, as there's no signal raised when the console is resized
+func watchTerminalSize(fd int, resize func(width, height int)) func() {
+	return func() {}
+}
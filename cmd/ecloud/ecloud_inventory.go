@@ -0,0 +1,243 @@
+package ecloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/ans-group/cli/internal/pkg/factory"
+	"github.com/ans-group/cli/internal/pkg/helper"
+	"github.com/ans-group/cli/internal/pkg/output"
+	"github.com/ans-group/sdk-go/pkg/service/ecloud"
+	"github.com/spf13/cobra"
+)
+
+func ecloudInventoryRootCmd(f factory.ClientFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inventory",
+		Short: "sub-commands relating to inventories",
+	}
+
+	// Child commands
+	cmd.AddCommand(ecloudInventoryAnsibleCmd(f))
+
+	return cmd
+}
+
+func ecloudInventoryAnsibleCmd(f factory.ClientFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ansible",
+		Short: "Outputs an Ansible dynamic inventory of instances",
+		Long: "This command outputs instances as an Ansible dynamic inventory in JSON format, following the inventory script " +
+			"protocol (--list / --host). Instances are grouped by VPC (vpc_<id>), availability zone (az_<id>), platform " +
+			"(platform_<platform>) and tag (tag_<scope>_<name>). Host variables include the instance ID, image, private " +
+			"and floating IP addresses, with ansible_host set to the first floating IP, or first private IP where the " +
+			"instance has no floating IP. To use with Ansible, pass an executable script which runs 'ans ecloud inventory ansible \"$@\"' " +
+			"as the inventory source",
+		Example: "ans ecloud inventory ansible --list\n" +
+			"ans ecloud inventory ansible --host i-abcdef12\n" +
+			"ans ecloud inventory ansible --list --filter vpc_id=vpc-abcdef12\n" +
+			"ansible-playbook -i ecloud_inventory.sh playbook.yml",
+		RunE: ecloudCobraRunEFunc(f, ecloudInventoryAnsible),
+	}
+
+	cmd.Flags().Bool("list", false, "Outputs full inventory. This is the default behaviour")
+	cmd.Flags().String("host", "", "Outputs host variables for specified host")
+	cmd.Flags().String("hostname", "id", "Specifies instance property to use as inventory hostname (id/name)")
+	cmd.Flags().String("name", "", "Instance name for filtering")
+	cmd.MarkFlagsMutuallyExclusive("list", "host")
+
+	return cmd
+}
+
+// AnsibleInventoryGroup represents a group within an Ansible dynamic inventory
+type AnsibleInventoryGroup struct {
+	Hosts    []string `json:"hosts,omitempty"`
+	Children []string `json:"children,omitempty"`
+}
+
+// AnsibleInventoryHostVars represents the variables for a single host within an Ansible dynamic inventory
+type AnsibleInventoryHostVars struct {
+	AnsibleHost        string   `json:"ansible_host,omitempty"`
+	ID                 string   `json:"ecloud_instance_id"`
+	Name               string   `json:"ecloud_instance_name"`
+	VPCID              string   `json:"ecloud_vpc_id"`
+	AvailabilityZoneID string   `json:"ecloud_availability_zone_id"`
+	Platform           string   `json:"ecloud_platform"`
+	ImageID            string   `json:"ecloud_image_id"`
+	ImageName          string   `json:"ecloud_image_name,omitempty"`
+	PrivateIPs         []string `json:"ecloud_private_ips"`
+	FloatingIPs        []string `json:"ecloud_floating_ips"`
+	Tags               []string `json:"ecloud_tags"`
+}
+
+// AnsibleInventory represents an Ansible dynamic inventory. It is marshalled with groups at the top level alongside
+// the _meta key, as expected by the inventory script protocol
+type AnsibleInventory struct {
+	Groups   map[string]*AnsibleInventoryGroup
+	HostVars map[string]AnsibleInventoryHostVars
+}
+
+func (i AnsibleInventory) MarshalJSON() ([]byte, error) {
+	inventory := map[string]any{
+		"_meta": map[string]any{
+			"hostvars": i.HostVars,
+		},
+	}
+
+	for name, group := range i.Groups {
+		inventory[name] = group
+	}
+
+	return json.Marshal(inventory)
+}
+
+func ecloudInventoryAnsible(service ecloud.ECloudService, cmd *cobra.Command, args []string) error {
+	hostnameProperty, _ := cmd.Flags().GetString("hostname")
+	if hostnameProperty != "id" && hostnameProperty != "name" {
+		return fmt.Errorf("invalid hostname property [%s], expected id or name", hostnameProperty)
+	}
+
+	params, err := helper.GetAPIRequestParametersFromFlags(cmd, helper.NewStringFilterFlagOption("name", "name"))
+	if err != nil {
+		return err
+	}
+
+	instances, err := service.GetInstances(params)
+	if err != nil {
+		return fmt.Errorf("error retrieving instances: %s", err)
+	}
+
+	if cmd.Flags().Changed("host") {
+		host, _ := cmd.Flags().GetString("host")
+		instances = slices.DeleteFunc(instances, func(instance ecloud.Instance) bool {
+			return getAnsibleInventoryHostname(instance, hostnameProperty) != host
+		})
+	}
+
+	inventory, err := getAnsibleInventory(service, instances, hostnameProperty)
+	if err != nil {
+		return err
+	}
+
+	var out []byte
+	if cmd.Flags().Changed("host") {
+		host, _ := cmd.Flags().GetString("host")
+		hostVars, ok := inventory.HostVars[host]
+		if !ok {
+			out, err = json.MarshalIndent(map[string]any{}, "", "  ")
+		} else {
+			out, err = json.MarshalIndent(hostVars, "", "  ")
+		}
+	} else {
+		out, err = json.MarshalIndent(inventory, "", "  ")
+	}
+	if err != nil {
+		return fmt.Errorf("error marshalling inventory: %s", err)
+	}
+
+	fmt.Println(string(out))
+
+	return nil
+}
+
+func getAnsibleInventory(service ecloud.ECloudService, instances []ecloud.Instance, hostnameProperty string) (AnsibleInventory, error) {
+	inventory := AnsibleInventory{
+		Groups:   map[string]*AnsibleInventoryGroup{"all": {}},
+		HostVars: make(map[string]AnsibleInventoryHostVars),
+	}
+
+	imageNames := make(map[string]string)
+	addHost := func(groupName string, host string) {
+		group, ok := inventory.Groups[groupName]
+		if !ok {
+			group = &AnsibleInventoryGroup{}
+			inventory.Groups[groupName] = group
+			inventory.Groups["all"].Children = append(inventory.Groups["all"].Children, groupName)
+		}
+		group.Hosts = append(group.Hosts, host)
+	}
+
+	for _, instance := range instances {
+		hostname := getAnsibleInventoryHostname(instance, hostnameProperty)
+		if _, exists := inventory.HostVars[hostname]; exists {
+			return AnsibleInventory{}, fmt.Errorf("multiple instances with hostname [%s], use --hostname id", hostname)
+		}
+
+		hostVars, err := getAnsibleInventoryHostVars(service, instance, imageNames)
+		if err != nil {
+			return AnsibleInventory{}, fmt.Errorf("error retrieving host variables for instance [%s]: %s", instance.ID, err)
+		}
+		inventory.HostVars[hostname] = hostVars
+
+		addHost(sanitiseAnsibleGroupName("vpc_"+instance.VPCID), hostname)
+		addHost(sanitiseAnsibleGroupName("az_"+instance.AvailabilityZoneID), hostname)
+		if instance.Platform != "" {
+			addHost(sanitiseAnsibleGroupName("platform_"+instance.Platform), hostname)
+		}
+		for _, tag := range instance.Tags {
+			addHost(sanitiseAnsibleGroupName(fmt.Sprintf("tag_%s_%s", tag.Scope, tag.Name)), hostname)
+		}
+	}
+
+	slices.Sort(inventory.Groups["all"].Children)
+
+	return inventory, nil
+}
+
+func getAnsibleInventoryHostVars(service ecloud.ECloudService, instance ecloud.Instance, imageNames map[string]string) (AnsibleInventoryHostVars, error) {
+	hostVars := AnsibleInventoryHostVars{
+		ID:                 instance.ID,
+		Name:               instance.Name,
+		VPCID:              instance.VPCID,
+		AvailabilityZoneID: instance.AvailabilityZoneID,
+		Platform:           instance.Platform,
+		ImageID:            instance.ImageID,
+		Tags:               []string{},
+	}
+
+	if instance.ImageID != "" {
+		imageName, ok := imageNames[instance.ImageID]
+		if !ok {
+			image, err := service.GetImage(instance.ImageID)
+			if err != nil {
+				// Images may be removed or inaccessible after an instance is built, so this isn't fatal
+				output.Errorf("Error retrieving image [%s]: %s", instance.ImageID, err)
+			}
+			imageName = image.Name
+			imageNames[instance.ImageID] = imageName
+		}
+		hostVars.ImageName = imageName
+	}
+
+	addresses, err := getInstanceAddresses(service, instance.ID)
+	if err != nil {
+		return AnsibleInventoryHostVars{}, err
+	}
+	hostVars.PrivateIPs = addresses.Private
+	hostVars.FloatingIPs = addresses.Floating
+	hostVars.AnsibleHost = addresses.Preferred(false)
+
+	for _, tag := range instance.Tags {
+		hostVars.Tags = append(hostVars.Tags, fmt.Sprintf("%s:%s", tag.Scope, tag.Name))
+	}
+
+	return hostVars, nil
+}
+
+func getAnsibleInventoryHostname(instance ecloud.Instance, hostnameProperty string) string {
+	if hostnameProperty == "name" {
+		return instance.Name
+	}
+
+	return instance.ID
+}
+
+var ansibleGroupNameInvalidChars = regexp.MustCompile(`[^a-z0-9_]`)
+
+// sanitiseAnsibleGroupName lower-cases name and replaces characters which aren't valid in Ansible group names
+func sanitiseAnsibleGroupName(name string) string {
+	return ansibleGroupNameInvalidChars.ReplaceAllString(strings.ToLower(name), "_")
+}
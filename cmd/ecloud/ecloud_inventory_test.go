@@ -0,0 +1,232 @@
+package ecloud
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ans-group/cli/test/mocks"
+	"github.com/ans-group/cli/test/test_output"
+	"github.com/ans-group/sdk-go/pkg/service/ecloud"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ecloudInventoryAnsible(t *testing.T) {
+	t.Run("List_OutputsInventory", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		cmd := ecloudInventoryAnsibleCmd(nil)
+		cmd.ParseFlags([]string{"--list"})
+
+		service.EXPECT().GetInstances(gomock.Any()).Return([]ecloud.Instance{
+			{
+				ID:                 "i-abcdef12",
+				Name:               "web01",
+				VPCID:              "vpc-abcdef12",
+				AvailabilityZoneID: "az-abcdef12",
+				ImageID:            "img-abcdef12",
+				Platform:           "Linux",
+				Tags:               []ecloud.ResourceTag{{Scope: "env", Name: "prod"}},
+			},
+		}, nil)
+		service.EXPECT().GetImage("img-abcdef12").Return(ecloud.Image{Name: "Ubuntu 22.04"}, nil)
+		service.EXPECT().GetInstanceNICs("i-abcdef12", gomock.Any()).Return([]ecloud.NIC{{IPAddress: "10.0.0.5"}}, nil)
+		service.EXPECT().GetInstanceFloatingIPs("i-abcdef12", gomock.Any()).Return([]ecloud.FloatingIP{{IPAddress: "203.0.113.5"}}, nil)
+
+		test_output.AssertOutput(t, `{
+  "_meta": {
+    "hostvars": {
+      "i-abcdef12": {
+        "ansible_host": "203.0.113.5",
+        "ecloud_instance_id": "i-abcdef12",
+        "ecloud_instance_name": "web01",
+        "ecloud_vpc_id": "vpc-abcdef12",
+        "ecloud_availability_zone_id": "az-abcdef12",
+        "ecloud_platform": "Linux",
+        "ecloud_image_id": "img-abcdef12",
+        "ecloud_image_name": "Ubuntu 22.04",
+        "ecloud_private_ips": [
+          "10.0.0.5"
+        ],
+        "ecloud_floating_ips": [
+          "203.0.113.5"
+        ],
+        "ecloud_tags": [
+          "env:prod"
+        ]
+      }
+    }
+  },
+  "all": {
+    "children": [
+      "az_az_abcdef12",
+      "platform_linux",
+      "tag_env_prod",
+      "vpc_vpc_abcdef12"
+    ]
+  },
+  "az_az_abcdef12": {
+    "hosts": [
+      "i-abcdef12"
+    ]
+  },
+  "platform_linux": {
+    "hosts": [
+      "i-abcdef12"
+    ]
+  },
+  "tag_env_prod": {
+    "hosts": [
+      "i-abcdef12"
+    ]
+  },
+  "vpc_vpc_abcdef12": {
+    "hosts": [
+      "i-abcdef12"
+    ]
+  }
+}
+`, func() {
+			err := ecloudInventoryAnsible(service, cmd, []string{})
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("Host_OutputsHostVars", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		cmd := ecloudInventoryAnsibleCmd(nil)
+		cmd.ParseFlags([]string{"--host", "web02", "--hostname", "name"})
+
+		service.EXPECT().GetInstances(gomock.Any()).Return([]ecloud.Instance{
+			{ID: "i-abcdef12", Name: "web01"},
+			{ID: "i-abcdef13", Name: "web02", VPCID: "vpc-abcdef12"},
+		}, nil)
+		service.EXPECT().GetInstanceNICs("i-abcdef13", gomock.Any()).Return([]ecloud.NIC{{IPAddress: "10.0.0.6"}}, nil)
+		service.EXPECT().GetInstanceFloatingIPs("i-abcdef13", gomock.Any()).Return([]ecloud.FloatingIP{}, nil)
+
+		test_output.AssertOutput(t, `{
+  "ansible_host": "10.0.0.6",
+  "ecloud_instance_id": "i-abcdef13",
+  "ecloud_instance_name": "web02",
+  "ecloud_vpc_id": "vpc-abcdef12",
+  "ecloud_availability_zone_id": "",
+  "ecloud_platform": "",
+  "ecloud_image_id": "",
+  "ecloud_private_ips": [
+    "10.0.0.6"
+  ],
+  "ecloud_floating_ips": [],
+  "ecloud_tags": []
+}
+`, func() {
+			err := ecloudInventoryAnsible(service, cmd, []string{})
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("UnknownHost_OutputsEmptyObject", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		cmd := ecloudInventoryAnsibleCmd(nil)
+		cmd.ParseFlags([]string{"--host", "i-unknown"})
+
+		service.EXPECT().GetInstances(gomock.Any()).Return([]ecloud.Instance{{ID: "i-abcdef12"}}, nil)
+
+		test_output.AssertOutput(t, "{}\n", func() {
+			err := ecloudInventoryAnsible(service, cmd, []string{})
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("GetImageError_OutputsErrorAndContinues", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		cmd := ecloudInventoryAnsibleCmd(nil)
+
+		service.EXPECT().GetInstances(gomock.Any()).Return([]ecloud.Instance{
+			{ID: "i-abcdef12", ImageID: "img-abcdef12"},
+			{ID: "i-abcdef13", ImageID: "img-abcdef12"},
+		}, nil)
+		service.EXPECT().GetImage("img-abcdef12").Return(ecloud.Image{}, errors.New("test error")).Times(1)
+		service.EXPECT().GetInstanceNICs(gomock.Any(), gomock.Any()).Return([]ecloud.NIC{}, nil).Times(2)
+		service.EXPECT().GetInstanceFloatingIPs(gomock.Any(), gomock.Any()).Return([]ecloud.FloatingIP{}, nil).Times(2)
+
+		test_output.AssertErrorOutput(t, "Error retrieving image [img-abcdef12]: test error\n", func() {
+			err := ecloudInventoryAnsible(service, cmd, []string{})
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("DuplicateHostname_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		cmd := ecloudInventoryAnsibleCmd(nil)
+		cmd.ParseFlags([]string{"--hostname", "name"})
+
+		service.EXPECT().GetInstances(gomock.Any()).Return([]ecloud.Instance{
+			{ID: "i-abcdef12", Name: "web"},
+			{ID: "i-abcdef13", Name: "web"},
+		}, nil)
+		service.EXPECT().GetInstanceNICs("i-abcdef12", gomock.Any()).Return([]ecloud.NIC{}, nil)
+		service.EXPECT().GetInstanceFloatingIPs("i-abcdef12", gomock.Any()).Return([]ecloud.FloatingIP{}, nil)
+
+		err := ecloudInventoryAnsible(service, cmd, []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "multiple instances with hostname [web], use --hostname id", err.Error())
+	})
+
+	t.Run("InvalidHostnameProperty_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		cmd := ecloudInventoryAnsibleCmd(nil)
+		cmd.ParseFlags([]string{"--hostname", "invalid"})
+
+		err := ecloudInventoryAnsible(service, cmd, []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "invalid hostname property [invalid], expected id or name", err.Error())
+	})
+
+	t.Run("GetInstanceNICsError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+
+		service.EXPECT().GetInstances(gomock.Any()).Return([]ecloud.Instance{{ID: "i-abcdef12"}}, nil)
+		service.EXPECT().GetInstanceNICs("i-abcdef12", gomock.Any()).Return(nil, errors.New("test error"))
+
+		err := ecloudInventoryAnsible(service, ecloudInventoryAnsibleCmd(nil), []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error retrieving host variables for instance [i-abcdef12]: error retrieving instance NICs: test error", err.Error())
+	})
+
+	t.Run("GetInstancesError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+
+		service.EXPECT().GetInstances(gomock.Any()).Return(nil, errors.New("test error"))
+
+		err := ecloudInventoryAnsible(service, ecloudInventoryAnsibleCmd(nil), []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error retrieving instances: test error", err.Error())
+	})
+}
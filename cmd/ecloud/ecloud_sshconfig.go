@@ -0,0 +1,197 @@
+package ecloud
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ans-group/cli/internal/pkg/factory"
+	"github.com/ans-group/cli/internal/pkg/helper"
+	"github.com/ans-group/cli/internal/pkg/output"
+	"github.com/ans-group/sdk-go/pkg/service/ecloud"
+	"github.com/spf13/cobra"
+)
+
+func ecloudSSHConfigCmd(f factory.ClientFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ssh-config",
+		Short: "Outputs SSH client configuration for instances",
+		Long: "This command outputs an OpenSSH client configuration Host block for each instance, named after the instance " +
+			"and set with its floating IP, or internal IP where the instance has no floating IP. Instances without a " +
+			"floating IP, or all instances when --internal is specified, are reached via ProxyJump through the instance " +
+			"specified with --bastion",
+		Example: "ans ecloud ssh-config --vpc vpc-abcdef12 >> ~/.ssh/config.d/ans\n" +
+			"ans ecloud ssh-config --vpc vpc-abcdef12 --bastion i-abcdef12\n" +
+			"ans ecloud ssh-config --internal --user admin",
+		RunE: ecloudCobraRunEFunc(f, ecloudSSHConfig),
+	}
+
+	cmd.Flags().String("vpc", "", "VPC ID for filtering")
+	cmd.Flags().String("name", "", "Instance name for filtering")
+	cmd.Flags().String("bastion", "", "ID of instance to use as ProxyJump host for instances reached via internal IP")
+	cmd.Flags().Bool("internal", false, "Specifies internal IPs should be used")
+	cmd.Flags().String("user", "root", "Specifies user to connect with")
+	cmd.Flags().Int("port", 2020, "Specifies port to connect to")
+	cmd.Flags().String("hostname", "name", "Specifies instance property to use as SSH host alias (name/id)")
+
+	return cmd
+}
+
+// sshConfigHost represents a Host block within an OpenSSH client configuration
+type sshConfigHost struct {
+	Alias      string
+	InstanceID string
+	HostName   string
+	ProxyJump  string
+}
+
+func ecloudSSHConfig(service ecloud.ECloudService, cmd *cobra.Command, args []string) error {
+	hostnameProperty, _ := cmd.Flags().GetString("hostname")
+	if hostnameProperty != "id" && hostnameProperty != "name" {
+		return fmt.Errorf("invalid hostname property [%s], expected name or id", hostnameProperty)
+	}
+
+	params, err := helper.GetAPIRequestParametersFromFlags(cmd,
+		helper.NewStringFilterFlagOption("vpc", "vpc_id"),
+		helper.NewStringFilterFlagOption("name", "name"),
+	)
+	if err != nil {
+		return err
+	}
+
+	instances, err := service.GetInstances(params)
+	if err != nil {
+		return fmt.Errorf("error retrieving instances: %s", err)
+	}
+
+	internal, _ := cmd.Flags().GetBool("internal")
+	bastionID, _ := cmd.Flags().GetString("bastion")
+
+	var hosts []sshConfigHost
+	aliases := make(map[string]string)
+	addHost := func(instance ecloud.Instance, hostName string, proxyJump string) (string, error) {
+		alias := getSSHConfigHostAlias(instance, hostnameProperty)
+		if existingID, exists := aliases[alias]; exists {
+			return "", fmt.Errorf("instances [%s] and [%s] have the same host alias [%s], use --hostname id", existingID, instance.ID, alias)
+		}
+		aliases[alias] = instance.ID
+
+		hosts = append(hosts, sshConfigHost{
+			Alias:      alias,
+			InstanceID: instance.ID,
+			HostName:   hostName,
+			ProxyJump:  proxyJump,
+		})
+
+		return alias, nil
+	}
+
+	var bastionAlias string
+	if bastionID != "" {
+		bastion, err := getSSHConfigBastion(service, instances, bastionID)
+		if err != nil {
+			return err
+		}
+
+		addresses, err := getInstanceAddresses(service, bastion.ID)
+		if err != nil {
+			return fmt.Errorf("error retrieving addresses for bastion instance [%s]: %s", bastion.ID, err)
+		}
+
+		if len(addresses.Floating) < 1 {
+			return fmt.Errorf("bastion instance [%s] has no floating IP", bastion.ID)
+		}
+
+		bastionAlias, err = addHost(bastion, addresses.Floating[0], "")
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, instance := range instances {
+		if instance.ID == bastionID {
+			continue
+		}
+
+		addresses, err := getInstanceAddresses(service, instance.ID)
+		if err != nil {
+			output.OutputWithErrorLevelf("Error retrieving addresses for instance [%s]: %s", instance.ID, err)
+			continue
+		}
+
+		hostName := addresses.Preferred(internal)
+		if hostName == "" {
+			output.Errorf("Instance [%s] has no IP address, skipping", instance.ID)
+			continue
+		}
+
+		var proxyJump string
+		if internal || len(addresses.Floating) < 1 {
+			if bastionAlias != "" {
+				proxyJump = bastionAlias
+			} else if !internal {
+				output.Errorf("Instance [%s] has no floating IP and no bastion specified, using internal IP", instance.ID)
+			}
+		}
+
+		_, err = addHost(instance, hostName, proxyJump)
+		if err != nil {
+			return err
+		}
+	}
+
+	user, _ := cmd.Flags().GetString("user")
+	port, _ := cmd.Flags().GetInt("port")
+
+	fmt.Print(formatSSHConfig(hosts, user, port))
+
+	return nil
+}
+
+// getSSHConfigBastion returns the instance with ID bastionID from instances, or retrieves it where it has been
+// excluded by filtering
+func getSSHConfigBastion(service ecloud.ECloudService, instances []ecloud.Instance, bastionID string) (ecloud.Instance, error) {
+	for _, instance := range instances {
+		if instance.ID == bastionID {
+			return instance, nil
+		}
+	}
+
+	bastion, err := service.GetInstance(bastionID)
+	if err != nil {
+		return ecloud.Instance{}, fmt.Errorf("error retrieving bastion instance [%s]: %s", bastionID, err)
+	}
+
+	return bastion, nil
+}
+
+func formatSSHConfig(hosts []sshConfigHost, user string, port int) string {
+	var config strings.Builder
+	config.WriteString("# Generated by 'ans ecloud ssh-config'\n")
+
+	for _, host := range hosts {
+		config.WriteString("\n")
+		fmt.Fprintf(&config, "# Instance %s\n", host.InstanceID)
+		fmt.Fprintf(&config, "Host %s\n", host.Alias)
+		fmt.Fprintf(&config, "    HostName %s\n", host.HostName)
+		fmt.Fprintf(&config, "    User %s\n", user)
+		fmt.Fprintf(&config, "    Port %d\n", port)
+		if host.ProxyJump != "" {
+			fmt.Fprintf(&config, "    ProxyJump %s\n", host.ProxyJump)
+		}
+	}
+
+	return config.String()
+}
+
+var sshConfigHostAliasInvalidChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// getSSHConfigHostAlias returns the SSH host alias for instance, replacing characters such as whitespace and
+// pattern wildcards which can't be used in a Host declaration
+func getSSHConfigHostAlias(instance ecloud.Instance, hostnameProperty string) string {
+	if hostnameProperty == "id" || instance.Name == "" {
+		return instance.ID
+	}
+
+	return sshConfigHostAliasInvalidChars.ReplaceAllString(instance.Name, "-")
+}
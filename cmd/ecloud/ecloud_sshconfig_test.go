@@ -0,0 +1,199 @@
+package ecloud
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ans-group/cli/test/mocks"
+	"github.com/ans-group/cli/test/test_output"
+	"github.com/ans-group/sdk-go/pkg/service/ecloud"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ecloudSSHConfig(t *testing.T) {
+	t.Run("Bastion_OutputsConfigWithProxyJump", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		cmd := ecloudSSHConfigCmd(nil)
+		cmd.ParseFlags([]string{"--vpc", "vpc-abcdef12", "--bastion", "i-abcdef12"})
+
+		service.EXPECT().GetInstances(gomock.Any()).Return([]ecloud.Instance{
+			{ID: "i-abcdef12", Name: "bastion"},
+			{ID: "i-abcdef13", Name: "web 01"},
+			{ID: "i-abcdef14", Name: "db01"},
+		}, nil)
+		service.EXPECT().GetInstanceNICs("i-abcdef12", gomock.Any()).Return([]ecloud.NIC{{IPAddress: "10.0.0.4"}}, nil)
+		service.EXPECT().GetInstanceFloatingIPs("i-abcdef12", gomock.Any()).Return([]ecloud.FloatingIP{{IPAddress: "203.0.113.4"}}, nil)
+		service.EXPECT().GetInstanceNICs("i-abcdef13", gomock.Any()).Return([]ecloud.NIC{{IPAddress: "10.0.0.5"}}, nil)
+		service.EXPECT().GetInstanceFloatingIPs("i-abcdef13", gomock.Any()).Return([]ecloud.FloatingIP{{IPAddress: "203.0.113.5"}}, nil)
+		service.EXPECT().GetInstanceNICs("i-abcdef14", gomock.Any()).Return([]ecloud.NIC{{IPAddress: "10.0.0.6"}}, nil)
+		service.EXPECT().GetInstanceFloatingIPs("i-abcdef14", gomock.Any()).Return([]ecloud.FloatingIP{}, nil)
+
+		test_output.AssertOutput(t, `# Generated by 'ans ecloud ssh-config'
+
+# Instance i-abcdef12
+Host bastion
+    HostName 203.0.113.4
+    User root
+    Port 2020
+
+# Instance i-abcdef13
+Host web-01
+    HostName 203.0.113.5
+    User root
+    Port 2020
+
+# Instance i-abcdef14
+Host db01
+    HostName 10.0.0.6
+    User root
+    Port 2020
+    ProxyJump bastion
+`, func() {
+			err := ecloudSSHConfig(service, cmd, []string{})
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("BastionOutsideFilter_RetrievesBastion", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		cmd := ecloudSSHConfigCmd(nil)
+		cmd.ParseFlags([]string{"--bastion", "i-abcdef12", "--internal", "--hostname", "id", "--user", "admin", "--port", "22"})
+
+		service.EXPECT().GetInstances(gomock.Any()).Return([]ecloud.Instance{{ID: "i-abcdef13", Name: "web01"}}, nil)
+		service.EXPECT().GetInstance("i-abcdef12").Return(ecloud.Instance{ID: "i-abcdef12", Name: "bastion"}, nil)
+		service.EXPECT().GetInstanceNICs("i-abcdef12", gomock.Any()).Return([]ecloud.NIC{}, nil)
+		service.EXPECT().GetInstanceFloatingIPs("i-abcdef12", gomock.Any()).Return([]ecloud.FloatingIP{{IPAddress: "203.0.113.4"}}, nil)
+		service.EXPECT().GetInstanceNICs("i-abcdef13", gomock.Any()).Return([]ecloud.NIC{{IPAddress: "10.0.0.5"}}, nil)
+		service.EXPECT().GetInstanceFloatingIPs("i-abcdef13", gomock.Any()).Return([]ecloud.FloatingIP{{IPAddress: "203.0.113.5"}}, nil)
+
+		test_output.AssertOutput(t, `# Generated by 'ans ecloud ssh-config'
+
+# Instance i-abcdef12
+Host i-abcdef12
+    HostName 203.0.113.4
+    User admin
+    Port 22
+
+# Instance i-abcdef13
+Host i-abcdef13
+    HostName 10.0.0.5
+    User admin
+    Port 22
+    ProxyJump i-abcdef12
+`, func() {
+			err := ecloudSSHConfig(service, cmd, []string{})
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("NoFloatingIPWithoutBastion_OutputsWarning", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+
+		service.EXPECT().GetInstances(gomock.Any()).Return([]ecloud.Instance{{ID: "i-abcdef12", Name: "web01"}, {ID: "i-abcdef13"}}, nil)
+		service.EXPECT().GetInstanceNICs("i-abcdef12", gomock.Any()).Return([]ecloud.NIC{{IPAddress: "10.0.0.5"}}, nil)
+		service.EXPECT().GetInstanceFloatingIPs("i-abcdef12", gomock.Any()).Return([]ecloud.FloatingIP{}, nil)
+		service.EXPECT().GetInstanceNICs("i-abcdef13", gomock.Any()).Return([]ecloud.NIC{}, nil)
+		service.EXPECT().GetInstanceFloatingIPs("i-abcdef13", gomock.Any()).Return([]ecloud.FloatingIP{}, nil)
+
+		test_output.AssertCombinedOutput(t, `# Generated by 'ans ecloud ssh-config'
+
+# Instance i-abcdef12
+Host web01
+    HostName 10.0.0.5
+    User root
+    Port 2020
+`, "Instance [i-abcdef12] has no floating IP and no bastion specified, using internal IP\nInstance [i-abcdef13] has no IP address, skipping\n", func() {
+			err := ecloudSSHConfig(service, ecloudSSHConfigCmd(nil), []string{})
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("GetInstanceAddressesError_OutputsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+
+		service.EXPECT().GetInstances(gomock.Any()).Return([]ecloud.Instance{{ID: "i-abcdef12"}}, nil)
+		service.EXPECT().GetInstanceNICs("i-abcdef12", gomock.Any()).Return(nil, errors.New("test error"))
+
+		test_output.AssertErrorOutput(t, "Error retrieving addresses for instance [i-abcdef12]: error retrieving instance NICs: test error\n", func() {
+			ecloudSSHConfig(service, ecloudSSHConfigCmd(nil), []string{})
+		})
+	})
+
+	t.Run("DuplicateAlias_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+
+		service.EXPECT().GetInstances(gomock.Any()).Return([]ecloud.Instance{{ID: "i-abcdef12", Name: "web"}, {ID: "i-abcdef13", Name: "web"}}, nil)
+		service.EXPECT().GetInstanceNICs(gomock.Any(), gomock.Any()).Return([]ecloud.NIC{{IPAddress: "10.0.0.5"}}, nil).Times(2)
+		service.EXPECT().GetInstanceFloatingIPs(gomock.Any(), gomock.Any()).Return([]ecloud.FloatingIP{{IPAddress: "203.0.113.5"}}, nil).Times(2)
+
+		err := ecloudSSHConfig(service, ecloudSSHConfigCmd(nil), []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "instances [i-abcdef12] and [i-abcdef13] have the same host alias [web], use --hostname id", err.Error())
+	})
+
+	t.Run("BastionWithoutFloatingIP_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		cmd := ecloudSSHConfigCmd(nil)
+		cmd.ParseFlags([]string{"--bastion", "i-abcdef12"})
+
+		service.EXPECT().GetInstances(gomock.Any()).Return([]ecloud.Instance{{ID: "i-abcdef12"}}, nil)
+		service.EXPECT().GetInstanceNICs("i-abcdef12", gomock.Any()).Return([]ecloud.NIC{{IPAddress: "10.0.0.5"}}, nil)
+		service.EXPECT().GetInstanceFloatingIPs("i-abcdef12", gomock.Any()).Return([]ecloud.FloatingIP{}, nil)
+
+		err := ecloudSSHConfig(service, cmd, []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "bastion instance [i-abcdef12] has no floating IP", err.Error())
+	})
+
+	t.Run("GetBastionError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		cmd := ecloudSSHConfigCmd(nil)
+		cmd.ParseFlags([]string{"--bastion", "i-abcdef12"})
+
+		service.EXPECT().GetInstances(gomock.Any()).Return([]ecloud.Instance{}, nil)
+		service.EXPECT().GetInstance("i-abcdef12").Return(ecloud.Instance{}, errors.New("test error"))
+
+		err := ecloudSSHConfig(service, cmd, []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error retrieving bastion instance [i-abcdef12]: test error", err.Error())
+	})
+
+	t.Run("GetInstancesError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+
+		service.EXPECT().GetInstances(gomock.Any()).Return(nil, errors.New("test error"))
+
+		err := ecloudSSHConfig(service, ecloudSSHConfigCmd(nil), []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error retrieving instances: test error", err.Error())
+	})
+}
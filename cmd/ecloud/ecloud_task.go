@@ -47,12 +47,12 @@ func ecloudTaskList(service ecloud.ECloudService, cmd *cobra.Command, args []str
 		return err
 	}
 
-	paginatedTasks, err := service.GetTasksPaginated(params)
+	err = output.CommandOutputStream[TaskCollection](cmd, params, service.GetTasksPaginated)
 	if err != nil {
 		return fmt.Errorf("error retrieving tasks: %s", err)
 	}
 
-	return output.CommandOutputPaginated(cmd, TaskCollection(paginatedTasks.Items()), paginatedTasks)
+	return nil
 }
 
 func ecloudTaskShowCmd(f factory.ClientFactory) *cobra.Command {
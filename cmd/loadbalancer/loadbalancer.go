@@ -18,9 +18,10 @@ func LoadBalancerRootCmd(f factory.ClientFactory, fs afero.Fs) *cobra.Command {
 	cmd.AddCommand(loadbalancerACLRootCmd(f))
 	cmd.AddCommand(loadbalancerBindRootCmd(f))
 	cmd.AddCommand(loadbalancerCertificateRootCmd(f))
-	cmd.AddCommand(loadbalancerClusterRootCmd(f))
+	cmd.AddCommand(loadbalancerClusterRootCmd(f, fs))
 	cmd.AddCommand(loadbalancerDeploymentRootCmd(f))
 	cmd.AddCommand(loadbalancerListenerRootCmd(f, fs))
+	cmd.AddCommand(loadbalancerTargetRootCmd(f, fs))
 	cmd.AddCommand(loadbalancerTargetGroupRootCmd(f))
 	cmd.AddCommand(loadbalancerVipsCmd(f))
 	cmd.AddCommand(loadbalancerTerraformCmd(f))
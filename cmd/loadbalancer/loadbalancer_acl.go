@@ -23,6 +23,7 @@ func loadbalancerACLRootCmd(f factory.ClientFactory) *cobra.Command {
 	cmd.AddCommand(loadbalancerACLCreateCmd(f))
 	cmd.AddCommand(loadbalancerACLUpdateCmd(f))
 	cmd.AddCommand(loadbalancerACLDeleteCmd(f))
+	cmd.AddCommand(loadbalancerACLTestCmd(f))
 
 	// Child root commands
 	cmd.AddCommand(loadbalancerACLConditionRootCmd(f))
@@ -0,0 +1,451 @@
+package loadbalancer
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ans-group/cli/internal/pkg/factory"
+	"github.com/ans-group/cli/internal/pkg/output"
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/spf13/cobra"
+)
+
+// ACL evaluation results
+const (
+	aclResultMatched     = "matched"
+	aclResultNotMatched  = "not_matched"
+	aclResultUnevaluable = "unevaluable"
+	aclResultSkipped     = "skipped"
+)
+
+// aclTerminalActions are actions which end request processing when fired, meaning no
+// further ACLs are evaluated and the request isn't passed to a target group
+var aclTerminalActions = []string{"redirect", "deny", "block", "tarpit"}
+
+// aclTestRequest represents a sample request to evaluate ACLs against
+type aclTestRequest struct {
+	Method   string
+	URL      *url.URL
+	Headers  map[string][]string
+	SourceIP net.IP
+}
+
+// aclConditionEvaluator evaluates a condition against a request, returning whether the
+// condition matched
+type aclConditionEvaluator func(req *aclTestRequest, arguments map[string]loadbalancer.ACLArgument) (bool, error)
+
+// aclCompareFunc compares an actual request value against an expected ACL argument value
+type aclCompareFunc func(actual, expected string) (bool, error)
+
+// aclConditionEvaluators contains evaluators for ACL conditions which can be evaluated locally, keyed by
+// condition name. Conditions are only evaluated where they're also present in the cluster ACL templates
+var aclConditionEvaluators = map[string]aclConditionEvaluator{
+	"header_matches":     aclHeaderCondition(aclStringCompare(func(actual, expected string) bool { return actual == expected })),
+	"header_begins_with": aclHeaderCondition(aclStringCompare(strings.HasPrefix)),
+	"header_ends_with":   aclHeaderCondition(aclStringCompare(strings.HasSuffix)),
+	"header_contains":    aclHeaderCondition(aclStringCompare(strings.Contains)),
+	"header_regex":       aclHeaderCondition(aclRegexMatch),
+	"path_matches":       aclPathCondition(aclStringCompare(func(actual, expected string) bool { return actual == expected })),
+	"path_begins_with":   aclPathCondition(aclStringCompare(strings.HasPrefix)),
+	"path_ends_with":     aclPathCondition(aclStringCompare(strings.HasSuffix)),
+	"path_contains":      aclPathCondition(aclStringCompare(strings.Contains)),
+	"path_regex":         aclPathCondition(aclRegexMatch),
+	"method_matches":     aclMethodCondition,
+	"source_ip":          aclSourceIPCondition,
+	"is_ssl":             aclIsSSLCondition,
+}
+
+func loadbalancerACLTestCmd(f factory.ClientFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "test <listener: id>",
+		Short: "Tests ACLs against a sample request",
+		Long: "This command evaluates listener and target group ACLs locally against a sample request, reporting which " +
+			"ACLs match, which actions would fire and which target group would serve the request. No changes are made.\n\n" +
+			"Conditions which aren't present in the cluster ACL templates, or which can't be evaluated locally, are reported " +
+			"as unevaluable, in which case the outcome reported may differ from that of the load balancer",
+		Example: "ans loadbalancer acl test 123 --request 'GET https://example.com/path' --header X-Foo:bar",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("missing listener")
+			}
+
+			return nil
+		},
+		RunE: loadbalancerCobraRunEFunc(f, loadbalancerACLTest),
+	}
+
+	cmd.Flags().String("request", "", "Sample request in format 'METHOD URL'")
+	_ = cmd.MarkFlagRequired("request")
+	cmd.Flags().StringArray("header", []string{}, "Header for sample request in format 'name:value'. Can be repeated")
+	cmd.Flags().String("source-ip", "", "Source IP address for sample request")
+
+	return cmd
+}
+
+func loadbalancerACLTest(service loadbalancer.LoadBalancerService, cmd *cobra.Command, args []string) error {
+	listenerID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid listener ID")
+	}
+
+	requestFlag, _ := cmd.Flags().GetString("request")
+	headerFlag, _ := cmd.Flags().GetStringArray("header")
+	sourceIPFlag, _ := cmd.Flags().GetString("source-ip")
+	req, err := parseACLTestRequest(requestFlag, headerFlag, sourceIPFlag)
+	if err != nil {
+		return err
+	}
+
+	listener, err := service.GetListener(listenerID)
+	if err != nil {
+		return fmt.Errorf("error retrieving listener: %s", err)
+	}
+
+	templates, err := service.GetClusterACLTemplates(listener.ClusterID)
+	if err != nil {
+		return fmt.Errorf("error retrieving ACL templates: %s", err)
+	}
+
+	listenerACLs, err := service.GetListenerACLs(listenerID, connection.APIRequestParameters{})
+	if err != nil {
+		return fmt.Errorf("error retrieving listener ACLs: %s", err)
+	}
+
+	results, targetGroupID, terminated := evaluateACLs(req, "listener", listenerACLs, templates)
+	defer func() { warnUnevaluableACLs(results) }()
+	if terminated {
+		output.Errorf("Request would be handled by action on listener ACL, and not passed to a target group")
+		return output.CommandOutput(cmd, ACLTestResultCollection(results))
+	}
+
+	if targetGroupID == 0 {
+		targetGroupID = listener.DefaultTargetGroupID
+	}
+	if targetGroupID == 0 {
+		output.Errorf("Request would not be served: no matching ACL selects a target group, and listener has no default target group")
+		return output.CommandOutput(cmd, ACLTestResultCollection(results))
+	}
+
+	targetGroupACLs, err := service.GetTargetGroupACLs(targetGroupID, connection.APIRequestParameters{})
+	if err != nil {
+		return fmt.Errorf("error retrieving target group ACLs: %s", err)
+	}
+
+	targetGroupResults, _, terminated := evaluateACLs(req, "target_group", targetGroupACLs, templates)
+	results = append(results, targetGroupResults...)
+	if terminated {
+		output.Errorf("Request would be handled by action on target group [%d] ACL", targetGroupID)
+	} else {
+		output.Errorf("Request would be served by target group [%d]", targetGroupID)
+	}
+
+	return output.CommandOutput(cmd, ACLTestResultCollection(results))
+}
+
+// warnUnevaluableACLs outputs a warning for each ACL in results which couldn't be evaluated
+func warnUnevaluableACLs(results []ACLTestResult) {
+	for _, result := range results {
+		if result.Result == aclResultUnevaluable {
+			output.Errorf("ACL [%d] couldn't be evaluated, outcome may differ: %s", result.ID, result.Notes)
+		}
+	}
+}
+
+// parseACLTestRequest parses a sample request from a request string in format 'METHOD URL', headers
+// in format 'name:value' and an optional source IP address
+func parseACLTestRequest(request string, headers []string, sourceIP string) (*aclTestRequest, error) {
+	parts := strings.Fields(request)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid request [%s], expected format 'METHOD URL'", request)
+	}
+
+	requestURL, err := url.Parse(parts[1])
+	if err != nil || requestURL.Host == "" {
+		return nil, fmt.Errorf("invalid request URL [%s]", parts[1])
+	}
+
+	req := &aclTestRequest{
+		Method:  strings.ToUpper(parts[0]),
+		URL:     requestURL,
+		Headers: map[string][]string{"host": {requestURL.Host}},
+	}
+
+	for _, header := range headers {
+		name, value, found := strings.Cut(header, ":")
+		if !found || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid header [%s], expected format 'name:value'", header)
+		}
+
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "host" {
+			req.Headers[name] = nil
+		}
+		req.Headers[name] = append(req.Headers[name], strings.TrimSpace(value))
+	}
+
+	if sourceIP != "" {
+		req.SourceIP = net.ParseIP(sourceIP)
+		if req.SourceIP == nil {
+			return nil, fmt.Errorf("invalid source IP [%s]", sourceIP)
+		}
+	}
+
+	return req, nil
+}
+
+// evaluateACLs evaluates acls in order against req, with conditions absent from templates treated as
+// unevaluable. The ID of the target group selected by a fired action is returned, along with whether a
+// terminal action fired. Actions of unevaluable ACLs aren't considered to fire
+func evaluateACLs(req *aclTestRequest, scope string, acls []loadbalancer.ACL, templates loadbalancer.ACLTemplates) (results []ACLTestResult, targetGroupID int, terminated bool) {
+	knownConditions := make(map[string]bool)
+	for _, condition := range templates.Conditions {
+		knownConditions[condition.Name] = true
+	}
+
+	for _, acl := range acls {
+		result := ACLTestResult{
+			ID:    acl.ID,
+			Name:  acl.Name,
+			Scope: scope,
+		}
+
+		if terminated {
+			result.Result = aclResultSkipped
+			result.Notes = "not evaluated, request already handled"
+			results = append(results, result)
+			continue
+		}
+
+		var notes []string
+		result.Result, notes = evaluateACLConditions(req, acl.Conditions, knownConditions)
+		result.Notes = strings.Join(notes, "; ")
+
+		if result.Result == aclResultMatched {
+			for _, action := range acl.Actions {
+				result.Actions = append(result.Actions, action.Name)
+
+				if argument, ok := action.Arguments[aclTargetGroupArgument]; ok && targetGroupID == 0 {
+					targetGroupID, _ = strconv.Atoi(fmt.Sprintf("%v", argument.Value))
+				}
+				if isACLTerminalAction(action.Name) {
+					terminated = true
+				}
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results, targetGroupID, terminated
+}
+
+// evaluateACLConditions evaluates conditions against req, returning aclResultMatched where all conditions
+// match. Where no condition is known not to match, but a condition is unknown or can't be evaluated,
+// aclResultUnevaluable is returned, along with notes explaining why
+func evaluateACLConditions(req *aclTestRequest, conditions []loadbalancer.ACLCondition, knownConditions map[string]bool) (string, []string) {
+	var notes []string
+	matched := true
+	unevaluable := false
+
+	for _, condition := range conditions {
+		if !knownConditions[condition.Name] {
+			notes = append(notes, fmt.Sprintf("unknown condition [%s]", condition.Name))
+			unevaluable = true
+			continue
+		}
+
+		evaluator, ok := aclConditionEvaluators[condition.Name]
+		if !ok {
+			notes = append(notes, fmt.Sprintf("unsupported condition [%s]", condition.Name))
+			unevaluable = true
+			continue
+		}
+
+		conditionMatched, err := evaluator(req, condition.Arguments)
+		if err != nil {
+			notes = append(notes, fmt.Sprintf("condition [%s]: %s", condition.Name, err))
+			unevaluable = true
+			continue
+		}
+
+		if conditionMatched == condition.Inverted {
+			matched = false
+		}
+	}
+
+	switch {
+	case !matched:
+		return aclResultNotMatched, notes
+	case unevaluable:
+		return aclResultUnevaluable, notes
+	default:
+		return aclResultMatched, notes
+	}
+}
+
+func isACLTerminalAction(name string) bool {
+	for _, terminalAction := range aclTerminalActions {
+		if name == terminalAction {
+			return true
+		}
+	}
+
+	return false
+}
+
+// aclArgumentValues returns the values for the first argument found with one of names, with
+// array values flattened
+func aclArgumentValues(arguments map[string]loadbalancer.ACLArgument, names ...string) ([]string, bool) {
+	for _, name := range names {
+		argument, ok := arguments[name]
+		if !ok {
+			continue
+		}
+
+		switch value := argument.Value.(type) {
+		case []string:
+			return value, true
+		case []any:
+			var values []string
+			for _, v := range value {
+				values = append(values, fmt.Sprintf("%v", v))
+			}
+			return values, true
+		default:
+			return []string{fmt.Sprintf("%v", value)}, true
+		}
+	}
+
+	return nil, false
+}
+
+// aclStringCompare returns an aclCompareFunc for compare, which can't fail
+func aclStringCompare(compare func(actual, expected string) bool) aclCompareFunc {
+	return func(actual, expected string) (bool, error) {
+		return compare(actual, expected), nil
+	}
+}
+
+func aclRegexMatch(actual, expected string) (bool, error) {
+	re, err := regexp.Compile(expected)
+	if err != nil {
+		return false, fmt.Errorf("invalid regular expression [%s]: %s", expected, err)
+	}
+
+	return re.MatchString(actual), nil
+}
+
+// aclHeaderCondition returns an evaluator comparing request headers using compare. Arguments are
+// expected either as 'header' and 'value' arguments, or as header name to value pairs
+func aclHeaderCondition(compare aclCompareFunc) aclConditionEvaluator {
+	return func(req *aclTestRequest, arguments map[string]loadbalancer.ACLArgument) (bool, error) {
+		expectedHeaders := make(map[string][]string)
+
+		if headerNames, ok := aclArgumentValues(arguments, "header"); ok {
+			values, ok := aclArgumentValues(arguments, "value")
+			if !ok {
+				return false, errors.New("missing value argument")
+			}
+			expectedHeaders[strings.ToLower(headerNames[0])] = values
+		} else {
+			for name := range arguments {
+				expectedHeaders[strings.ToLower(name)], _ = aclArgumentValues(arguments, name)
+			}
+		}
+
+		if len(expectedHeaders) < 1 {
+			return false, errors.New("missing header arguments")
+		}
+
+		for name, expectedValues := range expectedHeaders {
+			matched, err := aclAnyMatch(req.Headers[name], expectedValues, compare)
+			if err != nil || !matched {
+				return false, err
+			}
+		}
+
+		return true, nil
+	}
+}
+
+// aclPathCondition returns an evaluator comparing the request path using compare
+func aclPathCondition(compare aclCompareFunc) aclConditionEvaluator {
+	return func(req *aclTestRequest, arguments map[string]loadbalancer.ACLArgument) (bool, error) {
+		expectedValues, ok := aclArgumentValues(arguments, "path", "value")
+		if !ok {
+			return false, errors.New("missing path argument")
+		}
+
+		return aclAnyMatch([]string{req.URL.Path}, expectedValues, compare)
+	}
+}
+
+func aclMethodCondition(req *aclTestRequest, arguments map[string]loadbalancer.ACLArgument) (bool, error) {
+	expectedValues, ok := aclArgumentValues(arguments, "method", "value")
+	if !ok {
+		return false, errors.New("missing method argument")
+	}
+
+	return aclAnyMatch([]string{req.Method}, expectedValues, aclStringCompare(strings.EqualFold))
+}
+
+func aclSourceIPCondition(req *aclTestRequest, arguments map[string]loadbalancer.ACLArgument) (bool, error) {
+	if req.SourceIP == nil {
+		return false, errors.New("source IP not provided for request")
+	}
+
+	expectedValues, ok := aclArgumentValues(arguments, "ip", "value")
+	if !ok {
+		return false, errors.New("missing ip argument")
+	}
+
+	for _, expected := range expectedValues {
+		if _, network, err := net.ParseCIDR(expected); err == nil {
+			if network.Contains(req.SourceIP) {
+				return true, nil
+			}
+			continue
+		}
+
+		if req.SourceIP.Equal(net.ParseIP(expected)) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func aclIsSSLCondition(req *aclTestRequest, arguments map[string]loadbalancer.ACLArgument) (bool, error) {
+	return req.URL.Scheme == "https", nil
+}
+
+// aclAnyMatch returns true where any of actualValues match any of expectedValues using compare. Where
+// compare returns an error, e.g. for an invalid regular expression, the error is returned
+func aclAnyMatch(actualValues []string, expectedValues []string, compare aclCompareFunc) (bool, error) {
+	for _, expected := range expectedValues {
+		for _, actual := range actualValues {
+			matched, err := compare(actual, expected)
+			if err != nil {
+				return false, err
+			}
+			if matched {
+				return true, nil
+			}
+		}
+
+		// Ensure expected values are validated even where there are no actual values
+		if len(actualValues) == 0 {
+			if _, err := compare("", expected); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	return false, nil
+}
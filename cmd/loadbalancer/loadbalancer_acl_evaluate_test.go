@@ -0,0 +1,363 @@
+package loadbalancer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ans-group/cli/test/mocks"
+	"github.com/ans-group/cli/test/test_output"
+	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_loadbalancerACLTestCmd_Args(t *testing.T) {
+	t.Run("ValidArgs_NoError", func(t *testing.T) {
+		err := loadbalancerACLTestCmd(nil).Args(nil, []string{"123"})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("InvalidArgs_Error", func(t *testing.T) {
+		err := loadbalancerACLTestCmd(nil).Args(nil, []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "missing listener", err.Error())
+	})
+}
+
+func testACLTemplates(conditions ...string) loadbalancer.ACLTemplates {
+	templates := loadbalancer.ACLTemplates{}
+	for _, condition := range conditions {
+		templates.Conditions = append(templates.Conditions, loadbalancer.ACLTemplateCondition{Name: condition})
+	}
+
+	return templates
+}
+
+func headerACL(id int, header string, value string, actions ...loadbalancer.ACLAction) loadbalancer.ACL {
+	return loadbalancer.ACL{
+		ID:   id,
+		Name: "acl",
+		Conditions: []loadbalancer.ACLCondition{
+			{
+				Name: "header_matches",
+				Arguments: map[string]loadbalancer.ACLArgument{
+					"header": {Name: "header", Value: header},
+					"value":  {Name: "value", Value: value},
+				},
+			},
+		},
+		Actions: actions,
+	}
+}
+
+func Test_loadbalancerACLTest(t *testing.T) {
+	t.Run("MatchingACLSelectsTargetGroup", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+		cmd := loadbalancerACLTestCmd(nil)
+		cmd.ParseFlags([]string{"--request", "GET https://example.com/path", "--header", "X-Foo:bar"})
+
+		action := loadbalancer.ACLAction{
+			Name: "use_target_group",
+			Arguments: map[string]loadbalancer.ACLArgument{
+				"target_group_id": {Name: "target_group_id", Value: float64(456)},
+			},
+		}
+
+		gomock.InOrder(
+			service.EXPECT().GetListener(123).Return(loadbalancer.Listener{ID: 123, ClusterID: 1, DefaultTargetGroupID: 789}, nil),
+			service.EXPECT().GetClusterACLTemplates(1).Return(testACLTemplates("header_matches"), nil),
+			service.EXPECT().GetListenerACLs(123, gomock.Any()).Return([]loadbalancer.ACL{headerACL(1, "x-foo", "bar", action)}, nil),
+			service.EXPECT().GetTargetGroupACLs(456, gomock.Any()).Return([]loadbalancer.ACL{}, nil),
+		)
+
+		err := loadbalancerACLTest(service, cmd, []string{"123"})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("NoMatchingACL_UsesDefaultTargetGroup", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+		cmd := loadbalancerACLTestCmd(nil)
+		cmd.ParseFlags([]string{"--request", "GET https://example.com/path"})
+
+		gomock.InOrder(
+			service.EXPECT().GetListener(123).Return(loadbalancer.Listener{ID: 123, ClusterID: 1, DefaultTargetGroupID: 789}, nil),
+			service.EXPECT().GetClusterACLTemplates(1).Return(testACLTemplates("header_matches"), nil),
+			service.EXPECT().GetListenerACLs(123, gomock.Any()).Return([]loadbalancer.ACL{headerACL(1, "x-foo", "bar")}, nil),
+			service.EXPECT().GetTargetGroupACLs(789, gomock.Any()).Return([]loadbalancer.ACL{}, nil),
+		)
+
+		err := loadbalancerACLTest(service, cmd, []string{"123"})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("TerminalListenerAction_DoesNotRetrieveTargetGroupACLs", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+		cmd := loadbalancerACLTestCmd(nil)
+		cmd.ParseFlags([]string{"--request", "GET https://example.com/path", "--header", "X-Foo:bar"})
+
+		gomock.InOrder(
+			service.EXPECT().GetListener(123).Return(loadbalancer.Listener{ID: 123, ClusterID: 1, DefaultTargetGroupID: 789}, nil),
+			service.EXPECT().GetClusterACLTemplates(1).Return(testACLTemplates("header_matches"), nil),
+			service.EXPECT().GetListenerACLs(123, gomock.Any()).Return([]loadbalancer.ACL{headerACL(1, "x-foo", "bar", loadbalancer.ACLAction{Name: "redirect"})}, nil),
+		)
+
+		err := loadbalancerACLTest(service, cmd, []string{"123"})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("InvalidListenerID_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+		cmd := loadbalancerACLTestCmd(nil)
+		cmd.ParseFlags([]string{"--request", "GET https://example.com/path"})
+
+		err := loadbalancerACLTest(service, cmd, []string{"abc"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "invalid listener ID", err.Error())
+	})
+
+	t.Run("InvalidRequest_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+		cmd := loadbalancerACLTestCmd(nil)
+		cmd.ParseFlags([]string{"--request", "https://example.com/path"})
+
+		err := loadbalancerACLTest(service, cmd, []string{"123"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "invalid request [https://example.com/path], expected format 'METHOD URL'", err.Error())
+	})
+
+	t.Run("GetListenerError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+		cmd := loadbalancerACLTestCmd(nil)
+		cmd.ParseFlags([]string{"--request", "GET https://example.com/path"})
+
+		service.EXPECT().GetListener(123).Return(loadbalancer.Listener{}, errors.New("test error"))
+
+		err := loadbalancerACLTest(service, cmd, []string{"123"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error retrieving listener: test error", err.Error())
+	})
+
+	t.Run("GetClusterACLTemplatesError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+		cmd := loadbalancerACLTestCmd(nil)
+		cmd.ParseFlags([]string{"--request", "GET https://example.com/path"})
+
+		service.EXPECT().GetListener(123).Return(loadbalancer.Listener{ClusterID: 1}, nil)
+		service.EXPECT().GetClusterACLTemplates(1).Return(loadbalancer.ACLTemplates{}, errors.New("test error"))
+
+		err := loadbalancerACLTest(service, cmd, []string{"123"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error retrieving ACL templates: test error", err.Error())
+	})
+
+	t.Run("UnevaluableACL_OutputsWarning", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+		cmd := loadbalancerACLTestCmd(nil)
+		cmd.ParseFlags([]string{"--request", "GET https://example.com/path"})
+
+		gomock.InOrder(
+			service.EXPECT().GetListener(123).Return(loadbalancer.Listener{ID: 123, ClusterID: 1, DefaultTargetGroupID: 789}, nil),
+			service.EXPECT().GetClusterACLTemplates(1).Return(testACLTemplates(), nil),
+			service.EXPECT().GetListenerACLs(123, gomock.Any()).Return([]loadbalancer.ACL{headerACL(1, "host", "example.com")}, nil),
+			service.EXPECT().GetTargetGroupACLs(789, gomock.Any()).Return([]loadbalancer.ACL{}, nil),
+		)
+
+		test_output.AssertErrorOutput(t, "Request would be served by target group [789]\nACL [1] couldn't be evaluated, outcome may differ: unknown condition [header_matches]\n", func() {
+			err := loadbalancerACLTest(service, cmd, []string{"123"})
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("GetListenerACLsError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+		cmd := loadbalancerACLTestCmd(nil)
+		cmd.ParseFlags([]string{"--request", "GET https://example.com/path"})
+
+		service.EXPECT().GetListener(123).Return(loadbalancer.Listener{ClusterID: 1}, nil)
+		service.EXPECT().GetClusterACLTemplates(1).Return(loadbalancer.ACLTemplates{}, nil)
+		service.EXPECT().GetListenerACLs(123, gomock.Any()).Return(nil, errors.New("test error"))
+
+		err := loadbalancerACLTest(service, cmd, []string{"123"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error retrieving listener ACLs: test error", err.Error())
+	})
+
+	t.Run("GetTargetGroupACLsError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+		cmd := loadbalancerACLTestCmd(nil)
+		cmd.ParseFlags([]string{"--request", "GET https://example.com/path"})
+
+		service.EXPECT().GetListener(123).Return(loadbalancer.Listener{ClusterID: 1, DefaultTargetGroupID: 789}, nil)
+		service.EXPECT().GetClusterACLTemplates(1).Return(loadbalancer.ACLTemplates{}, nil)
+		service.EXPECT().GetListenerACLs(123, gomock.Any()).Return([]loadbalancer.ACL{}, nil)
+		service.EXPECT().GetTargetGroupACLs(789, gomock.Any()).Return(nil, errors.New("test error"))
+
+		err := loadbalancerACLTest(service, cmd, []string{"123"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error retrieving target group ACLs: test error", err.Error())
+	})
+}
+
+func Test_parseACLTestRequest(t *testing.T) {
+	t.Run("Valid_ReturnsRequest", func(t *testing.T) {
+		req, err := parseACLTestRequest("get https://example.com/path", []string{"X-Foo: bar", "Host:other.com"}, "10.0.0.1")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "GET", req.Method)
+		assert.Equal(t, "/path", req.URL.Path)
+		assert.Equal(t, []string{"bar"}, req.Headers["x-foo"])
+		assert.Equal(t, []string{"other.com"}, req.Headers["host"])
+		assert.Equal(t, "10.0.0.1", req.SourceIP.String())
+	})
+
+	t.Run("InvalidURL_ReturnsError", func(t *testing.T) {
+		_, err := parseACLTestRequest("GET /path", nil, "")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "invalid request URL [/path]", err.Error())
+	})
+
+	t.Run("InvalidHeader_ReturnsError", func(t *testing.T) {
+		_, err := parseACLTestRequest("GET https://example.com", []string{"invalid"}, "")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "invalid header [invalid], expected format 'name:value'", err.Error())
+	})
+
+	t.Run("InvalidSourceIP_ReturnsError", func(t *testing.T) {
+		_, err := parseACLTestRequest("GET https://example.com", nil, "invalid")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "invalid source IP [invalid]", err.Error())
+	})
+}
+
+func Test_evaluateACLConditions(t *testing.T) {
+	req, _ := parseACLTestRequest("GET https://example.com/api/test", []string{"Accept:application/json"}, "10.0.0.5")
+
+	condition := func(name string, inverted bool, arguments map[string]any) loadbalancer.ACLCondition {
+		c := loadbalancer.ACLCondition{Name: name, Inverted: inverted, Arguments: map[string]loadbalancer.ACLArgument{}}
+		for k, v := range arguments {
+			c.Arguments[k] = loadbalancer.ACLArgument{Name: k, Value: v}
+		}
+		return c
+	}
+
+	knownConditions := map[string]bool{}
+	for name := range aclConditionEvaluators {
+		knownConditions[name] = true
+	}
+	knownConditions["unsupported"] = true
+
+	tests := []struct {
+		name      string
+		condition loadbalancer.ACLCondition
+		result    string
+	}{
+		{"HeaderPairMatches", condition("header_matches", false, map[string]any{"host": "example.com"}), aclResultMatched},
+		{"HeaderArrayMatches", condition("header_matches", false, map[string]any{"header": "accept", "value": []any{"text/html", "application/json"}}), aclResultMatched},
+		{"HeaderMissing", condition("header_matches", false, map[string]any{"header": "x-missing", "value": "a"}), aclResultNotMatched},
+		{"HeaderInverted", condition("header_matches", true, map[string]any{"host": "example.com"}), aclResultNotMatched},
+		{"PathBeginsWith", condition("path_begins_with", false, map[string]any{"path": "/api"}), aclResultMatched},
+		{"PathRegex", condition("path_regex", false, map[string]any{"path": "^/api/t.+$"}), aclResultMatched},
+		{"PathRegexInvalid", condition("path_regex", false, map[string]any{"path": "^/api/(t"}), aclResultUnevaluable},
+		{"HeaderRegexInvalidMissingHeader", condition("header_regex", false, map[string]any{"header": "x-missing", "value": "(a"}), aclResultUnevaluable},
+		{"MethodMatches", condition("method_matches", false, map[string]any{"method": "get"}), aclResultMatched},
+		{"SourceIPCIDR", condition("source_ip", false, map[string]any{"ip": "10.0.0.0/24"}), aclResultMatched},
+		{"SourceIPNoMatch", condition("source_ip", false, map[string]any{"ip": "10.0.1.5"}), aclResultNotMatched},
+		{"IsSSL", condition("is_ssl", false, nil), aclResultMatched},
+		{"Unknown", condition("unknown", false, nil), aclResultUnevaluable},
+		{"Unsupported", condition("unsupported", false, nil), aclResultUnevaluable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _ := evaluateACLConditions(req, []loadbalancer.ACLCondition{tt.condition}, knownConditions)
+
+			assert.Equal(t, tt.result, result)
+		})
+	}
+
+	t.Run("UnknownWithNonMatching_NotMatched", func(t *testing.T) {
+		result, notes := evaluateACLConditions(req, []loadbalancer.ACLCondition{
+			condition("unknown", false, nil),
+			condition("source_ip", false, map[string]any{"ip": "10.0.1.5"}),
+		}, knownConditions)
+
+		assert.Equal(t, aclResultNotMatched, result)
+		assert.Equal(t, []string{"unknown condition [unknown]"}, notes)
+	})
+
+	t.Run("Unsupported_ReturnsNote", func(t *testing.T) {
+		_, notes := evaluateACLConditions(req, []loadbalancer.ACLCondition{condition("unsupported", false, nil)}, knownConditions)
+
+		assert.Equal(t, []string{"unsupported condition [unsupported]"}, notes)
+	})
+
+	t.Run("InvalidRegex_ReturnsNote", func(t *testing.T) {
+		_, notes := evaluateACLConditions(req, []loadbalancer.ACLCondition{condition("path_regex", false, map[string]any{"path": "(t"})}, knownConditions)
+
+		assert.Equal(t, []string{"condition [path_regex]: invalid regular expression [(t]: error parsing regexp: missing closing ): `(t`"}, notes)
+	})
+}
+
+func Test_evaluateACLs(t *testing.T) {
+	t.Run("TerminalAction_SkipsRemainingACLs", func(t *testing.T) {
+		req, _ := parseACLTestRequest("GET https://example.com/", nil, "")
+		acls := []loadbalancer.ACL{
+			headerACL(1, "host", "example.com", loadbalancer.ACLAction{Name: "redirect"}),
+			headerACL(2, "host", "example.com"),
+		}
+
+		results, targetGroupID, terminated := evaluateACLs(req, "listener", acls, testACLTemplates("header_matches"))
+
+		assert.True(t, terminated)
+		assert.Equal(t, 0, targetGroupID)
+		assert.Len(t, results, 2)
+		assert.Equal(t, aclResultMatched, results[0].Result)
+		assert.Equal(t, []string{"redirect"}, results[0].Actions)
+		assert.Equal(t, aclResultSkipped, results[1].Result)
+		assert.Equal(t, "not evaluated, request already handled", results[1].Notes)
+	})
+}
@@ -7,12 +7,15 @@ import (
 
 	"github.com/ans-group/cli/internal/pkg/factory"
 	"github.com/ans-group/cli/internal/pkg/helper"
+	"github.com/ans-group/cli/internal/pkg/input"
 	"github.com/ans-group/cli/internal/pkg/output"
+	"github.com/ans-group/sdk-go/pkg/connection"
 	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 )
 
-func loadbalancerClusterRootCmd(f factory.ClientFactory) *cobra.Command {
+func loadbalancerClusterRootCmd(f factory.ClientFactory, fs afero.Fs) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "cluster",
 		Short: "sub-commands relating to clusters",
@@ -24,6 +27,9 @@ func loadbalancerClusterRootCmd(f factory.ClientFactory) *cobra.Command {
 	cmd.AddCommand(loadbalancerClusterUpdateCmd(f))
 	cmd.AddCommand(loadbalancerClusterDeployCmd(f))
 	cmd.AddCommand(loadbalancerClusterValidateCmd(f))
+	cmd.AddCommand(loadbalancerClusterDiffCmd(f))
+	cmd.AddCommand(loadbalancerClusterExportCmd(f))
+	cmd.AddCommand(loadbalancerClusterImportCmd(f, fs))
 
 	// Child root commands
 	cmd.AddCommand(loadbalancerClusterACLTemplateRootCmd(f))
@@ -148,7 +154,7 @@ func loadbalancerClusterUpdate(service loadbalancer.LoadBalancerService, cmd *co
 }
 
 func loadbalancerClusterDeployCmd(f factory.ClientFactory) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:     "deploy <cluster: id>...",
 		Short:   "Deploys a cluster",
 		Long:    "This command deploys one or more clusters",
@@ -162,9 +168,16 @@ func loadbalancerClusterDeployCmd(f factory.ClientFactory) *cobra.Command {
 		},
 		RunE: loadbalancerCobraRunEFunc(f, loadbalancerClusterDeploy),
 	}
+
+	cmd.Flags().Bool("confirm", false, "Specifies that pending changes should be shown, with confirmation requested before deploying. "+
+		"Clusters without pending changes are not deployed")
+
+	return cmd
 }
 
 func loadbalancerClusterDeploy(service loadbalancer.LoadBalancerService, cmd *cobra.Command, args []string) error {
+	confirm, _ := cmd.Flags().GetBool("confirm")
+
 	for _, arg := range args {
 		clusterID, err := strconv.Atoi(arg)
 		if err != nil {
@@ -172,6 +185,33 @@ func loadbalancerClusterDeploy(service loadbalancer.LoadBalancerService, cmd *co
 			continue
 		}
 
+		if confirm {
+			changes, err := getClusterChanges(service, clusterID)
+			if err != nil {
+				output.OutputWithErrorLevelf("Error retrieving pending changes for cluster [%s]: %s", arg, err)
+				continue
+			}
+
+			if len(changes) == 0 {
+				output.Errorf("No pending changes for cluster [%s], skipping deployment", arg)
+				continue
+			}
+
+			err = output.CommandOutput(cmd, ClusterChangeCollection(changes))
+			if err != nil {
+				return err
+			}
+
+			confirmed, err := input.Confirm(fmt.Sprintf("Deploy cluster [%s]?", arg))
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				output.Errorf("Skipping deployment of cluster [%s]", arg)
+				continue
+			}
+		}
+
 		err = service.DeployCluster(clusterID)
 		if err != nil {
 			output.OutputWithErrorLevelf("Error deploying cluster [%s]: %s", arg, err)
@@ -216,3 +256,66 @@ func loadbalancerClusterValidate(service loadbalancer.LoadBalancerService, cmd *
 
 	return nil
 }
+
+// deployClusterAndWait deploys cluster with ID clusterID, waiting for the resulting deployment
+// to be recorded. An error is returned if the deployment was unsuccessful
+func deployClusterAndWait(service loadbalancer.LoadBalancerService, clusterID int) (loadbalancer.Deployment, error) {
+	previousDeployment, err := getLatestClusterDeployment(service, clusterID)
+	if err != nil {
+		return loadbalancer.Deployment{}, fmt.Errorf("error retrieving deployments: %s", err)
+	}
+
+	err = service.DeployCluster(clusterID)
+	if err != nil {
+		return loadbalancer.Deployment{}, fmt.Errorf("error deploying cluster: %s", err)
+	}
+
+	var deployment loadbalancer.Deployment
+	err = helper.WaitForCommand(func() (finished bool, err error) {
+		deployment, err = getLatestClusterDeployment(service, clusterID)
+		if err != nil {
+			return false, fmt.Errorf("failed to retrieve deployments: %s", err)
+		}
+
+		return deployment.ID > previousDeployment.ID, nil
+	})
+	if err != nil {
+		return deployment, err
+	}
+
+	if !deployment.Successful {
+		return deployment, fmt.Errorf("deployment [%d] was unsuccessful", deployment.ID)
+	}
+
+	return deployment, nil
+}
+
+// getLatestClusterDeployment returns the most recent deployment for cluster with ID clusterID,
+// or an empty deployment if the cluster has never been deployed
+func getLatestClusterDeployment(service loadbalancer.LoadBalancerService, clusterID int) (loadbalancer.Deployment, error) {
+	deployments, err := service.GetDeployments(clusterFilterParameters(clusterID))
+	if err != nil {
+		return loadbalancer.Deployment{}, err
+	}
+
+	latest := loadbalancer.Deployment{}
+	for _, deployment := range deployments {
+		if deployment.ID > latest.ID {
+			latest = deployment
+		}
+	}
+
+	return latest, nil
+}
+
+// clusterFilterParameters returns request parameters filtering by cluster with ID clusterID
+func clusterFilterParameters(clusterID int) connection.APIRequestParameters {
+	params := connection.APIRequestParameters{}
+	params.WithFilter(connection.APIRequestFiltering{
+		Property: "cluster_id",
+		Operator: connection.EQOperator,
+		Value:    []string{strconv.Itoa(clusterID)},
+	})
+
+	return params
+}
@@ -0,0 +1,628 @@
+package loadbalancer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ans-group/cli/internal/pkg/factory"
+	"github.com/ans-group/cli/internal/pkg/helper"
+	"github.com/ans-group/cli/internal/pkg/output"
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// aclTargetGroupArgument is the name of the ACL condition/action argument which references
+// a target group, and therefore requires remapping on import
+const aclTargetGroupArgument string = "target_group_id"
+
+// ClusterConfig represents the exported configuration of a cluster. Properties are stored
+// using their API names, and IDs are those of the source cluster, used for remapping
+// references on import
+type ClusterConfig struct {
+	Cluster      string                     `yaml:"cluster"`
+	VIPs         []ClusterConfigVIP         `yaml:"vips"`
+	TargetGroups []ClusterConfigTargetGroup `yaml:"target_groups"`
+	Listeners    []ClusterConfigListener    `yaml:"listeners"`
+}
+
+type ClusterConfigVIP struct {
+	ID           int    `yaml:"id"`
+	InternalCIDR string `yaml:"internal_cidr"`
+	ExternalCIDR string `yaml:"external_cidr"`
+}
+
+type ClusterConfigTargetGroup struct {
+	ID         int              `yaml:"id"`
+	Properties map[string]any   `yaml:"properties"`
+	Targets    []map[string]any `yaml:"targets"`
+	ACLs       []map[string]any `yaml:"acls"`
+}
+
+type ClusterConfigListener struct {
+	ID           int                        `yaml:"id"`
+	Properties   map[string]any             `yaml:"properties"`
+	AccessIPs    []string                   `yaml:"access_ips"`
+	Binds        []ClusterConfigBind        `yaml:"binds"`
+	Certificates []ClusterConfigCertificate `yaml:"certificates"`
+	ACLs         []map[string]any           `yaml:"acls"`
+}
+
+type ClusterConfigBind struct {
+	VIPID int `yaml:"vip_id"`
+	Port  int `yaml:"port"`
+}
+
+// ClusterConfigCertificate represents a listener certificate. Certificate contents aren't
+// retrievable via the API, so paths to files containing these must be populated before import
+type ClusterConfigCertificate struct {
+	Name            string `yaml:"name"`
+	KeyFile         string `yaml:"key_file"`
+	CertificateFile string `yaml:"certificate_file"`
+	CABundleFile    string `yaml:"ca_bundle_file"`
+}
+
+func loadbalancerClusterExportCmd(f factory.ClientFactory) *cobra.Command {
+	return &cobra.Command{
+		Use:     "export <cluster: id>",
+		Short:   "Exports cluster configuration",
+		Long:    "This command exports the configuration of a cluster as YAML, for importing into another cluster",
+		Example: "ans loadbalancer cluster export 123 > lb.yaml",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("missing cluster")
+			}
+
+			return nil
+		},
+		RunE: loadbalancerCobraRunEFunc(f, loadbalancerClusterExport),
+	}
+}
+
+func loadbalancerClusterExport(service loadbalancer.LoadBalancerService, cmd *cobra.Command, args []string) error {
+	clusterID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid cluster ID")
+	}
+
+	config, err := getClusterConfig(service, clusterID)
+	if err != nil {
+		return err
+	}
+
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cluster configuration: %s", err)
+	}
+
+	_, err = fmt.Print(string(out))
+	return err
+}
+
+func getClusterConfig(service loadbalancer.LoadBalancerService, clusterID int) (ClusterConfig, error) {
+	config := ClusterConfig{}
+
+	cluster, err := service.GetCluster(clusterID)
+	if err != nil {
+		return config, fmt.Errorf("error retrieving cluster: %s", err)
+	}
+	config.Cluster = cluster.Name
+
+	clusterParams := clusterFilterParameters(clusterID)
+
+	vips, err := service.GetVIPs(clusterParams)
+	if err != nil {
+		return config, fmt.Errorf("error retrieving VIPs: %s", err)
+	}
+	for _, vip := range vips {
+		config.VIPs = append(config.VIPs, ClusterConfigVIP{
+			ID:           vip.ID,
+			InternalCIDR: vip.InternalCIDR,
+			ExternalCIDR: vip.ExternalCIDR,
+		})
+	}
+
+	targetGroups, err := service.GetTargetGroups(clusterParams)
+	if err != nil {
+		return config, fmt.Errorf("error retrieving target groups: %s", err)
+	}
+	for _, targetGroup := range targetGroups {
+		targetGroupConfig := ClusterConfigTargetGroup{ID: targetGroup.ID}
+		targetGroupConfig.Properties, err = toClusterConfigProperties(targetGroup, loadbalancer.CreateTargetGroupRequest{}, "cluster_id")
+		if err != nil {
+			return config, err
+		}
+
+		targets, err := service.GetTargetGroupTargets(targetGroup.ID, connection.APIRequestParameters{})
+		if err != nil {
+			return config, fmt.Errorf("error retrieving targets for target group [%d]: %s", targetGroup.ID, err)
+		}
+		for _, target := range targets {
+			targetProperties, err := toClusterConfigProperties(target, loadbalancer.CreateTargetRequest{})
+			if err != nil {
+				return config, err
+			}
+			targetGroupConfig.Targets = append(targetGroupConfig.Targets, targetProperties)
+		}
+
+		acls, err := service.GetTargetGroupACLs(targetGroup.ID, connection.APIRequestParameters{})
+		if err != nil {
+			return config, fmt.Errorf("error retrieving ACLs for target group [%d]: %s", targetGroup.ID, err)
+		}
+		targetGroupConfig.ACLs, err = toClusterConfigACLs(acls)
+		if err != nil {
+			return config, err
+		}
+
+		config.TargetGroups = append(config.TargetGroups, targetGroupConfig)
+	}
+
+	listeners, err := service.GetListeners(clusterParams)
+	if err != nil {
+		return config, fmt.Errorf("error retrieving listeners: %s", err)
+	}
+	for _, listener := range listeners {
+		listenerConfig := ClusterConfigListener{ID: listener.ID}
+		listenerConfig.Properties, err = toClusterConfigProperties(listener, loadbalancer.CreateListenerRequest{}, "cluster_id")
+		if err != nil {
+			return config, err
+		}
+
+		accessIPs, err := service.GetListenerAccessIPs(listener.ID, connection.APIRequestParameters{})
+		if err != nil {
+			return config, fmt.Errorf("error retrieving access IPs for listener [%d]: %s", listener.ID, err)
+		}
+		for _, accessIP := range accessIPs {
+			listenerConfig.AccessIPs = append(listenerConfig.AccessIPs, accessIP.IP.String())
+		}
+
+		binds, err := service.GetListenerBinds(listener.ID, connection.APIRequestParameters{})
+		if err != nil {
+			return config, fmt.Errorf("error retrieving binds for listener [%d]: %s", listener.ID, err)
+		}
+		for _, bind := range binds {
+			listenerConfig.Binds = append(listenerConfig.Binds, ClusterConfigBind{VIPID: bind.VIPID, Port: bind.Port})
+		}
+
+		certificates, err := service.GetListenerCertificates(listener.ID, connection.APIRequestParameters{})
+		if err != nil {
+			return config, fmt.Errorf("error retrieving certificates for listener [%d]: %s", listener.ID, err)
+		}
+		for _, certificate := range certificates {
+			listenerConfig.Certificates = append(listenerConfig.Certificates, ClusterConfigCertificate{Name: certificate.Name})
+		}
+
+		acls, err := service.GetListenerACLs(listener.ID, connection.APIRequestParameters{})
+		if err != nil {
+			return config, fmt.Errorf("error retrieving ACLs for listener [%d]: %s", listener.ID, err)
+		}
+		listenerConfig.ACLs, err = toClusterConfigACLs(acls)
+		if err != nil {
+			return config, err
+		}
+
+		config.Listeners = append(config.Listeners, listenerConfig)
+	}
+
+	return config, nil
+}
+
+func loadbalancerClusterImportCmd(f factory.ClientFactory, fs afero.Fs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <cluster: id>",
+		Short: "Imports cluster configuration",
+		Long: "This command imports cluster configuration previously exported with 'ans loadbalancer cluster export', " +
+			"creating target groups, targets, listeners, access IPs, binds, certificates and ACLs with references remapped " +
+			"to the new resources. The cluster is then validated and deployed. If import fails, resources created prior to the " +
+			"failure are output so that they can be removed or completed manually",
+		Example: "ans loadbalancer cluster import 123 -f lb.yaml",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("missing cluster")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := f.NewClient()
+			if err != nil {
+				return err
+			}
+
+			return loadbalancerClusterImport(c.LoadBalancerService(), cmd, fs, args)
+		},
+	}
+
+	cmd.Flags().StringP("file", "f", "", "Path to file containing exported cluster configuration")
+	_ = cmd.MarkFlagRequired("file")
+	cmd.Flags().StringSlice("vip-map", []string{}, "Mapping of source VIP ID to target VIP ID in format 'source:target', can be repeated. "+
+		"Unmapped VIPs are matched by position")
+	cmd.Flags().Bool("skip-deploy", false, "Specifies that the cluster should be validated but not deployed following import")
+
+	return cmd
+}
+
+func loadbalancerClusterImport(service loadbalancer.LoadBalancerService, cmd *cobra.Command, fs afero.Fs, args []string) error {
+	clusterID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid cluster ID")
+	}
+
+	content, err := helper.GetContentsFromFilePathFlag(cmd, fs, "file")
+	if err != nil {
+		return fmt.Errorf("failed to read cluster configuration: %s", err)
+	}
+
+	var config ClusterConfig
+	err = yaml.Unmarshal([]byte(content), &config)
+	if err != nil {
+		return fmt.Errorf("failed to parse cluster configuration: %s", err)
+	}
+
+	vips, err := service.GetVIPs(clusterFilterParameters(clusterID))
+	if err != nil {
+		return fmt.Errorf("error retrieving VIPs: %s", err)
+	}
+
+	vipMapFlag, _ := cmd.Flags().GetStringSlice("vip-map")
+	vipMap, err := getClusterConfigVIPMap(config.VIPs, vips, vipMapFlag)
+	if err != nil {
+		return err
+	}
+
+	importer := &clusterConfigImporter{
+		service:        service,
+		fs:             fs,
+		clusterID:      clusterID,
+		vipMap:         vipMap,
+		targetGroupMap: make(map[int]int),
+	}
+
+	skipDeploy, _ := cmd.Flags().GetBool("skip-deploy")
+	err = importLoadBalancerCluster(service, importer, config, skipDeploy)
+	if err != nil {
+		// Resources created prior to the failure are output, so that they can be removed or
+		// completed manually
+		if len(importer.resources) > 0 {
+			output.Errorf("cluster import failed, outputting resources created prior to failure")
+			_ = output.CommandOutput(cmd, ClusterImportResourceCollection(importer.resources))
+		}
+		return err
+	}
+
+	return output.CommandOutput(cmd, ClusterImportResourceCollection(importer.resources))
+}
+
+func importLoadBalancerCluster(service loadbalancer.LoadBalancerService, importer *clusterConfigImporter, config ClusterConfig, skipDeploy bool) error {
+	err := importer.Import(config)
+	if err != nil {
+		return err
+	}
+
+	err = service.ValidateCluster(importer.clusterID)
+	if err != nil {
+		return fmt.Errorf("error validating cluster: %s", err)
+	}
+
+	if !skipDeploy {
+		err = service.DeployCluster(importer.clusterID)
+		if err != nil {
+			return fmt.Errorf("error deploying cluster: %s", err)
+		}
+	}
+
+	return nil
+}
+
+type clusterConfigImporter struct {
+	service        loadbalancer.LoadBalancerService
+	fs             afero.Fs
+	clusterID      int
+	vipMap         map[int]int
+	targetGroupMap map[int]int
+	resources      []ClusterImportResource
+}
+
+func (i *clusterConfigImporter) Import(config ClusterConfig) error {
+	// Target groups are created first, as listeners and ACLs may reference them
+	for _, targetGroupConfig := range config.TargetGroups {
+		createRequest := loadbalancer.CreateTargetGroupRequest{}
+		err := fromClusterConfigProperties(targetGroupConfig.Properties, &createRequest)
+		if err != nil {
+			return err
+		}
+		createRequest.ClusterID = i.clusterID
+
+		targetGroupID, err := i.service.CreateTargetGroup(createRequest)
+		if err != nil {
+			return fmt.Errorf("error creating target group [%s]: %s", createRequest.Name, err)
+		}
+		i.targetGroupMap[targetGroupConfig.ID] = targetGroupID
+		i.addResource("target_group", targetGroupConfig.ID, targetGroupID, createRequest.Name)
+
+		for _, targetProperties := range targetGroupConfig.Targets {
+			targetRequest := loadbalancer.CreateTargetRequest{}
+			err := fromClusterConfigProperties(targetProperties, &targetRequest)
+			if err != nil {
+				return err
+			}
+
+			targetID, err := i.service.CreateTargetGroupTarget(targetGroupID, targetRequest)
+			if err != nil {
+				return fmt.Errorf("error creating target [%s] for target group [%s]: %s", targetRequest.Name, createRequest.Name, err)
+			}
+			i.addResource("target", 0, targetID, targetRequest.Name)
+		}
+	}
+
+	for _, listenerConfig := range config.Listeners {
+		createRequest := loadbalancer.CreateListenerRequest{}
+		err := fromClusterConfigProperties(listenerConfig.Properties, &createRequest)
+		if err != nil {
+			return err
+		}
+		createRequest.ClusterID = i.clusterID
+		if createRequest.DefaultTargetGroupID > 0 {
+			createRequest.DefaultTargetGroupID, err = i.mapTargetGroupID(createRequest.DefaultTargetGroupID)
+			if err != nil {
+				return err
+			}
+		}
+
+		listenerID, err := i.service.CreateListener(createRequest)
+		if err != nil {
+			return fmt.Errorf("error creating listener [%s]: %s", createRequest.Name, err)
+		}
+		i.addResource("listener", listenerConfig.ID, listenerID, createRequest.Name)
+
+		for _, accessIP := range listenerConfig.AccessIPs {
+			accessIPID, err := i.service.CreateListenerAccessIP(listenerID, loadbalancer.CreateAccessIPRequest{IP: connection.IPAddress(accessIP)})
+			if err != nil {
+				return fmt.Errorf("error creating access IP [%s] for listener [%s]: %s", accessIP, createRequest.Name, err)
+			}
+			i.addResource("access_ip", 0, accessIPID, accessIP)
+		}
+
+		for _, bind := range listenerConfig.Binds {
+			vipID, ok := i.vipMap[bind.VIPID]
+			if !ok {
+				return fmt.Errorf("no target VIP mapped for source VIP [%d]", bind.VIPID)
+			}
+
+			bindID, err := i.service.CreateListenerBind(listenerID, loadbalancer.CreateBindRequest{VIPID: vipID, Port: bind.Port})
+			if err != nil {
+				return fmt.Errorf("error creating bind for listener [%s]: %s", createRequest.Name, err)
+			}
+			i.addResource("bind", 0, bindID, strconv.Itoa(bind.Port))
+		}
+
+		for _, certificate := range listenerConfig.Certificates {
+			if certificate.KeyFile == "" || certificate.CertificateFile == "" {
+				output.OutputWithErrorLevelf("Skipping certificate [%s] for listener [%s]: key_file and certificate_file must be specified", certificate.Name, createRequest.Name)
+				continue
+			}
+
+			certificateRequest, err := i.getCertificateRequest(certificate)
+			if err != nil {
+				return err
+			}
+
+			certificateID, err := i.service.CreateListenerCertificate(listenerID, certificateRequest)
+			if err != nil {
+				return fmt.Errorf("error creating certificate [%s] for listener [%s]: %s", certificate.Name, createRequest.Name, err)
+			}
+			i.addResource("certificate", 0, certificateID, certificate.Name)
+		}
+
+		err = i.importACLs(listenerConfig.ACLs, loadbalancer.CreateACLRequest{ListenerID: listenerID})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, targetGroupConfig := range config.TargetGroups {
+		err := i.importACLs(targetGroupConfig.ACLs, loadbalancer.CreateACLRequest{TargetGroupID: i.targetGroupMap[targetGroupConfig.ID]})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (i *clusterConfigImporter) importACLs(acls []map[string]any, baseRequest loadbalancer.CreateACLRequest) error {
+	for _, aclProperties := range acls {
+		createRequest := baseRequest
+		err := fromClusterConfigProperties(aclProperties, &createRequest)
+		if err != nil {
+			return err
+		}
+		createRequest.ListenerID = baseRequest.ListenerID
+		createRequest.TargetGroupID = baseRequest.TargetGroupID
+
+		for _, condition := range createRequest.Conditions {
+			err := i.mapACLArguments(condition.Arguments)
+			if err != nil {
+				return err
+			}
+		}
+		for _, action := range createRequest.Actions {
+			err := i.mapACLArguments(action.Arguments)
+			if err != nil {
+				return err
+			}
+		}
+
+		aclID, err := i.service.CreateACL(createRequest)
+		if err != nil {
+			return fmt.Errorf("error creating ACL [%s]: %s", createRequest.Name, err)
+		}
+		i.addResource("acl", 0, aclID, createRequest.Name)
+	}
+
+	return nil
+}
+
+func (i *clusterConfigImporter) mapACLArguments(arguments map[string]loadbalancer.ACLArgument) error {
+	for key, argument := range arguments {
+		if argument.Name != aclTargetGroupArgument && key != aclTargetGroupArgument {
+			continue
+		}
+
+		sourceID, err := strconv.Atoi(fmt.Sprintf("%v", argument.Value))
+		if err != nil {
+			return fmt.Errorf("invalid target group ID [%v] in ACL argument", argument.Value)
+		}
+
+		argument.Value, err = i.mapTargetGroupID(sourceID)
+		if err != nil {
+			return err
+		}
+		arguments[key] = argument
+	}
+
+	return nil
+}
+
+func (i *clusterConfigImporter) mapTargetGroupID(sourceID int) (int, error) {
+	targetGroupID, ok := i.targetGroupMap[sourceID]
+	if !ok {
+		return 0, fmt.Errorf("unknown target group [%d] referenced in configuration", sourceID)
+	}
+
+	return targetGroupID, nil
+}
+
+func (i *clusterConfigImporter) getCertificateRequest(certificate ClusterConfigCertificate) (loadbalancer.CreateCertificateRequest, error) {
+	createRequest := loadbalancer.CreateCertificateRequest{Name: certificate.Name}
+
+	files := []struct {
+		path     string
+		contents *string
+	}{
+		{path: certificate.KeyFile, contents: &createRequest.Key},
+		{path: certificate.CertificateFile, contents: &createRequest.Certificate},
+		{path: certificate.CABundleFile, contents: &createRequest.CABundle},
+	}
+	for _, file := range files {
+		if file.path == "" {
+			continue
+		}
+
+		content, err := afero.ReadFile(i.fs, file.path)
+		if err != nil {
+			return createRequest, fmt.Errorf("failed to read file for certificate [%s]: %s", certificate.Name, err)
+		}
+		*file.contents = string(content)
+	}
+
+	return createRequest, nil
+}
+
+func (i *clusterConfigImporter) addResource(resourceType string, sourceID int, id int, name string) {
+	i.resources = append(i.resources, ClusterImportResource{
+		Type:     resourceType,
+		SourceID: sourceID,
+		ID:       id,
+		Name:     name,
+	})
+}
+
+// getClusterConfigVIPMap returns a map of source VIP ID to target VIP ID. Mappings provided
+// via mappingFlag take precedence, with remaining source VIPs mapped by position
+func getClusterConfigVIPMap(sourceVIPs []ClusterConfigVIP, targetVIPs []loadbalancer.VIP, mappingFlag []string) (map[int]int, error) {
+	vipMap := make(map[int]int)
+
+	for _, mapping := range mappingFlag {
+		parts := strings.Split(mapping, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid VIP mapping [%s], expected format 'source:target'", mapping)
+		}
+
+		sourceID, sourceErr := strconv.Atoi(parts[0])
+		targetID, targetErr := strconv.Atoi(parts[1])
+		if sourceErr != nil || targetErr != nil {
+			return nil, fmt.Errorf("invalid VIP mapping [%s], expected format 'source:target'", mapping)
+		}
+
+		vipMap[sourceID] = targetID
+	}
+
+	sort.Slice(targetVIPs, func(i, j int) bool { return targetVIPs[i].ID < targetVIPs[j].ID })
+	for index, sourceVIP := range sourceVIPs {
+		if _, ok := vipMap[sourceVIP.ID]; ok {
+			continue
+		}
+		if index < len(targetVIPs) {
+			vipMap[sourceVIP.ID] = targetVIPs[index].ID
+		}
+	}
+
+	return vipMap, nil
+}
+
+// toClusterConfigProperties converts resource v to a property map, using the fields of
+// request type r. Properties with names in exclude are removed
+func toClusterConfigProperties[T any](v any, r T, exclude ...string) (map[string]any, error) {
+	resourceJSON, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal resource: %s", err)
+	}
+
+	err = json.Unmarshal(resourceJSON, &r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert resource: %s", err)
+	}
+
+	requestJSON, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal resource: %s", err)
+	}
+
+	properties := make(map[string]any)
+	err = json.Unmarshal(requestJSON, &properties)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert resource: %s", err)
+	}
+
+	for _, property := range exclude {
+		delete(properties, property)
+	}
+
+	return properties, nil
+}
+
+// fromClusterConfigProperties populates request r from property map properties
+func fromClusterConfigProperties(properties map[string]any, r any) error {
+	propertiesJSON, err := json.Marshal(properties)
+	if err != nil {
+		return fmt.Errorf("failed to marshal configuration properties: %s", err)
+	}
+
+	err = json.Unmarshal(propertiesJSON, r)
+	if err != nil {
+		return fmt.Errorf("invalid configuration properties: %s", err)
+	}
+
+	return nil
+}
+
+func toClusterConfigACLs(acls []loadbalancer.ACL) ([]map[string]any, error) {
+	var aclConfigs []map[string]any
+	for _, acl := range acls {
+		aclProperties, err := toClusterConfigProperties(acl, loadbalancer.CreateACLRequest{}, "listener_id", "target_group_id")
+		if err != nil {
+			return nil, err
+		}
+
+		aclConfigs = append(aclConfigs, aclProperties)
+	}
+
+	return aclConfigs, nil
+}
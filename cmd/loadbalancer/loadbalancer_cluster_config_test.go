@@ -0,0 +1,376 @@
+package loadbalancer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ans-group/cli/test/mocks"
+	"github.com/ans-group/cli/test/test_output"
+	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_loadbalancerClusterExportCmd_Args(t *testing.T) {
+	t.Run("ValidArgs_NoError", func(t *testing.T) {
+		err := loadbalancerClusterExportCmd(nil).Args(nil, []string{"123"})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("MissingCluster_Error", func(t *testing.T) {
+		err := loadbalancerClusterExportCmd(nil).Args(nil, []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "missing cluster", err.Error())
+	})
+}
+
+func Test_loadbalancerClusterExport(t *testing.T) {
+	t.Run("Valid_OutputsYAML", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+
+		gomock.InOrder(
+			service.EXPECT().GetCluster(123).Return(loadbalancer.Cluster{ID: 123, Name: "staging"}, nil),
+			service.EXPECT().GetVIPs(gomock.Any()).Return([]loadbalancer.VIP{{ID: 10, InternalCIDR: "10.0.0.5/24"}}, nil),
+			service.EXPECT().GetTargetGroups(gomock.Any()).Return([]loadbalancer.TargetGroup{{ID: 1, ClusterID: 123, Name: "web", Mode: loadbalancer.ModeHTTP}}, nil),
+			service.EXPECT().GetTargetGroupTargets(1, gomock.Any()).Return([]loadbalancer.Target{{ID: 2, Name: "web1", IP: "10.0.0.10", Port: 80}}, nil),
+			service.EXPECT().GetTargetGroupACLs(1, gomock.Any()).Return([]loadbalancer.ACL{}, nil),
+			service.EXPECT().GetListeners(gomock.Any()).Return([]loadbalancer.Listener{{ID: 3, ClusterID: 123, Name: "frontend", DefaultTargetGroupID: 1}}, nil),
+			service.EXPECT().GetListenerAccessIPs(3, gomock.Any()).Return([]loadbalancer.AccessIP{{ID: 4, IP: "1.2.3.4"}}, nil),
+			service.EXPECT().GetListenerBinds(3, gomock.Any()).Return([]loadbalancer.Bind{{ID: 5, VIPID: 10, Port: 443}}, nil),
+			service.EXPECT().GetListenerCertificates(3, gomock.Any()).Return([]loadbalancer.Certificate{{ID: 6, Name: "example.com"}}, nil),
+			service.EXPECT().GetListenerACLs(3, gomock.Any()).Return([]loadbalancer.ACL{{ID: 7, Name: "redirect"}}, nil),
+		)
+
+		test_output.AssertOutputFunc(t, func(stdOut string) {
+			assert.Contains(t, stdOut, "cluster: staging")
+			assert.Contains(t, stdOut, "default_target_group_id: 1")
+			assert.Contains(t, stdOut, "name: web1")
+			assert.Contains(t, stdOut, "- 1.2.3.4")
+			assert.Contains(t, stdOut, "vip_id: 10")
+			assert.Contains(t, stdOut, "name: redirect")
+			assert.NotContains(t, stdOut, "cluster_id")
+			assert.NotContains(t, stdOut, "created_at")
+		}, func() {
+			err := loadbalancerClusterExport(service, loadbalancerClusterExportCmd(nil), []string{"123"})
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("InvalidClusterID_ReturnsError", func(t *testing.T) {
+		err := loadbalancerClusterExport(nil, loadbalancerClusterExportCmd(nil), []string{"abc"})
+
+		assert.Equal(t, "invalid cluster ID", err.Error())
+	})
+
+	t.Run("GetClusterError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+
+		service.EXPECT().GetCluster(123).Return(loadbalancer.Cluster{}, errors.New("test error"))
+
+		err := loadbalancerClusterExport(service, loadbalancerClusterExportCmd(nil), []string{"123"})
+
+		assert.Equal(t, "error retrieving cluster: test error", err.Error())
+	})
+
+	t.Run("GetTargetGroupTargetsError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+
+		gomock.InOrder(
+			service.EXPECT().GetCluster(123).Return(loadbalancer.Cluster{}, nil),
+			service.EXPECT().GetVIPs(gomock.Any()).Return([]loadbalancer.VIP{}, nil),
+			service.EXPECT().GetTargetGroups(gomock.Any()).Return([]loadbalancer.TargetGroup{{ID: 1}}, nil),
+			service.EXPECT().GetTargetGroupTargets(1, gomock.Any()).Return([]loadbalancer.Target{}, errors.New("test error")),
+		)
+
+		err := loadbalancerClusterExport(service, loadbalancerClusterExportCmd(nil), []string{"123"})
+
+		assert.Equal(t, "error retrieving targets for target group [1]: test error", err.Error())
+	})
+}
+
+func Test_loadbalancerClusterImportCmd_Args(t *testing.T) {
+	t.Run("ValidArgs_NoError", func(t *testing.T) {
+		err := loadbalancerClusterImportCmd(nil, nil).Args(nil, []string{"123"})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("MissingCluster_Error", func(t *testing.T) {
+		err := loadbalancerClusterImportCmd(nil, nil).Args(nil, []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "missing cluster", err.Error())
+	})
+}
+
+const testClusterConfig = `cluster: staging
+vips:
+  - id: 10
+target_groups:
+  - id: 1
+    properties:
+      name: web
+      mode: http
+    targets:
+      - name: web1
+        ip: 10.0.0.10
+        port: 80
+    acls: []
+listeners:
+  - id: 3
+    properties:
+      name: frontend
+      default_target_group_id: 1
+    access_ips:
+      - 1.2.3.4
+    binds:
+      - vip_id: 10
+        port: 443
+    certificates:
+      - name: example.com
+        key_file: /tmp/cert.key
+        certificate_file: /tmp/cert.crt
+    acls:
+      - name: api
+        actions:
+          - name: use_backend
+            arguments:
+              target_group_id:
+                name: target_group_id
+                value: 1
+`
+
+func Test_loadbalancerClusterImport(t *testing.T) {
+	t.Run("Valid_RemapsReferences", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/tmp/lb.yaml", []byte(testClusterConfig), 0644)
+		afero.WriteFile(fs, "/tmp/cert.key", []byte("testkey"), 0644)
+		afero.WriteFile(fs, "/tmp/cert.crt", []byte("testcert"), 0644)
+
+		cmd := loadbalancerClusterImportCmd(nil, nil)
+		cmd.Flags().Set("file", "/tmp/lb.yaml")
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+
+		gomock.InOrder(
+			service.EXPECT().GetVIPs(gomock.Any()).Return([]loadbalancer.VIP{{ID: 20}}, nil),
+			service.EXPECT().CreateTargetGroup(loadbalancer.CreateTargetGroupRequest{ClusterID: 456, Name: "web", Mode: loadbalancer.ModeHTTP}).Return(101, nil),
+			service.EXPECT().CreateTargetGroupTarget(101, loadbalancer.CreateTargetRequest{Name: "web1", IP: "10.0.0.10", Port: 80}).Return(102, nil),
+			service.EXPECT().CreateListener(loadbalancer.CreateListenerRequest{ClusterID: 456, Name: "frontend", DefaultTargetGroupID: 101}).Return(103, nil),
+			service.EXPECT().CreateListenerAccessIP(103, loadbalancer.CreateAccessIPRequest{IP: "1.2.3.4"}).Return(104, nil),
+			service.EXPECT().CreateListenerBind(103, loadbalancer.CreateBindRequest{VIPID: 20, Port: 443}).Return(105, nil),
+			service.EXPECT().CreateListenerCertificate(103, loadbalancer.CreateCertificateRequest{Name: "example.com", Key: "testkey", Certificate: "testcert"}).Return(106, nil),
+			service.EXPECT().CreateACL(gomock.Any()).DoAndReturn(func(req loadbalancer.CreateACLRequest) (int, error) {
+				assert.Equal(t, 103, req.ListenerID)
+				assert.Equal(t, 101, req.Actions[0].Arguments["target_group_id"].Value)
+				return 107, nil
+			}),
+			service.EXPECT().ValidateCluster(456).Return(nil),
+			service.EXPECT().DeployCluster(456).Return(nil),
+		)
+
+		err := loadbalancerClusterImport(service, cmd, fs, []string{"456"})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("SkipDeploy_DoesNotDeploy", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/tmp/lb.yaml", []byte("cluster: staging\n"), 0644)
+
+		cmd := loadbalancerClusterImportCmd(nil, nil)
+		cmd.Flags().Set("file", "/tmp/lb.yaml")
+		cmd.Flags().Set("skip-deploy", "true")
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+
+		gomock.InOrder(
+			service.EXPECT().GetVIPs(gomock.Any()).Return([]loadbalancer.VIP{}, nil),
+			service.EXPECT().ValidateCluster(456).Return(nil),
+		)
+
+		err := loadbalancerClusterImport(service, cmd, fs, []string{"456"})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("CertificateWithoutFiles_OutputsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/tmp/lb.yaml", []byte("listeners:\n  - id: 3\n    properties:\n      name: frontend\n    certificates:\n      - name: example.com\n"), 0644)
+
+		cmd := loadbalancerClusterImportCmd(nil, nil)
+		cmd.Flags().Set("file", "/tmp/lb.yaml")
+		cmd.Flags().Set("skip-deploy", "true")
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+
+		gomock.InOrder(
+			service.EXPECT().GetVIPs(gomock.Any()).Return([]loadbalancer.VIP{}, nil),
+			service.EXPECT().CreateListener(gomock.Any()).Return(103, nil),
+			service.EXPECT().ValidateCluster(456).Return(nil),
+		)
+
+		test_output.AssertErrorOutput(t, "Skipping certificate [example.com] for listener [frontend]: key_file and certificate_file must be specified\n", func() {
+			loadbalancerClusterImport(service, cmd, fs, []string{"456"})
+		})
+	})
+
+	t.Run("UnknownTargetGroup_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/tmp/lb.yaml", []byte("listeners:\n  - id: 3\n    properties:\n      name: frontend\n      default_target_group_id: 99\n"), 0644)
+
+		cmd := loadbalancerClusterImportCmd(nil, nil)
+		cmd.Flags().Set("file", "/tmp/lb.yaml")
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+
+		service.EXPECT().GetVIPs(gomock.Any()).Return([]loadbalancer.VIP{}, nil)
+
+		err := loadbalancerClusterImport(service, cmd, fs, []string{"456"})
+
+		assert.Equal(t, "unknown target group [99] referenced in configuration", err.Error())
+	})
+
+	t.Run("InvalidClusterID_ReturnsError", func(t *testing.T) {
+		err := loadbalancerClusterImport(nil, loadbalancerClusterImportCmd(nil, nil), nil, []string{"abc"})
+
+		assert.Equal(t, "invalid cluster ID", err.Error())
+	})
+
+	t.Run("MissingFile_ReturnsError", func(t *testing.T) {
+		cmd := loadbalancerClusterImportCmd(nil, nil)
+		cmd.Flags().Set("file", "/tmp/lb.yaml")
+
+		err := loadbalancerClusterImport(nil, cmd, afero.NewMemMapFs(), []string{"456"})
+
+		assert.Contains(t, err.Error(), "failed to read cluster configuration")
+	})
+
+	t.Run("CreateTargetGroupError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/tmp/lb.yaml", []byte(testClusterConfig), 0644)
+
+		cmd := loadbalancerClusterImportCmd(nil, nil)
+		cmd.Flags().Set("file", "/tmp/lb.yaml")
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+
+		gomock.InOrder(
+			service.EXPECT().GetVIPs(gomock.Any()).Return([]loadbalancer.VIP{}, nil),
+			service.EXPECT().CreateTargetGroup(gomock.Any()).Return(0, errors.New("test error")),
+		)
+
+		err := loadbalancerClusterImport(service, cmd, fs, []string{"456"})
+
+		assert.Equal(t, "error creating target group [web]: test error", err.Error())
+	})
+
+	t.Run("CreateTargetError_OutputsCreatedResourcesAndReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/tmp/lb.yaml", []byte(testClusterConfig), 0644)
+
+		cmd := loadbalancerClusterImportCmd(nil, nil)
+		cmd.Flags().Set("file", "/tmp/lb.yaml")
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+
+		gomock.InOrder(
+			service.EXPECT().GetVIPs(gomock.Any()).Return([]loadbalancer.VIP{{ID: 20}}, nil),
+			service.EXPECT().CreateTargetGroup(gomock.Any()).Return(101, nil),
+			service.EXPECT().CreateTargetGroupTarget(101, gomock.Any()).Return(0, errors.New("test error")),
+		)
+
+		test_output.AssertCombinedOutputFunc(t, func(stdOut, stdErr string) {
+			assert.Contains(t, stdOut, "target_group")
+			assert.Contains(t, stdOut, "101")
+			assert.Contains(t, stdOut, "web")
+			assert.Contains(t, stdErr, "cluster import failed, outputting resources created prior to failure")
+		}, func() {
+			err := loadbalancerClusterImport(service, cmd, fs, []string{"456"})
+
+			assert.Equal(t, "error creating target [web1] for target group [web]: test error", err.Error())
+		})
+	})
+
+	t.Run("ValidateClusterError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/tmp/lb.yaml", []byte("cluster: staging\n"), 0644)
+
+		cmd := loadbalancerClusterImportCmd(nil, nil)
+		cmd.Flags().Set("file", "/tmp/lb.yaml")
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+
+		gomock.InOrder(
+			service.EXPECT().GetVIPs(gomock.Any()).Return([]loadbalancer.VIP{}, nil),
+			service.EXPECT().ValidateCluster(456).Return(errors.New("test error")),
+		)
+
+		err := loadbalancerClusterImport(service, cmd, fs, []string{"456"})
+
+		assert.Equal(t, "error validating cluster: test error", err.Error())
+	})
+}
+
+func Test_getClusterConfigVIPMap(t *testing.T) {
+	t.Run("ByPosition", func(t *testing.T) {
+		vipMap, err := getClusterConfigVIPMap(
+			[]ClusterConfigVIP{{ID: 1}, {ID: 2}},
+			[]loadbalancer.VIP{{ID: 20}, {ID: 10}},
+			[]string{},
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, map[int]int{1: 10, 2: 20}, vipMap)
+	})
+
+	t.Run("WithMapping_MappingTakesPrecedence", func(t *testing.T) {
+		vipMap, err := getClusterConfigVIPMap(
+			[]ClusterConfigVIP{{ID: 1}, {ID: 2}},
+			[]loadbalancer.VIP{{ID: 10}, {ID: 20}},
+			[]string{"1:30"},
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, map[int]int{1: 30, 2: 20}, vipMap)
+	})
+
+	t.Run("InvalidMapping_ReturnsError", func(t *testing.T) {
+		_, err := getClusterConfigVIPMap(nil, nil, []string{"invalid"})
+
+		assert.Equal(t, "invalid VIP mapping [invalid], expected format 'source:target'", err.Error())
+	})
+}
@@ -0,0 +1,135 @@
+package loadbalancer
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ans-group/cli/internal/pkg/factory"
+	"github.com/ans-group/cli/internal/pkg/output"
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/spf13/cobra"
+)
+
+func loadbalancerClusterDiffCmd(f factory.ClientFactory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff <cluster: id>",
+		Short: "Shows pending changes for a cluster",
+		Long: "This command shows resources created or updated since the last successful deployment of a cluster. " +
+			"Deleted resources and ACL changes cannot be determined, and are not shown",
+		Example: "ans loadbalancer cluster diff 123",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("missing cluster")
+			}
+
+			return nil
+		},
+		RunE: loadbalancerCobraRunEFunc(f, loadbalancerClusterDiff),
+	}
+}
+
+func loadbalancerClusterDiff(service loadbalancer.LoadBalancerService, cmd *cobra.Command, args []string) error {
+	clusterID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid cluster ID")
+	}
+
+	changes, err := getClusterChanges(service, clusterID)
+	if err != nil {
+		return err
+	}
+
+	return output.CommandOutput(cmd, ClusterChangeCollection(changes))
+}
+
+// getClusterChanges returns resources created or updated since the last successful deployment
+// of cluster with ID clusterID
+func getClusterChanges(service loadbalancer.LoadBalancerService, clusterID int) ([]ClusterChange, error) {
+	deployments, err := service.GetDeployments(clusterFilterParameters(clusterID))
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving deployments: %s", err)
+	}
+
+	var since time.Time
+	for _, deployment := range deployments {
+		if deployment.Successful && deployment.CreatedAt.Time().After(since) {
+			since = deployment.CreatedAt.Time()
+		}
+	}
+
+	var changes []ClusterChange
+	addChange := func(resource string, id int, name string, createdAt connection.DateTime, updatedAt connection.DateTime) {
+		change := ClusterChange{
+			Resource:  resource,
+			ID:        id,
+			Name:      name,
+			UpdatedAt: updatedAt,
+		}
+
+		switch {
+		case createdAt.Time().After(since):
+			change.Change = "created"
+		case updatedAt.Time().After(since):
+			change.Change = "updated"
+		default:
+			return
+		}
+
+		changes = append(changes, change)
+	}
+
+	clusterParams := clusterFilterParameters(clusterID)
+
+	targetGroups, err := service.GetTargetGroups(clusterParams)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving target groups: %s", err)
+	}
+	for _, targetGroup := range targetGroups {
+		addChange("target_group", targetGroup.ID, targetGroup.Name, targetGroup.CreatedAt, targetGroup.UpdatedAt)
+
+		targets, err := service.GetTargetGroupTargets(targetGroup.ID, connection.APIRequestParameters{})
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving targets for target group [%d]: %s", targetGroup.ID, err)
+		}
+		for _, target := range targets {
+			addChange("target", target.ID, target.Name, target.CreatedAt, target.UpdatedAt)
+		}
+	}
+
+	listeners, err := service.GetListeners(clusterParams)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving listeners: %s", err)
+	}
+	for _, listener := range listeners {
+		addChange("listener", listener.ID, listener.Name, listener.CreatedAt, listener.UpdatedAt)
+
+		accessIPs, err := service.GetListenerAccessIPs(listener.ID, connection.APIRequestParameters{})
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving access IPs for listener [%d]: %s", listener.ID, err)
+		}
+		for _, accessIP := range accessIPs {
+			addChange("access_ip", accessIP.ID, accessIP.IP.String(), accessIP.CreatedAt, accessIP.UpdatedAt)
+		}
+
+		binds, err := service.GetListenerBinds(listener.ID, connection.APIRequestParameters{})
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving binds for listener [%d]: %s", listener.ID, err)
+		}
+		for _, bind := range binds {
+			addChange("bind", bind.ID, strconv.Itoa(bind.Port), bind.CreatedAt, bind.UpdatedAt)
+		}
+
+		certificates, err := service.GetListenerCertificates(listener.ID, connection.APIRequestParameters{})
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving certificates for listener [%d]: %s", listener.ID, err)
+		}
+		for _, certificate := range certificates {
+			addChange("certificate", certificate.ID, certificate.Name, certificate.CreatedAt, certificate.UpdatedAt)
+		}
+	}
+
+	return changes, nil
+}
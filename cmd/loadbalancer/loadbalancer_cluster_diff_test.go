@@ -0,0 +1,117 @@
+package loadbalancer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ans-group/cli/test/mocks"
+	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_loadbalancerClusterDiffCmd_Args(t *testing.T) {
+	t.Run("ValidArgs_NoError", func(t *testing.T) {
+		err := loadbalancerClusterDiffCmd(nil).Args(nil, []string{"123"})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("MissingCluster_Error", func(t *testing.T) {
+		err := loadbalancerClusterDiffCmd(nil).Args(nil, []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "missing cluster", err.Error())
+	})
+}
+
+func Test_loadbalancerClusterDiff(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+
+		gomock.InOrder(
+			service.EXPECT().GetDeployments(gomock.Any()).Return([]loadbalancer.Deployment{}, nil),
+			service.EXPECT().GetTargetGroups(gomock.Any()).Return([]loadbalancer.TargetGroup{}, nil),
+			service.EXPECT().GetListeners(gomock.Any()).Return([]loadbalancer.Listener{}, nil),
+		)
+
+		err := loadbalancerClusterDiff(service, loadbalancerClusterDiffCmd(nil), []string{"123"})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("InvalidClusterID_ReturnsError", func(t *testing.T) {
+		err := loadbalancerClusterDiff(nil, loadbalancerClusterDiffCmd(nil), []string{"abc"})
+
+		assert.Equal(t, "invalid cluster ID", err.Error())
+	})
+
+	t.Run("GetDeploymentsError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+
+		service.EXPECT().GetDeployments(gomock.Any()).Return([]loadbalancer.Deployment{}, errors.New("test error"))
+
+		err := loadbalancerClusterDiff(service, loadbalancerClusterDiffCmd(nil), []string{"123"})
+
+		assert.Equal(t, "error retrieving deployments: test error", err.Error())
+	})
+}
+
+func Test_getClusterChanges(t *testing.T) {
+	t.Run("ChangesSinceLastSuccessfulDeployment", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+
+		gomock.InOrder(
+			service.EXPECT().GetDeployments(gomock.Any()).Return([]loadbalancer.Deployment{
+				{ID: 1, Successful: true, CreatedAt: "2024-01-01T10:00:00+0000"},
+				{ID: 2, Successful: false, CreatedAt: "2024-01-03T10:00:00+0000"},
+			}, nil),
+			service.EXPECT().GetTargetGroups(gomock.Any()).Return([]loadbalancer.TargetGroup{
+				{ID: 10, Name: "unchanged", CreatedAt: "2023-12-01T10:00:00+0000", UpdatedAt: "2023-12-01T10:00:00+0000"},
+			}, nil),
+			service.EXPECT().GetTargetGroupTargets(10, gomock.Any()).Return([]loadbalancer.Target{
+				{ID: 11, Name: "updated", CreatedAt: "2023-12-01T10:00:00+0000", UpdatedAt: "2024-01-02T10:00:00+0000"},
+			}, nil),
+			service.EXPECT().GetListeners(gomock.Any()).Return([]loadbalancer.Listener{
+				{ID: 20, Name: "created", CreatedAt: "2024-01-02T10:00:00+0000", UpdatedAt: "2024-01-02T10:00:00+0000"},
+			}, nil),
+			service.EXPECT().GetListenerAccessIPs(20, gomock.Any()).Return([]loadbalancer.AccessIP{}, nil),
+			service.EXPECT().GetListenerBinds(20, gomock.Any()).Return([]loadbalancer.Bind{}, nil),
+			service.EXPECT().GetListenerCertificates(20, gomock.Any()).Return([]loadbalancer.Certificate{}, nil),
+		)
+
+		changes, err := getClusterChanges(service, 123)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []ClusterChange{
+			{Resource: "target", ID: 11, Name: "updated", Change: "updated", UpdatedAt: "2024-01-02T10:00:00+0000"},
+			{Resource: "listener", ID: 20, Name: "created", Change: "created", UpdatedAt: "2024-01-02T10:00:00+0000"},
+		}, changes)
+	})
+
+	t.Run("GetListenersError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+
+		gomock.InOrder(
+			service.EXPECT().GetDeployments(gomock.Any()).Return([]loadbalancer.Deployment{}, nil),
+			service.EXPECT().GetTargetGroups(gomock.Any()).Return([]loadbalancer.TargetGroup{}, nil),
+			service.EXPECT().GetListeners(gomock.Any()).Return([]loadbalancer.Listener{}, errors.New("test error")),
+		)
+
+		_, err := getClusterChanges(service, 123)
+
+		assert.Equal(t, "error retrieving listeners: test error", err.Error())
+	})
+}
@@ -1,12 +1,16 @@
 package loadbalancer
 
 import (
+	"bytes"
 	"errors"
+	"io"
 	"testing"
 
 	"github.com/ans-group/cli/internal/pkg/clierrors"
+	"github.com/ans-group/cli/internal/pkg/input"
 	"github.com/ans-group/cli/test/mocks"
 	"github.com/ans-group/cli/test/test_output"
+	"github.com/ans-group/sdk-go/pkg/connection"
 	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
 	gomock "github.com/golang/mock/gomock"
 	"github.com/spf13/cobra"
@@ -95,6 +99,25 @@ func Test_loadbalancerClusterShow(t *testing.T) {
 		loadbalancerClusterShow(service, &cobra.Command{}, []string{"123", "456"})
 	})
 
+	t.Run("ConfirmNoChanges_SkipsPromptAndDeploy", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+		cmd := loadbalancerClusterDeployCmd(nil)
+		cmd.Flags().Set("confirm", "true")
+
+		gomock.InOrder(
+			service.EXPECT().GetDeployments(gomock.Any()).Return([]loadbalancer.Deployment{}, nil),
+			service.EXPECT().GetTargetGroups(gomock.Any()).Return([]loadbalancer.TargetGroup{}, nil),
+			service.EXPECT().GetListeners(gomock.Any()).Return([]loadbalancer.Listener{}, nil),
+		)
+
+		test_output.AssertErrorOutput(t, "No pending changes for cluster [123], skipping deployment\n", func() {
+			loadbalancerClusterDeploy(service, cmd, []string{"123"})
+		})
+	})
+
 	t.Run("GetClusterID_OutputsError", func(t *testing.T) {
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
@@ -221,6 +244,59 @@ func Test_loadbalancerClusterDeploy(t *testing.T) {
 		loadbalancerClusterDeploy(service, &cobra.Command{}, []string{"123"})
 	})
 
+	t.Run("ConfirmAccepted_Deploys", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		oldReader := input.InputReader
+		defer func() { input.InputReader = oldReader }()
+		input.InputReader = func() io.Reader {
+			return bytes.NewReader([]byte("y\n"))
+		}
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+		cmd := loadbalancerClusterDeployCmd(nil)
+		cmd.Flags().Set("confirm", "true")
+
+		gomock.InOrder(
+			service.EXPECT().GetDeployments(gomock.Any()).Return([]loadbalancer.Deployment{}, nil),
+			service.EXPECT().GetTargetGroups(gomock.Any()).Return([]loadbalancer.TargetGroup{{ID: 1, Name: "web", CreatedAt: "2024-01-02T10:00:00+0000"}}, nil),
+			service.EXPECT().GetTargetGroupTargets(1, gomock.Any()).Return([]loadbalancer.Target{}, nil),
+			service.EXPECT().GetListeners(gomock.Any()).Return([]loadbalancer.Listener{}, nil),
+			service.EXPECT().DeployCluster(123).Return(nil).Times(1),
+		)
+
+		test_output.AssertErrorOutput(t, "Deploy cluster [123]? [y/N]:\n", func() {
+			loadbalancerClusterDeploy(service, cmd, []string{"123"})
+		})
+	})
+
+	t.Run("ConfirmDeclined_SkipsDeploy", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		oldReader := input.InputReader
+		defer func() { input.InputReader = oldReader }()
+		input.InputReader = func() io.Reader {
+			return bytes.NewReader([]byte("n\n"))
+		}
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+		cmd := loadbalancerClusterDeployCmd(nil)
+		cmd.Flags().Set("confirm", "true")
+
+		gomock.InOrder(
+			service.EXPECT().GetDeployments(gomock.Any()).Return([]loadbalancer.Deployment{}, nil),
+			service.EXPECT().GetTargetGroups(gomock.Any()).Return([]loadbalancer.TargetGroup{{ID: 1, Name: "web", CreatedAt: "2024-01-02T10:00:00+0000"}}, nil),
+			service.EXPECT().GetTargetGroupTargets(1, gomock.Any()).Return([]loadbalancer.Target{}, nil),
+			service.EXPECT().GetListeners(gomock.Any()).Return([]loadbalancer.Listener{}, nil),
+		)
+
+		test_output.AssertErrorOutput(t, "Deploy cluster [123]? [y/N]:\nSkipping deployment of cluster [123]\n", func() {
+			loadbalancerClusterDeploy(service, cmd, []string{"123"})
+		})
+	})
+
 	t.Run("GetClusterID_OutputsError", func(t *testing.T) {
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
@@ -297,3 +373,62 @@ func Test_loadbalancerClusterValidate(t *testing.T) {
 		})
 	})
 }
+
+func Test_deployClusterAndWait(t *testing.T) {
+	t.Run("Successful_ReturnsDeployment", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+
+		gomock.InOrder(
+			service.EXPECT().GetDeployments(gomock.Any()).Return([]loadbalancer.Deployment{{ID: 10}}, nil),
+			service.EXPECT().DeployCluster(123).Return(nil),
+			service.EXPECT().GetDeployments(gomock.Any()).Return([]loadbalancer.Deployment{{ID: 11, Successful: true}, {ID: 10}}, nil),
+		)
+
+		deployment, err := deployClusterAndWait(service, 123)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 11, deployment.ID)
+	})
+
+	t.Run("Unsuccessful_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+
+		gomock.InOrder(
+			service.EXPECT().GetDeployments(gomock.Any()).Return([]loadbalancer.Deployment{}, nil),
+			service.EXPECT().DeployCluster(123).Return(nil),
+			service.EXPECT().GetDeployments(gomock.Any()).Return([]loadbalancer.Deployment{{ID: 11, Successful: false}}, nil),
+		)
+
+		_, err := deployClusterAndWait(service, 123)
+
+		assert.Equal(t, "deployment [11] was unsuccessful", err.Error())
+	})
+
+	t.Run("DeployClusterError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+
+		gomock.InOrder(
+			service.EXPECT().GetDeployments(gomock.Any()).Return([]loadbalancer.Deployment{}, nil),
+			service.EXPECT().DeployCluster(123).Return(errors.New("test error")),
+		)
+
+		_, err := deployClusterAndWait(service, 123)
+
+		assert.Equal(t, "error deploying cluster: test error", err.Error())
+	})
+}
+
+func Test_clusterFilterParameters(t *testing.T) {
+	params := clusterFilterParameters(123)
+
+	assert.Equal(t, []connection.APIRequestFiltering{{Property: "cluster_id", Operator: connection.EQOperator, Value: []string{"123"}}}, params.Filtering)
+}
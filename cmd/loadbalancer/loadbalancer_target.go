@@ -0,0 +1,348 @@
+package loadbalancer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/ans-group/cli/internal/pkg/factory"
+	"github.com/ans-group/cli/internal/pkg/output"
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+// loadbalancerDrainStateFileName is the name of the file within the user's home directory used for
+// persisting drained targets when no state file is specified
+const loadbalancerDrainStateFileName = ".ans_loadbalancer_drain.json"
+
+// loadbalancerDrainState represents persisted drain state, with the drain mode keyed by target
+type loadbalancerDrainState map[string]string
+
+func loadbalancerTargetRootCmd(f factory.ClientFactory, fs afero.Fs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "target",
+		Short: "sub-commands relating to targets",
+	}
+
+	// Child commands
+	cmd.AddCommand(loadbalancerTargetDrainCmd(f, fs))
+	cmd.AddCommand(loadbalancerTargetUndrainCmd(f, fs))
+
+	return cmd
+}
+
+func loadbalancerTargetDrainCmd(f factory.ClientFactory, fs afero.Fs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "drain <targetgroup: id> <target: id>...",
+		Short: "Drains a target",
+		Long: "This command takes one or more targets out of rotation, then validates and deploys the affected clusters, " +
+			"waiting for deployment to complete. Targets can alternatively be specified by IP address across all target groups.\n\n" +
+			"Drained targets are recorded in a state file, so that only targets drained by this command are undrained. " +
+			"Targets which are already drained are skipped",
+		Example: "ans loadbalancer target drain 123 456\nans loadbalancer target drain --ip 10.0.0.5",
+		Args:    loadbalancerTargetDrainArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := f.NewClient()
+			if err != nil {
+				return err
+			}
+
+			return loadbalancerTargetDrain(c.LoadBalancerService(), cmd, fs, args)
+		},
+	}
+
+	addLoadbalancerTargetDrainFlags(cmd)
+
+	return cmd
+}
+
+func loadbalancerTargetDrain(service loadbalancer.LoadBalancerService, cmd *cobra.Command, fs afero.Fs, args []string) error {
+	return loadbalancerTargetSetDrained(service, cmd, fs, args, true)
+}
+
+func loadbalancerTargetUndrainCmd(f factory.ClientFactory, fs afero.Fs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "undrain <targetgroup: id> <target: id>...",
+		Short: "Undrains a target",
+		Long: "This command returns one or more drained targets to rotation, then validates and deploys the affected clusters, " +
+			"waiting for deployment to complete. Targets can alternatively be specified by IP address across all target groups.\n\n" +
+			"Only targets drained by the drain command with the same mode are undrained, so that targets configured as backup or " +
+			"inactive aren't modified. Use --force to undrain targets regardless",
+		Example: "ans loadbalancer target undrain 123 456\nans loadbalancer target undrain --ip 10.0.0.5",
+		Args:    loadbalancerTargetDrainArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := f.NewClient()
+			if err != nil {
+				return err
+			}
+
+			return loadbalancerTargetUndrain(c.LoadBalancerService(), cmd, fs, args)
+		},
+	}
+
+	addLoadbalancerTargetDrainFlags(cmd)
+	cmd.Flags().Bool("force", false, "Specifies that targets should be undrained even if they weren't drained by the drain command")
+
+	return cmd
+}
+
+func loadbalancerTargetUndrain(service loadbalancer.LoadBalancerService, cmd *cobra.Command, fs afero.Fs, args []string) error {
+	return loadbalancerTargetSetDrained(service, cmd, fs, args, false)
+}
+
+func addLoadbalancerTargetDrainFlags(cmd *cobra.Command) {
+	cmd.Flags().String("ip", "", "IP address of targets, used in place of target group and target IDs")
+	cmd.Flags().String("mode", "backup", "Drain mode {backup, inactive}. 'backup' marks targets as backup, 'inactive' deactivates targets")
+	cmd.Flags().String("state-file", "", fmt.Sprintf("Specifies file for persisting drained targets (default is $HOME/%s)", loadbalancerDrainStateFileName))
+}
+
+func loadbalancerTargetDrainArgs(cmd *cobra.Command, args []string) error {
+	if cmd.Flags().Changed("ip") {
+		return nil
+	}
+	if len(args) < 1 {
+		return errors.New("missing target group")
+	}
+	if len(args) < 2 {
+		return errors.New("missing target")
+	}
+
+	return nil
+}
+
+type targetGroupTarget struct {
+	TargetGroupID int
+	TargetID      int
+}
+
+func (t targetGroupTarget) String() string {
+	return fmt.Sprintf("%d/%d", t.TargetGroupID, t.TargetID)
+}
+
+func loadbalancerTargetSetDrained(service loadbalancer.LoadBalancerService, cmd *cobra.Command, fs afero.Fs, args []string, drained bool) error {
+	patchRequest := loadbalancer.PatchTargetRequest{}
+	mode, _ := cmd.Flags().GetString("mode")
+	switch mode {
+	case "backup":
+		patchRequest.Backup = &drained
+	case "inactive":
+		active := !drained
+		patchRequest.Active = &active
+	default:
+		return fmt.Errorf("invalid drain mode [%s]", mode)
+	}
+
+	statePath, err := getLoadbalancerDrainStatePath(cmd)
+	if err != nil {
+		return err
+	}
+
+	state, err := readLoadbalancerDrainState(fs, statePath)
+	if err != nil {
+		return err
+	}
+
+	targets, err := getLoadbalancerDrainTargets(service, cmd, args)
+	if err != nil {
+		return err
+	}
+
+	force := false
+	if cmd.Flags().Lookup("force") != nil {
+		force, _ = cmd.Flags().GetBool("force")
+	}
+
+	// Retrieve cluster for each target group before updating its targets, so each affected cluster is
+	// deployed once, and targets aren't updated where their cluster can't be determined
+	targetGroupClusters := make(map[int]int)
+	var clusterIDs []int
+	var updatedTargets []loadbalancer.Target
+	for _, target := range targets {
+		if drained {
+			existingTarget, err := service.GetTargetGroupTarget(target.TargetGroupID, target.TargetID)
+			if err != nil {
+				output.OutputWithErrorLevelf("Error retrieving target [%d]: %s", target.TargetID, err)
+				continue
+			}
+
+			if (mode == "backup" && existingTarget.Backup) || (mode == "inactive" && !existingTarget.Active) {
+				output.Errorf("Target [%d] is already drained with mode [%s], skipping", target.TargetID, mode)
+				continue
+			}
+		} else if state[target.String()] != mode && !force {
+			output.OutputWithErrorLevelf("Target [%d] wasn't drained with mode [%s] by drain command, use --force to undrain regardless", target.TargetID, mode)
+			continue
+		}
+
+		if _, ok := targetGroupClusters[target.TargetGroupID]; !ok {
+			targetGroup, err := service.GetTargetGroup(target.TargetGroupID)
+			if err != nil {
+				output.OutputWithErrorLevelf("Error retrieving target group [%d]: %s", target.TargetGroupID, err)
+				continue
+			}
+			targetGroupClusters[target.TargetGroupID] = targetGroup.ClusterID
+		}
+
+		err := service.PatchTargetGroupTarget(target.TargetGroupID, target.TargetID, patchRequest)
+		if err != nil {
+			output.OutputWithErrorLevelf("Error updating target [%d]: %s", target.TargetID, err)
+			continue
+		}
+
+		if drained {
+			state[target.String()] = mode
+		} else {
+			delete(state, target.String())
+		}
+
+		if !slices.Contains(clusterIDs, targetGroupClusters[target.TargetGroupID]) {
+			clusterIDs = append(clusterIDs, targetGroupClusters[target.TargetGroupID])
+		}
+
+		updatedTarget, err := service.GetTargetGroupTarget(target.TargetGroupID, target.TargetID)
+		if err != nil {
+			output.OutputWithErrorLevelf("Error retrieving updated target [%d]: %s", target.TargetID, err)
+			continue
+		}
+
+		updatedTargets = append(updatedTargets, updatedTarget)
+	}
+
+	err = writeLoadbalancerDrainState(fs, statePath, state)
+	if err != nil {
+		output.OutputWithErrorLevelf("Error persisting drained targets: %s", err)
+	}
+
+	sort.Ints(clusterIDs)
+
+	for _, clusterID := range clusterIDs {
+		err := service.ValidateCluster(clusterID)
+		if err != nil {
+			output.OutputWithErrorLevelf("Error validating cluster [%d]: %s", clusterID, err)
+			continue
+		}
+
+		_, err = deployClusterAndWait(service, clusterID)
+		if err != nil {
+			output.OutputWithErrorLevelf("Error deploying cluster [%d]: %s", clusterID, err)
+			continue
+		}
+	}
+
+	return output.CommandOutput(cmd, TargetCollection(updatedTargets))
+}
+
+func getLoadbalancerDrainStatePath(cmd *cobra.Command) (string, error) {
+	statePath, _ := cmd.Flags().GetString("state-file")
+	if statePath != "" {
+		return statePath, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine home directory for state file: %s", err)
+	}
+
+	return filepath.Join(home, loadbalancerDrainStateFileName), nil
+}
+
+func readLoadbalancerDrainState(fs afero.Fs, path string) (loadbalancerDrainState, error) {
+	state := make(loadbalancerDrainState)
+
+	exists, err := afero.Exists(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %s", err)
+	}
+	if !exists {
+		return state, nil
+	}
+
+	content, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %s", err)
+	}
+
+	err = json.Unmarshal(content, &state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse state file: %s", err)
+	}
+
+	return state, nil
+}
+
+func writeLoadbalancerDrainState(fs afero.Fs, path string, state loadbalancerDrainState) error {
+	content, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %s", err)
+	}
+
+	err = afero.WriteFile(fs, path, content, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to write state file: %s", err)
+	}
+
+	return nil
+}
+
+// getLoadbalancerDrainTargets returns targets from args, or targets matching the IP address
+// provided by the ip flag across all target groups
+func getLoadbalancerDrainTargets(service loadbalancer.LoadBalancerService, cmd *cobra.Command, args []string) ([]targetGroupTarget, error) {
+	var targets []targetGroupTarget
+
+	if cmd.Flags().Changed("ip") {
+		ip, _ := cmd.Flags().GetString("ip")
+
+		targetGroups, err := service.GetTargetGroups(connection.APIRequestParameters{})
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving target groups: %s", err)
+		}
+
+		params := connection.APIRequestParameters{}
+		params.WithFilter(connection.APIRequestFiltering{
+			Property: "ip",
+			Operator: connection.EQOperator,
+			Value:    []string{ip},
+		})
+
+		for _, targetGroup := range targetGroups {
+			groupTargets, err := service.GetTargetGroupTargets(targetGroup.ID, params)
+			if err != nil {
+				return nil, fmt.Errorf("error retrieving targets for target group [%d]: %s", targetGroup.ID, err)
+			}
+
+			for _, target := range groupTargets {
+				targets = append(targets, targetGroupTarget{TargetGroupID: targetGroup.ID, TargetID: target.ID})
+			}
+		}
+
+		if len(targets) < 1 {
+			return nil, fmt.Errorf("no targets found with IP [%s]", ip)
+		}
+
+		return targets, nil
+	}
+
+	targetGroupID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid target group ID")
+	}
+
+	for _, arg := range args[1:] {
+		targetID, err := strconv.Atoi(arg)
+		if err != nil {
+			output.OutputWithErrorLevelf("Invalid target ID [%s]", arg)
+			continue
+		}
+
+		targets = append(targets, targetGroupTarget{TargetGroupID: targetGroupID, TargetID: targetID})
+	}
+
+	return targets, nil
+}
@@ -0,0 +1,303 @@
+package loadbalancer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ans-group/cli/test/mocks"
+	"github.com/ans-group/cli/test/test_output"
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_loadbalancerTargetDrainCmd_Args(t *testing.T) {
+	t.Run("ValidArgs_NoError", func(t *testing.T) {
+		cmd := loadbalancerTargetDrainCmd(nil, nil)
+		err := cmd.Args(cmd, []string{"123", "456"})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("IPFlag_NoError", func(t *testing.T) {
+		cmd := loadbalancerTargetDrainCmd(nil, nil)
+		cmd.Flags().Set("ip", "10.0.0.5")
+		err := cmd.Args(cmd, []string{})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("MissingTargetGroup_Error", func(t *testing.T) {
+		cmd := loadbalancerTargetDrainCmd(nil, nil)
+		err := cmd.Args(cmd, []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "missing target group", err.Error())
+	})
+
+	t.Run("MissingTarget_Error", func(t *testing.T) {
+		cmd := loadbalancerTargetDrainCmd(nil, nil)
+		err := cmd.Args(cmd, []string{"123"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "missing target", err.Error())
+	})
+}
+
+func Test_loadbalancerTargetDrain(t *testing.T) {
+	t.Run("Valid_DrainsAndDeploys", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+
+		backup := true
+		gomock.InOrder(
+			service.EXPECT().GetTargetGroupTarget(123, 456).Return(loadbalancer.Target{ID: 456, Active: true}, nil),
+			service.EXPECT().GetTargetGroup(123).Return(loadbalancer.TargetGroup{ID: 123, ClusterID: 1}, nil),
+			service.EXPECT().PatchTargetGroupTarget(123, 456, loadbalancer.PatchTargetRequest{Backup: &backup}).Return(nil),
+			service.EXPECT().GetTargetGroupTarget(123, 456).Return(loadbalancer.Target{}, nil),
+			service.EXPECT().ValidateCluster(1).Return(nil),
+			service.EXPECT().GetDeployments(gomock.Any()).Return([]loadbalancer.Deployment{{ID: 10}}, nil),
+			service.EXPECT().DeployCluster(1).Return(nil),
+			service.EXPECT().GetDeployments(gomock.Any()).Return([]loadbalancer.Deployment{{ID: 10}, {ID: 11, Successful: true}}, nil),
+		)
+
+		err := loadbalancerTargetDrain(service, loadbalancerTargetDrainCmd(nil, nil), afero.NewMemMapFs(), []string{"123", "456"})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("InactiveMode_DeactivatesTarget", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+		cmd := loadbalancerTargetDrainCmd(nil, nil)
+		cmd.Flags().Set("mode", "inactive")
+
+		active := false
+		gomock.InOrder(
+			service.EXPECT().GetTargetGroupTarget(123, 456).Return(loadbalancer.Target{ID: 456, Active: true}, nil),
+			service.EXPECT().GetTargetGroup(123).Return(loadbalancer.TargetGroup{ID: 123, ClusterID: 1}, nil),
+			service.EXPECT().PatchTargetGroupTarget(123, 456, loadbalancer.PatchTargetRequest{Active: &active}).Return(nil),
+			service.EXPECT().GetTargetGroupTarget(123, 456).Return(loadbalancer.Target{}, nil),
+			service.EXPECT().ValidateCluster(1).Return(nil),
+			service.EXPECT().GetDeployments(gomock.Any()).Return([]loadbalancer.Deployment{}, nil),
+			service.EXPECT().DeployCluster(1).Return(nil),
+			service.EXPECT().GetDeployments(gomock.Any()).Return([]loadbalancer.Deployment{{ID: 11, Successful: true}}, nil),
+		)
+
+		err := loadbalancerTargetDrain(service, cmd, afero.NewMemMapFs(), []string{"123", "456"})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("IPFlag_DrainsMatchingTargets", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+		cmd := loadbalancerTargetDrainCmd(nil, nil)
+		cmd.Flags().Set("ip", "10.0.0.5")
+
+		expectedParams := connection.APIRequestParameters{}
+		expectedParams.WithFilter(connection.APIRequestFiltering{Property: "ip", Operator: connection.EQOperator, Value: []string{"10.0.0.5"}})
+
+		gomock.InOrder(
+			service.EXPECT().GetTargetGroups(gomock.Any()).Return([]loadbalancer.TargetGroup{{ID: 123}, {ID: 124}}, nil),
+			service.EXPECT().GetTargetGroupTargets(123, expectedParams).Return([]loadbalancer.Target{{ID: 456}}, nil),
+			service.EXPECT().GetTargetGroupTargets(124, expectedParams).Return([]loadbalancer.Target{{ID: 457}}, nil),
+		)
+		service.EXPECT().PatchTargetGroupTarget(123, 456, gomock.Any()).Return(nil)
+		service.EXPECT().PatchTargetGroupTarget(124, 457, gomock.Any()).Return(nil)
+		service.EXPECT().GetTargetGroup(123).Return(loadbalancer.TargetGroup{ID: 123, ClusterID: 1}, nil)
+		service.EXPECT().GetTargetGroup(124).Return(loadbalancer.TargetGroup{ID: 124, ClusterID: 1}, nil)
+		service.EXPECT().GetTargetGroupTarget(123, 456).Return(loadbalancer.Target{Active: true}, nil).Times(2)
+		service.EXPECT().GetTargetGroupTarget(124, 457).Return(loadbalancer.Target{Active: true}, nil).Times(2)
+		service.EXPECT().ValidateCluster(1).Return(nil).Times(1)
+		gomock.InOrder(
+			service.EXPECT().GetDeployments(gomock.Any()).Return([]loadbalancer.Deployment{}, nil),
+			service.EXPECT().DeployCluster(1).Return(nil).Times(1),
+			service.EXPECT().GetDeployments(gomock.Any()).Return([]loadbalancer.Deployment{{ID: 11, Successful: true}}, nil),
+		)
+
+		err := loadbalancerTargetDrain(service, cmd, afero.NewMemMapFs(), []string{})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("IPFlagNoTargets_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+		cmd := loadbalancerTargetDrainCmd(nil, nil)
+		cmd.Flags().Set("ip", "10.0.0.5")
+
+		gomock.InOrder(
+			service.EXPECT().GetTargetGroups(gomock.Any()).Return([]loadbalancer.TargetGroup{{ID: 123}}, nil),
+			service.EXPECT().GetTargetGroupTargets(123, gomock.Any()).Return([]loadbalancer.Target{}, nil),
+		)
+
+		err := loadbalancerTargetDrain(service, cmd, afero.NewMemMapFs(), []string{})
+
+		assert.Equal(t, "no targets found with IP [10.0.0.5]", err.Error())
+	})
+
+	t.Run("InvalidMode_ReturnsError", func(t *testing.T) {
+		cmd := loadbalancerTargetDrainCmd(nil, nil)
+		cmd.Flags().Set("mode", "invalid")
+
+		err := loadbalancerTargetDrain(nil, cmd, afero.NewMemMapFs(), []string{"123", "456"})
+
+		assert.Equal(t, "invalid drain mode [invalid]", err.Error())
+	})
+
+	t.Run("InvalidTargetGroupID_ReturnsError", func(t *testing.T) {
+		err := loadbalancerTargetDrain(nil, loadbalancerTargetDrainCmd(nil, nil), afero.NewMemMapFs(), []string{"abc", "456"})
+
+		assert.Equal(t, "invalid target group ID", err.Error())
+	})
+
+	t.Run("PatchTargetGroupTargetError_OutputsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+
+		gomock.InOrder(
+			service.EXPECT().GetTargetGroupTarget(123, 456).Return(loadbalancer.Target{Active: true}, nil),
+			service.EXPECT().GetTargetGroup(123).Return(loadbalancer.TargetGroup{ID: 123, ClusterID: 1}, nil),
+			service.EXPECT().PatchTargetGroupTarget(123, 456, gomock.Any()).Return(errors.New("test error")),
+		)
+
+		test_output.AssertErrorOutput(t, "Error updating target [456]: test error\n", func() {
+			loadbalancerTargetDrain(service, loadbalancerTargetDrainCmd(nil, nil), afero.NewMemMapFs(), []string{"123", "456"})
+		})
+	})
+
+	t.Run("GetTargetGroupError_SkipsTargetWithoutRecordingState", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+		fs := afero.NewMemMapFs()
+		cmd := loadbalancerTargetDrainCmd(nil, nil)
+		cmd.Flags().Set("state-file", "/state.json")
+
+		service.EXPECT().GetTargetGroupTarget(123, 456).Return(loadbalancer.Target{Active: true}, nil)
+		service.EXPECT().GetTargetGroup(123).Return(loadbalancer.TargetGroup{}, errors.New("test error"))
+
+		test_output.AssertErrorOutput(t, "Error retrieving target group [123]: test error\n", func() {
+			loadbalancerTargetDrain(service, cmd, fs, []string{"123", "456"})
+		})
+
+		state, err := readLoadbalancerDrainState(fs, "/state.json")
+		assert.Nil(t, err)
+		assert.Empty(t, state)
+	})
+
+	t.Run("ValidateClusterError_OutputsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+
+		gomock.InOrder(
+			service.EXPECT().GetTargetGroupTarget(123, 456).Return(loadbalancer.Target{Active: true}, nil),
+			service.EXPECT().GetTargetGroup(123).Return(loadbalancer.TargetGroup{ID: 123, ClusterID: 1}, nil),
+			service.EXPECT().PatchTargetGroupTarget(123, 456, gomock.Any()).Return(nil),
+			service.EXPECT().GetTargetGroupTarget(123, 456).Return(loadbalancer.Target{}, nil),
+			service.EXPECT().ValidateCluster(1).Return(errors.New("test error")),
+		)
+
+		test_output.AssertErrorOutput(t, "Error validating cluster [1]: test error\n", func() {
+			loadbalancerTargetDrain(service, loadbalancerTargetDrainCmd(nil, nil), afero.NewMemMapFs(), []string{"123", "456"})
+		})
+	})
+}
+
+func Test_loadbalancerTargetUndrain(t *testing.T) {
+	t.Run("Valid_UndrainsAndDeploys", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/tmp/drain.json", []byte(`{"123/456":"backup","123/457":"inactive"}`), 0600)
+		cmd := loadbalancerTargetUndrainCmd(nil, nil)
+		cmd.Flags().Set("state-file", "/tmp/drain.json")
+
+		backup := false
+		gomock.InOrder(
+			service.EXPECT().GetTargetGroup(123).Return(loadbalancer.TargetGroup{ID: 123, ClusterID: 1}, nil),
+			service.EXPECT().PatchTargetGroupTarget(123, 456, loadbalancer.PatchTargetRequest{Backup: &backup}).Return(nil),
+			service.EXPECT().GetTargetGroupTarget(123, 456).Return(loadbalancer.Target{}, nil),
+			service.EXPECT().ValidateCluster(1).Return(nil),
+			service.EXPECT().GetDeployments(gomock.Any()).Return([]loadbalancer.Deployment{}, nil),
+			service.EXPECT().DeployCluster(1).Return(nil),
+			service.EXPECT().GetDeployments(gomock.Any()).Return([]loadbalancer.Deployment{{ID: 11, Successful: true}}, nil),
+		)
+
+		err := loadbalancerTargetUndrain(service, cmd, fs, []string{"123", "456"})
+
+		assert.Nil(t, err)
+		content, _ := afero.ReadFile(fs, "/tmp/drain.json")
+		assert.Equal(t, `{"123/457":"inactive"}`, string(content))
+	})
+
+	t.Run("NotDrainedByCommand_OutputsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/tmp/drain.json", []byte(`{"123/456":"inactive"}`), 0600)
+		cmd := loadbalancerTargetUndrainCmd(nil, nil)
+		cmd.Flags().Set("state-file", "/tmp/drain.json")
+
+		test_output.AssertErrorOutput(t, "Target [456] wasn't drained with mode [backup] by drain command, use --force to undrain regardless\n", func() {
+			loadbalancerTargetUndrain(service, cmd, fs, []string{"123", "456"})
+		})
+	})
+
+	t.Run("NotDrainedByCommandWithForce_UndrainsTarget", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockLoadBalancerService(mockCtrl)
+		cmd := loadbalancerTargetUndrainCmd(nil, nil)
+		cmd.Flags().Set("state-file", "/tmp/drain.json")
+		cmd.Flags().Set("force", "true")
+
+		backup := false
+		gomock.InOrder(
+			service.EXPECT().GetTargetGroup(123).Return(loadbalancer.TargetGroup{ID: 123, ClusterID: 1}, nil),
+			service.EXPECT().PatchTargetGroupTarget(123, 456, loadbalancer.PatchTargetRequest{Backup: &backup}).Return(nil),
+			service.EXPECT().GetTargetGroupTarget(123, 456).Return(loadbalancer.Target{}, nil),
+			service.EXPECT().ValidateCluster(1).Return(nil),
+			service.EXPECT().GetDeployments(gomock.Any()).Return([]loadbalancer.Deployment{}, nil),
+			service.EXPECT().DeployCluster(1).Return(nil),
+			service.EXPECT().GetDeployments(gomock.Any()).Return([]loadbalancer.Deployment{{ID: 11, Successful: true}}, nil),
+		)
+
+		err := loadbalancerTargetUndrain(service, cmd, afero.NewMemMapFs(), []string{"123", "456"})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("InvalidStateFile_ReturnsError", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/tmp/drain.json", []byte(`invalid`), 0600)
+		cmd := loadbalancerTargetUndrainCmd(nil, nil)
+		cmd.Flags().Set("state-file", "/tmp/drain.json")
+
+		err := loadbalancerTargetUndrain(nil, cmd, fs, []string{"123", "456"})
+
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "failed to parse state file")
+	})
+}
@@ -1,6 +1,7 @@
 package loadbalancer
 
 import (
+	"github.com/ans-group/sdk-go/pkg/connection"
 	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
 )
 
@@ -93,3 +94,48 @@ type VIPCollection []loadbalancer.VIP
 func (m VIPCollection) DefaultColumns() []string {
 	return []string{"id", "cluster_id", "internal_cidr", "external_cidr"}
 }
+
+// ClusterImportResource represents a resource created during cluster configuration import
+type ClusterImportResource struct {
+	Type     string `json:"type"`
+	SourceID int    `json:"source_id"`
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+}
+
+type ClusterImportResourceCollection []ClusterImportResource
+
+func (m ClusterImportResourceCollection) DefaultColumns() []string {
+	return []string{"type", "source_id", "id", "name"}
+}
+
+// ClusterChange represents a pending change to a cluster resource
+type ClusterChange struct {
+	Resource  string              `json:"resource"`
+	ID        int                 `json:"id"`
+	Name      string              `json:"name"`
+	Change    string              `json:"change"`
+	UpdatedAt connection.DateTime `json:"updated_at"`
+}
+
+type ClusterChangeCollection []ClusterChange
+
+func (m ClusterChangeCollection) DefaultColumns() []string {
+	return []string{"resource", "id", "name", "change", "updated_at"}
+}
+
+// ACLTestResult represents the result of evaluating an ACL against a sample request
+type ACLTestResult struct {
+	ID      int      `json:"id"`
+	Name    string   `json:"name"`
+	Scope   string   `json:"scope"`
+	Result  string   `json:"result"`
+	Actions []string `json:"actions"`
+	Notes   string   `json:"notes"`
+}
+
+type ACLTestResultCollection []ACLTestResult
+
+func (m ACLTestResultCollection) DefaultColumns() []string {
+	return []string{"id", "name", "scope", "result", "actions", "notes"}
+}
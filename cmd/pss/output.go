@@ -1,6 +1,11 @@
 package pss
 
 import (
+	"fmt"
+	"reflect"
+
+	"github.com/ans-group/cli/internal/pkg/output"
+	"github.com/ans-group/sdk-go/pkg/connection"
 	"github.com/ans-group/sdk-go/pkg/service/pss"
 )
 
@@ -69,3 +74,58 @@ type CaseCollection []pss.Case
 func (m CaseCollection) DefaultColumns() []string {
 	return []string{"id", "case_type", "title", "status", "created_at", "updated_at"}
 }
+
+// RequestWatchEvent represents a new reply or status change observed whilst watching a request
+type RequestWatchEvent struct {
+	Event       string              `json:"event"`
+	RequestID   int                 `json:"request_id"`
+	Subject     string              `json:"subject"`
+	Status      string              `json:"status"`
+	ReplyID     string              `json:"reply_id"`
+	AuthorName  string              `json:"author_name"`
+	Description string              `json:"description"`
+	CreatedAt   connection.DateTime `json:"created_at"`
+}
+
+type RequestWatchEventCollection []RequestWatchEvent
+
+func (m RequestWatchEventCollection) DefaultColumns() []string {
+	return []string{"event", "request_id", "status", "reply_id", "author_name", "created_at"}
+}
+
+// ReportRow represents aggregated statistics for a group of requests or cases
+type ReportRow struct {
+	Type                  string  `json:"type"`
+	Dimension             string  `json:"dimension"`
+	Value                 string  `json:"value"`
+	Total                 int     `json:"total"`
+	Open                  int     `json:"open"`
+	Resolved              int     `json:"resolved"`
+	AvgFirstReplyHours    float64 `json:"avg_first_reply_hours"`
+	MedianFirstReplyHours float64 `json:"median_first_reply_hours"`
+	AvgResolutionHours    float64 `json:"avg_resolution_hours"`
+	MedianResolutionHours float64 `json:"median_resolution_hours"`
+	Open0To7d             int     `json:"open_0_7d"`
+	Open7To30d            int     `json:"open_7_30d"`
+	Open30To90d           int     `json:"open_30_90d"`
+	Open90dPlus           int     `json:"open_90d_plus"`
+}
+
+type ReportRowCollection []ReportRow
+
+func (m ReportRowCollection) DefaultColumns() []string {
+	return []string{"type", "dimension", "value", "total", "open", "resolved", "median_first_reply_hours", "median_resolution_hours", "open_0_7d", "open_7_30d", "open_30_90d", "open_90d_plus"}
+}
+
+var hoursFieldValueHandler = func(reflectedValue reflect.Value) string {
+	return fmt.Sprintf("%.1f", reflectedValue.Float())
+}
+
+func (m ReportRowCollection) FieldValueHandlers() map[string]output.FieldValueHandlerFunc {
+	return map[string]output.FieldValueHandlerFunc{
+		"avg_first_reply_hours":    hoursFieldValueHandler,
+		"median_first_reply_hours": hoursFieldValueHandler,
+		"avg_resolution_hours":     hoursFieldValueHandler,
+		"median_resolution_hours":  hoursFieldValueHandler,
+	}
+}
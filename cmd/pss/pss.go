@@ -13,10 +13,13 @@ func PSSRootCmd(f factory.ClientFactory, fs afero.Fs) *cobra.Command {
 		Short: "Commands relating to PSS service",
 	}
 
+	// Child commands
+	cmd.AddCommand(pssReportCmd(f))
+
 	// Child root commands
-	cmd.AddCommand(pssRequestRootCmd(f))
+	cmd.AddCommand(pssRequestRootCmd(f, fs))
 	cmd.AddCommand(pssReplyRootCmd(f, fs))
-	cmd.AddCommand(pssIncidentRootCmd(f))
+	cmd.AddCommand(pssIncidentRootCmd(f, fs))
 	cmd.AddCommand(pssChangeRootCmd(f))
 	cmd.AddCommand(pssCaseRootCmd(f))
 	cmd.AddCommand(pssProblemRootCmd(f))
@@ -48,12 +48,7 @@ func pssCaseList(service pss.PSSService, cmd *cobra.Command, args []string) erro
 		return err
 	}
 
-	paginatedCases, err := service.GetCasesPaginated(params)
-	if err != nil {
-		return err
-	}
-
-	return output.CommandOutputPaginated(cmd, CaseCollection(paginatedCases.Items()), paginatedCases)
+	return output.CommandOutputStream[CaseCollection](cmd, params, service.GetCasesPaginated)
 }
 
 func pssCaseShowCmd(f factory.ClientFactory) *cobra.Command {
@@ -50,12 +50,7 @@ func pssChangeList(service pss.PSSService, cmd *cobra.Command, args []string) er
 		return err
 	}
 
-	paginatedChanges, err := service.GetChangeCasesPaginated(params)
-	if err != nil {
-		return err
-	}
-
-	return output.CommandOutputPaginated(cmd, ChangeCaseCollection(paginatedChanges.Items()), paginatedChanges)
+	return output.CommandOutputStream[ChangeCaseCollection](cmd, params, service.GetChangeCasesPaginated)
 }
 
 func pssChangeShowCmd(f factory.ClientFactory) *cobra.Command {
@@ -0,0 +1,67 @@
+package pss
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/ans-group/cli/internal/pkg/input"
+	"github.com/ans-group/cli/internal/pkg/output"
+	"github.com/ans-group/sdk-go/pkg/config"
+	"github.com/ans-group/sdk-go/pkg/service/pss"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+func addComposeFlags(cmd *cobra.Command) {
+	cmd.Flags().String("template", "", "Specifies name of template from config (pss.templates.<name>) to pre-fill editor with. Requires $VISUAL or $EDITOR, and can't be used with piped input")
+	cmd.Flags().StringArray("attach", []string{}, "Specifies path of file to attach. Can be repeated")
+}
+
+// composeInput returns the value of flag flagName if set, otherwise reads input with given name via
+// $EDITOR or piped input, pre-filled with the template specified by the template flag
+func composeInput(cmd *cobra.Command, flagName string, name string) (string, error) {
+	if cmd.Flags().Changed(flagName) {
+		return cmd.Flags().GetString(flagName)
+	}
+
+	var template string
+	if cmd.Flags().Changed("template") {
+		templateName, _ := cmd.Flags().GetString("template")
+		template = config.GetString(fmt.Sprintf("pss.templates.%s", templateName))
+		if len(template) < 1 {
+			return "", fmt.Errorf("template [%s] not found in config", templateName)
+		}
+	}
+
+	return input.ComposeInput(name, template)
+}
+
+// getAttachmentPaths returns paths from the attach flag, returning an error if any path can't be read
+func getAttachmentPaths(cmd *cobra.Command, fs afero.Fs) ([]string, error) {
+	paths, _ := cmd.Flags().GetStringArray("attach")
+	for _, path := range paths {
+		ok, err := afero.Exists(fs, path)
+		if err != nil || !ok {
+			return nil, fmt.Errorf("attachment file [%s] not found", path)
+		}
+	}
+
+	return paths, nil
+}
+
+// uploadAttachments uploads files at paths as attachments to reply with ID replyID
+func uploadAttachments(service pss.PSSService, fs afero.Fs, replyID string, paths []string) {
+	for _, path := range paths {
+		fileStream, err := fs.Open(path)
+		if err != nil {
+			output.OutputWithErrorLevelf("Failed to open file [%s]: %s", path, err)
+			continue
+		}
+
+		err = service.UploadReplyAttachmentStream(replyID, filepath.Base(path), fileStream)
+		fileStream.Close()
+		if err != nil {
+			output.OutputWithErrorLevelf("Failed to upload attachment [%s]: %s", path, err)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package pss
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/ans-group/cli/internal/pkg/input"
+	"github.com/ans-group/cli/test/mocks"
+	"github.com/ans-group/cli/test/test_output"
+	"github.com/ans-group/sdk-go/pkg/config"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestComposeCmd() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("description", "", "")
+	addComposeFlags(cmd)
+
+	return cmd
+}
+
+func Test_composeInput(t *testing.T) {
+	t.Run("FlagSet_ReturnsFlagValue", func(t *testing.T) {
+		cmd := newTestComposeCmd()
+		cmd.ParseFlags([]string{"--description", "test description"})
+
+		text, err := composeInput(cmd, "description", "description")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "test description", text)
+	})
+
+	t.Run("PipedInput_ReturnsInput", func(t *testing.T) {
+		oldReader := input.InputReader
+		oldIsInteractive := input.IsInteractive
+		defer func() {
+			input.InputReader = oldReader
+			input.IsInteractive = oldIsInteractive
+		}()
+
+		input.IsInteractive = func() bool { return false }
+		input.InputReader = func() io.Reader {
+			return bytes.NewReader([]byte("piped description\n"))
+		}
+
+		cmd := newTestComposeCmd()
+
+		text, err := composeInput(cmd, "description", "description")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "piped description", text)
+	})
+
+	t.Run("PipedInputWithTemplate_ReturnsError", func(t *testing.T) {
+		oldIsInteractive := input.IsInteractive
+		defer func() { input.IsInteractive = oldIsInteractive }()
+
+		input.IsInteractive = func() bool { return false }
+
+		config.Set("", "pss.templates.update", "template text")
+		defer config.Reset()
+
+		cmd := newTestComposeCmd()
+		cmd.ParseFlags([]string{"--template", "update"})
+
+		_, err := composeInput(cmd, "description", "description")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "template can't be used when description is piped to stdin", err.Error())
+	})
+
+	t.Run("TemplateNotFound_ReturnsError", func(t *testing.T) {
+		cmd := newTestComposeCmd()
+		cmd.ParseFlags([]string{"--template", "missing"})
+
+		_, err := composeInput(cmd, "description", "description")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "template [missing] not found in config", err.Error())
+	})
+}
+
+func Test_getAttachmentPaths(t *testing.T) {
+	t.Run("ExistingFiles_ReturnsPaths", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/test.txt", []byte("test"), 0644)
+
+		cmd := newTestComposeCmd()
+		cmd.ParseFlags([]string{"--attach", "/test.txt"})
+
+		paths, err := getAttachmentPaths(cmd, fs)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"/test.txt"}, paths)
+	})
+
+	t.Run("MissingFile_ReturnsError", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+
+		cmd := newTestComposeCmd()
+		cmd.ParseFlags([]string{"--attach", "/missing.txt"})
+
+		_, err := getAttachmentPaths(cmd, fs)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "attachment file [/missing.txt] not found", err.Error())
+	})
+}
+
+func Test_uploadAttachments(t *testing.T) {
+	t.Run("UploadsEachFile", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/dir/one.txt", []byte("one"), 0644)
+		afero.WriteFile(fs, "/dir/two.txt", []byte("two"), 0644)
+
+		gomock.InOrder(
+			service.EXPECT().UploadReplyAttachmentStream("C123", "one.txt", gomock.Any()).Return(nil),
+			service.EXPECT().UploadReplyAttachmentStream("C123", "two.txt", gomock.Any()).Return(nil),
+		)
+
+		uploadAttachments(service, fs, "C123", []string{"/dir/one.txt", "/dir/two.txt"})
+	})
+
+	t.Run("UploadError_OutputsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/one.txt", []byte("one"), 0644)
+
+		service.EXPECT().UploadReplyAttachmentStream("C123", "one.txt", gomock.Any()).Return(errors.New("test error"))
+
+		test_output.AssertErrorOutput(t, "Failed to upload attachment [/one.txt]: test error\n", func() {
+			uploadAttachments(service, fs, "C123", []string{"/one.txt"})
+		})
+	})
+}
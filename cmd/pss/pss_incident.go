@@ -1,17 +1,20 @@
 package pss
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 
 	"github.com/ans-group/cli/internal/pkg/factory"
 	"github.com/ans-group/cli/internal/pkg/helper"
 	"github.com/ans-group/cli/internal/pkg/output"
+	"github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/ans-group/sdk-go/pkg/service/pss"
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 )
 
-func pssIncidentRootCmd(f factory.ClientFactory) *cobra.Command {
+func pssIncidentRootCmd(f factory.ClientFactory, fs afero.Fs) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "incident",
 		Short: "sub-commands relating to incident cases",
@@ -20,7 +23,7 @@ func pssIncidentRootCmd(f factory.ClientFactory) *cobra.Command {
 	// Child commands
 	cmd.AddCommand(pssIncidentListCmd(f))
 	cmd.AddCommand(pssIncidentShowCmd(f))
-	cmd.AddCommand(pssIncidentCreateCmd(f))
+	cmd.AddCommand(pssIncidentCreateCmd(f, fs))
 	cmd.AddCommand(pssIncidentCloseCmd(f))
 
 	// Child root commands
@@ -50,12 +53,7 @@ func pssIncidentList(service pss.PSSService, cmd *cobra.Command, args []string)
 		return err
 	}
 
-	paginatedIncidents, err := service.GetIncidentCasesPaginated(params)
-	if err != nil {
-		return err
-	}
-
-	return output.CommandOutputPaginated(cmd, IncidentCaseCollection(paginatedIncidents.Items()), paginatedIncidents)
+	return output.CommandOutputStream[IncidentCaseCollection](cmd, params, service.GetIncidentCasesPaginated)
 }
 
 func pssIncidentShowCmd(f factory.ClientFactory) *cobra.Command {
@@ -90,20 +88,29 @@ func pssIncidentShow(service pss.PSSService, cmd *cobra.Command, args []string)
 	return output.CommandOutput(cmd, IncidentCaseCollection(incidents))
 }
 
-func pssIncidentCreateCmd(f factory.ClientFactory) *cobra.Command {
+func pssIncidentCreateCmd(f factory.ClientFactory, fs afero.Fs) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "create",
-		Short:   "Creates an incident",
-		Long:    "This command creates a new incident",
-		Example: "ans pss incident create --title 'test incident' --description 'test incident' --type Fault --category 70b67a49-7ace-4146-a295-11590a0b1203 --supported-service 1b684067-a587-4997-acb9-da2f4cb7be81 --impact Minor",
-		RunE:    pssCobraRunEFunc(f, pssIncidentCreate),
+		Use:   "create",
+		Short: "Creates an incident",
+		Long: "This command creates a new incident. When --from-instance is specified, details, NICs, volumes, recent tasks " +
+			"and affected firewall rules are collected for the eCloud instance and added to the incident description, with " +
+			"the full JSON diagnostic bundle added as a case update",
+		Example: "ans pss incident create --title 'test incident' --description 'test incident' --type Fault --category 70b67a49-7ace-4146-a295-11590a0b1203 --supported-service 1b684067-a587-4997-acb9-da2f4cb7be81 --impact Minor\n" +
+			"ans pss incident create --title 'instance unresponsive' --from-instance i-abcdef12 --type Fault --category 70b67a49-7ace-4146-a295-11590a0b1203 --supported-service 1b684067-a587-4997-acb9-da2f4cb7be81 --impact Minor",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := f.NewClient()
+			if err != nil {
+				return err
+			}
+
+			return pssIncidentCreate(c.PSSService(), c.ECloudService(), fs, cmd, args)
+		},
 	}
 
 	// Setup flags
 	cmd.Flags().String("title", "", "Specifies the title for incident case")
 	_ = cmd.MarkFlagRequired("title")
-	cmd.Flags().String("description", "", "Specifies the description for incident case")
-	_ = cmd.MarkFlagRequired("description")
+	cmd.Flags().String("description", "", "Specifies the description for incident case. Required unless --from-instance is specified")
 	cmd.Flags().String("type", "", "Specifies the type of incident case")
 	_ = cmd.MarkFlagRequired("type")
 	cmd.Flags().String("category", "", "Category ID for incident case")
@@ -115,11 +122,13 @@ func pssIncidentCreateCmd(f factory.ClientFactory) *cobra.Command {
 	cmd.Flags().Bool("security", false, "Specifies whether incident case is a security incident")
 	cmd.Flags().String("customer-reference", "", "Specifies the customer reference for incident case")
 	cmd.Flags().Int("contact", 0, "Contact ID for incident case")
+	cmd.Flags().String("from-instance", "", "Specifies eCloud instance ID to collect diagnostics for")
+	cmd.Flags().String("bundle-file", "", "Specifies path to additionally save JSON diagnostic bundle to, when --from-instance is specified")
 
 	return cmd
 }
 
-func pssIncidentCreate(service pss.PSSService, cmd *cobra.Command, args []string) error {
+func pssIncidentCreate(service pss.PSSService, ecloudService ecloud.ECloudService, fs afero.Fs, cmd *cobra.Command, args []string) error {
 	createIncidentCase := pss.CreateIncidentCaseRequest{}
 
 	createIncidentCase.Title, _ = cmd.Flags().GetString("title")
@@ -144,11 +153,57 @@ func pssIncidentCreate(service pss.PSSService, cmd *cobra.Command, args []string
 	}
 	createIncidentCase.Impact = parsedIncidentCaseImpact
 
+	var diagnosticsBundle []byte
+	if cmd.Flags().Changed("from-instance") {
+		instanceID, _ := cmd.Flags().GetString("from-instance")
+		diagnostics, err := collectInstanceDiagnostics(ecloudService, instanceID)
+		if err != nil {
+			return err
+		}
+
+		for _, diagnosticsErr := range diagnostics.Errors {
+			output.Errorf("Warning: %s", diagnosticsErr)
+		}
+
+		diagnosticsBundle, err = json.MarshalIndent(diagnostics, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshalling diagnostic bundle: %s", err)
+		}
+
+		if cmd.Flags().Changed("bundle-file") {
+			bundleFile, _ := cmd.Flags().GetString("bundle-file")
+			err = afero.WriteFile(fs, bundleFile, diagnosticsBundle, 0644)
+			if err != nil {
+				return fmt.Errorf("error writing diagnostic bundle to [%s]: %s", bundleFile, err)
+			}
+		}
+
+		if len(createIncidentCase.Description) > 0 {
+			createIncidentCase.Description += "\n\n"
+		}
+		createIncidentCase.Description += formatInstanceDiagnostics(diagnostics)
+	}
+
+	if len(createIncidentCase.Description) < 1 {
+		return errors.New("missing description")
+	}
+
 	incidentID, err := service.CreateIncidentCase(createIncidentCase)
 	if err != nil {
 		return fmt.Errorf("error creating incident: %s", err)
 	}
 
+	if diagnosticsBundle != nil {
+		// Attachments aren't supported for cases, so the bundle is added as a case update
+		_, err = service.CreateCaseUpdate(incidentID, pss.CreateCaseUpdateRequest{
+			Description: fmt.Sprintf("Diagnostic bundle (JSON):\n%s", diagnosticsBundle),
+			ContactID:   createIncidentCase.ContactID,
+		})
+		if err != nil {
+			output.OutputWithErrorLevelf("Error adding diagnostic bundle to incident [%s]: %s", incidentID, err)
+		}
+	}
+
 	incident, err := service.GetIncidentCase(incidentID)
 	if err != nil {
 		return fmt.Errorf("error retrieving new incident: %s", err)
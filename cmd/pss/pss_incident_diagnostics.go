@@ -0,0 +1,200 @@
+package pss
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/ans-group/sdk-go/pkg/service/ecloud"
+)
+
+// incidentDiagnosticsTaskLimit is the maximum number of recent instance tasks collected
+const incidentDiagnosticsTaskLimit = 10
+
+// IncidentDiagnostics represents diagnostic information collected for an eCloud instance
+type IncidentDiagnostics struct {
+	CollectedAt   time.Time             `json:"collected_at"`
+	Instance      ecloud.Instance       `json:"instance"`
+	NICs          []ecloud.NIC          `json:"nics"`
+	Volumes       []ecloud.Volume       `json:"volumes"`
+	Tasks         []ecloud.Task         `json:"tasks"`
+	FirewallRules []ecloud.FirewallRule `json:"firewall_rules"`
+	Errors        []string              `json:"errors,omitempty"`
+}
+
+// collectInstanceDiagnostics collects diagnostic information for instance with ID instanceID. Failure
+// to retrieve the instance returns an error, whereas other failures are recorded in the diagnostics
+func collectInstanceDiagnostics(service ecloud.ECloudService, instanceID string) (*IncidentDiagnostics, error) {
+	instance, err := service.GetInstance(instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving instance [%s]: %s", instanceID, err)
+	}
+
+	diagnostics := &IncidentDiagnostics{
+		CollectedAt: time.Now().UTC(),
+		Instance:    instance,
+	}
+
+	diagnostics.NICs, err = service.GetInstanceNICs(instanceID, connection.APIRequestParameters{})
+	if err != nil {
+		diagnostics.Errors = append(diagnostics.Errors, fmt.Sprintf("error retrieving NICs: %s", err))
+	}
+
+	diagnostics.Volumes, err = service.GetInstanceVolumes(instanceID, connection.APIRequestParameters{})
+	if err != nil {
+		diagnostics.Errors = append(diagnostics.Errors, fmt.Sprintf("error retrieving volumes: %s", err))
+	}
+
+	tasks, err := service.GetInstanceTasks(instanceID, connection.APIRequestParameters{})
+	if err != nil {
+		diagnostics.Errors = append(diagnostics.Errors, fmt.Sprintf("error retrieving tasks: %s", err))
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].CreatedAt.Time().After(tasks[j].CreatedAt.Time())
+	})
+	if len(tasks) > incidentDiagnosticsTaskLimit {
+		tasks = tasks[:incidentDiagnosticsTaskLimit]
+	}
+	diagnostics.Tasks = tasks
+
+	diagnostics.FirewallRules = collectInstanceFirewallRules(service, diagnostics)
+
+	return diagnostics, nil
+}
+
+// collectInstanceFirewallRules returns firewall rules from policies on the routers for the instance's
+// NIC networks, where the rule source or destination includes an instance IP address
+func collectInstanceFirewallRules(service ecloud.ECloudService, diagnostics *IncidentDiagnostics) []ecloud.FirewallRule {
+	var ips []net.IP
+	var routerIDs []string
+	for _, nic := range diagnostics.NICs {
+		if ip := net.ParseIP(nic.IPAddress); ip != nil {
+			ips = append(ips, ip)
+		}
+
+		network, err := service.GetNetwork(nic.NetworkID)
+		if err != nil {
+			diagnostics.Errors = append(diagnostics.Errors, fmt.Sprintf("error retrieving network [%s]: %s", nic.NetworkID, err))
+			continue
+		}
+
+		if !slices.Contains(routerIDs, network.RouterID) {
+			routerIDs = append(routerIDs, network.RouterID)
+		}
+	}
+
+	var rules []ecloud.FirewallRule
+	for _, routerID := range routerIDs {
+		policies, err := service.GetRouterFirewallPolicies(routerID, connection.APIRequestParameters{})
+		if err != nil {
+			diagnostics.Errors = append(diagnostics.Errors, fmt.Sprintf("error retrieving firewall policies for router [%s]: %s", routerID, err))
+			continue
+		}
+
+		for _, policy := range policies {
+			policyRules, err := service.GetFirewallPolicyFirewallRules(policy.ID, connection.APIRequestParameters{})
+			if err != nil {
+				diagnostics.Errors = append(diagnostics.Errors, fmt.Sprintf("error retrieving firewall rules for policy [%s]: %s", policy.ID, err))
+				continue
+			}
+
+			for _, rule := range policyRules {
+				if firewallAddressesContain(rule.Source, ips) || firewallAddressesContain(rule.Destination, ips) {
+					rules = append(rules, rule)
+				}
+			}
+		}
+	}
+
+	return rules
+}
+
+// firewallAddressesContain returns true if firewall rule addresses (comma-separated IP addresses,
+// CIDRs, ranges or 'ANY') include any of ips
+func firewallAddressesContain(addresses string, ips []net.IP) bool {
+	for _, address := range strings.Split(addresses, ",") {
+		address = strings.TrimSpace(address)
+		if strings.EqualFold(address, "ANY") {
+			return true
+		}
+
+		for _, ip := range ips {
+			if _, network, err := net.ParseCIDR(address); err == nil {
+				if network.Contains(ip) {
+					return true
+				}
+				continue
+			}
+
+			if start, end, found := strings.Cut(address, "-"); found {
+				startIP := net.ParseIP(strings.TrimSpace(start))
+				endIP := net.ParseIP(strings.TrimSpace(end))
+				if startIP != nil && endIP != nil && bytes.Compare(ip.To16(), startIP.To16()) >= 0 && bytes.Compare(ip.To16(), endIP.To16()) <= 0 {
+					return true
+				}
+				continue
+			}
+
+			if ip.Equal(net.ParseIP(address)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// formatInstanceDiagnostics renders diagnostics as plain text for inclusion in an incident description
+func formatInstanceDiagnostics(diagnostics *IncidentDiagnostics) string {
+	var b strings.Builder
+	instance := diagnostics.Instance
+
+	fmt.Fprintf(&b, "Instance diagnostics (collected %s)\n\n", diagnostics.CollectedAt.Format(time.RFC3339))
+	fmt.Fprintf(&b, "Instance: %s (%s)\n", instance.Name, instance.ID)
+	fmt.Fprintf(&b, "VPC: %s, availability zone: %s\n", instance.VPCID, instance.AvailabilityZoneID)
+	fmt.Fprintf(&b, "Specification: %d vCPU, %d MiB RAM, %d GiB volume, platform %s\n", instance.VCPUCores, instance.RAMCapacity, instance.VolumeCapacity, instance.Platform)
+	fmt.Fprintf(&b, "Online: %s, agent running: %s, locked: %t\n", formatDiagnosticsBool(instance.Online), formatDiagnosticsBool(instance.AgentRunning), instance.Locked)
+	fmt.Fprintf(&b, "Sync: %s (%s), task in progress: %t\n", instance.Sync.Status, instance.Sync.Type, instance.Task.InProgress)
+
+	b.WriteString("\nNICs:\n")
+	for _, nic := range diagnostics.NICs {
+		fmt.Fprintf(&b, "- %s: %s (%s) on network %s\n", nic.ID, nic.IPAddress, nic.MACAddress, nic.NetworkID)
+	}
+
+	b.WriteString("\nVolumes:\n")
+	for _, volume := range diagnostics.Volumes {
+		fmt.Fprintf(&b, "- %s (%s): %d GiB, %d IOPS, type %s\n", volume.ID, volume.Name, volume.Capacity, volume.IOPS, volume.Type)
+	}
+
+	b.WriteString("\nRecent tasks:\n")
+	for _, task := range diagnostics.Tasks {
+		fmt.Fprintf(&b, "- %s %s (%s): %s\n", task.CreatedAt, task.Name, task.ID, task.Status)
+	}
+
+	b.WriteString("\nFirewall rules affecting instance:\n")
+	for _, rule := range diagnostics.FirewallRules {
+		fmt.Fprintf(&b, "- %s (%s) in policy %s: %s %s -> %s %s, enabled: %t\n", rule.Name, rule.ID, rule.FirewallPolicyID, rule.Direction, rule.Source, rule.Destination, rule.Action, rule.Enabled)
+	}
+
+	if len(diagnostics.Errors) > 0 {
+		b.WriteString("\nCollection errors:\n")
+		for _, e := range diagnostics.Errors {
+			fmt.Fprintf(&b, "- %s\n", e)
+		}
+	}
+
+	return b.String()
+}
+
+func formatDiagnosticsBool(b *bool) string {
+	if b == nil {
+		return "unknown"
+	}
+
+	return fmt.Sprintf("%t", *b)
+}
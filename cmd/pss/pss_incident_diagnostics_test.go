@@ -0,0 +1,147 @@
+package pss
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ans-group/cli/test/mocks"
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/ans-group/sdk-go/pkg/service/ecloud"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func expectInstanceDiagnostics(service *mocks.MockECloudService) {
+	service.EXPECT().GetInstance("i-abcdef12").Return(ecloud.Instance{ID: "i-abcdef12", Name: "web01"}, nil)
+	service.EXPECT().GetInstanceNICs("i-abcdef12", gomock.Any()).Return([]ecloud.NIC{{ID: "nic-abcdef12", IPAddress: "10.0.0.5", NetworkID: "net-abcdef12"}}, nil)
+	service.EXPECT().GetInstanceVolumes("i-abcdef12", gomock.Any()).Return([]ecloud.Volume{{ID: "vol-abcdef12"}}, nil)
+	service.EXPECT().GetInstanceTasks("i-abcdef12", gomock.Any()).Return([]ecloud.Task{{ID: "task-abcdef12"}}, nil)
+	service.EXPECT().GetNetwork("net-abcdef12").Return(ecloud.Network{ID: "net-abcdef12", RouterID: "rtr-abcdef12"}, nil)
+	service.EXPECT().GetRouterFirewallPolicies("rtr-abcdef12", gomock.Any()).Return([]ecloud.FirewallPolicy{{ID: "fwp-abcdef12"}}, nil)
+	service.EXPECT().GetFirewallPolicyFirewallRules("fwp-abcdef12", gomock.Any()).Return([]ecloud.FirewallRule{
+		{ID: "fwr-1", Source: "ANY", Destination: "10.0.0.0/24"},
+		{ID: "fwr-2", Source: "192.168.0.1", Destination: "192.168.0.2"},
+	}, nil)
+}
+
+func Test_collectInstanceDiagnostics(t *testing.T) {
+	t.Run("CollectsDiagnostics", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+		expectInstanceDiagnostics(service)
+
+		diagnostics, err := collectInstanceDiagnostics(service, "i-abcdef12")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "web01", diagnostics.Instance.Name)
+		assert.Len(t, diagnostics.NICs, 1)
+		assert.Len(t, diagnostics.Volumes, 1)
+		assert.Len(t, diagnostics.Tasks, 1)
+		assert.Len(t, diagnostics.FirewallRules, 1)
+		assert.Equal(t, "fwr-1", diagnostics.FirewallRules[0].ID)
+		assert.Empty(t, diagnostics.Errors)
+	})
+
+	t.Run("LimitsTasksToMostRecent", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+
+		var tasks []ecloud.Task
+		for i := 0; i < 15; i++ {
+			tasks = append(tasks, ecloud.Task{
+				ID:        string(rune('a' + i)),
+				CreatedAt: connection.DateTime(time.Date(2024, 1, i+1, 0, 0, 0, 0, time.UTC).Format("2006-01-02T15:04:05-0700")),
+			})
+		}
+
+		service.EXPECT().GetInstance("i-abcdef12").Return(ecloud.Instance{}, nil)
+		service.EXPECT().GetInstanceNICs("i-abcdef12", gomock.Any()).Return(nil, nil)
+		service.EXPECT().GetInstanceVolumes("i-abcdef12", gomock.Any()).Return(nil, nil)
+		service.EXPECT().GetInstanceTasks("i-abcdef12", gomock.Any()).Return(tasks, nil)
+
+		diagnostics, err := collectInstanceDiagnostics(service, "i-abcdef12")
+
+		assert.Nil(t, err)
+		assert.Len(t, diagnostics.Tasks, 10)
+		assert.Equal(t, "o", diagnostics.Tasks[0].ID)
+	})
+
+	t.Run("GetInstanceError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+
+		service.EXPECT().GetInstance("i-abcdef12").Return(ecloud.Instance{}, errors.New("test error"))
+
+		_, err := collectInstanceDiagnostics(service, "i-abcdef12")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error retrieving instance [i-abcdef12]: test error", err.Error())
+	})
+
+	t.Run("CollectionErrors_RecordedInDiagnostics", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockECloudService(mockCtrl)
+
+		service.EXPECT().GetInstance("i-abcdef12").Return(ecloud.Instance{}, nil)
+		service.EXPECT().GetInstanceNICs("i-abcdef12", gomock.Any()).Return(nil, errors.New("test error 1"))
+		service.EXPECT().GetInstanceVolumes("i-abcdef12", gomock.Any()).Return(nil, errors.New("test error 2"))
+		service.EXPECT().GetInstanceTasks("i-abcdef12", gomock.Any()).Return(nil, errors.New("test error 3"))
+
+		diagnostics, err := collectInstanceDiagnostics(service, "i-abcdef12")
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{
+			"error retrieving NICs: test error 1",
+			"error retrieving volumes: test error 2",
+			"error retrieving tasks: test error 3",
+		}, diagnostics.Errors)
+	})
+}
+
+func Test_firewallAddressesContain(t *testing.T) {
+	ips := []net.IP{net.ParseIP("10.0.0.5")}
+
+	tests := []struct {
+		addresses string
+		expected  bool
+	}{
+		{"ANY", true},
+		{"10.0.0.5", true},
+		{"10.0.0.0/24", true},
+		{"10.0.0.1-10.0.0.10", true},
+		{"192.168.0.1, 10.0.0.5", true},
+		{"10.0.1.0/24", false},
+		{"10.0.0.6-10.0.0.10", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addresses, func(t *testing.T) {
+			assert.Equal(t, tt.expected, firewallAddressesContain(tt.addresses, ips))
+		})
+	}
+}
+
+func Test_formatInstanceDiagnostics(t *testing.T) {
+	t.Run("IncludesCollectionErrors", func(t *testing.T) {
+		s := formatInstanceDiagnostics(&IncidentDiagnostics{
+			Instance: ecloud.Instance{ID: "i-abcdef12", Name: "web01"},
+			NICs:     []ecloud.NIC{{ID: "nic-abcdef12", IPAddress: "10.0.0.5"}},
+			Errors:   []string{"test error"},
+		})
+
+		assert.Contains(t, s, "Instance: web01 (i-abcdef12)\n")
+		assert.Contains(t, s, "- nic-abcdef12: 10.0.0.5")
+		assert.Contains(t, s, "Collection errors:\n- test error\n")
+	})
+}
@@ -2,14 +2,17 @@ package pss
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/ans-group/cli/internal/pkg/clierrors"
 	"github.com/ans-group/cli/test/mocks"
 	"github.com/ans-group/cli/test/test_output"
 	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/ans-group/sdk-go/pkg/service/ecloud"
 	"github.com/ans-group/sdk-go/pkg/service/pss"
 	gomock "github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
@@ -121,7 +124,7 @@ func Test_pssIncidentCreate(t *testing.T) {
 		defer mockCtrl.Finish()
 
 		service := mocks.NewMockPSSService(mockCtrl)
-		cmd := pssIncidentCreateCmd(nil)
+		cmd := pssIncidentCreateCmd(nil, nil)
 		setFlags(cmd)
 
 		gomock.InOrder(
@@ -136,7 +139,7 @@ func Test_pssIncidentCreate(t *testing.T) {
 			service.EXPECT().GetIncidentCase("INC123456").Return(pss.IncidentCase{}, nil),
 		)
 
-		pssIncidentCreate(service, cmd, []string{})
+		pssIncidentCreate(service, nil, nil, cmd, []string{})
 	})
 
 	t.Run("InvalidType_ReturnsError", func(t *testing.T) {
@@ -144,11 +147,11 @@ func Test_pssIncidentCreate(t *testing.T) {
 		defer mockCtrl.Finish()
 
 		service := mocks.NewMockPSSService(mockCtrl)
-		cmd := pssIncidentCreateCmd(nil)
+		cmd := pssIncidentCreateCmd(nil, nil)
 		setFlags(cmd)
 		cmd.Flags().Set("type", "invalid")
 
-		err := pssIncidentCreate(service, cmd, []string{})
+		err := pssIncidentCreate(service, nil, nil, cmd, []string{})
 		assert.Contains(t, err.Error(), "Invalid pss.IncidentCaseType")
 	})
 
@@ -157,25 +160,90 @@ func Test_pssIncidentCreate(t *testing.T) {
 		defer mockCtrl.Finish()
 
 		service := mocks.NewMockPSSService(mockCtrl)
-		cmd := pssIncidentCreateCmd(nil)
+		cmd := pssIncidentCreateCmd(nil, nil)
 		setFlags(cmd)
 		cmd.Flags().Set("impact", "invalid")
 
-		err := pssIncidentCreate(service, cmd, []string{})
+		err := pssIncidentCreate(service, nil, nil, cmd, []string{})
 		assert.Contains(t, err.Error(), "Invalid pss.IncidentCaseImpact")
 	})
 
+	t.Run("FromInstance_AddsDiagnostics", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		ecloudService := mocks.NewMockECloudService(mockCtrl)
+		fs := afero.NewMemMapFs()
+		cmd := pssIncidentCreateCmd(nil, fs)
+		setFlags(cmd)
+		cmd.Flags().Set("from-instance", "i-abcdef12")
+		cmd.Flags().Set("bundle-file", "/bundle.json")
+
+		expectInstanceDiagnostics(ecloudService)
+		gomock.InOrder(
+			service.EXPECT().CreateIncidentCase(gomock.Any()).Do(func(req pss.CreateIncidentCaseRequest) {
+				assert.True(t, strings.HasPrefix(req.Description, "test description\n\nInstance diagnostics"))
+				assert.Contains(t, req.Description, "Instance: web01 (i-abcdef12)")
+			}).Return("INC123456", nil),
+			service.EXPECT().CreateCaseUpdate("INC123456", gomock.Any()).Do(func(caseID string, req pss.CreateCaseUpdateRequest) {
+				assert.Contains(t, req.Description, "\"id\": \"i-abcdef12\"")
+			}).Return("1", nil),
+			service.EXPECT().GetIncidentCase("INC123456").Return(pss.IncidentCase{}, nil),
+		)
+
+		err := pssIncidentCreate(service, ecloudService, fs, cmd, []string{})
+
+		assert.Nil(t, err)
+
+		exists, _ := afero.Exists(fs, "/bundle.json")
+		assert.True(t, exists)
+	})
+
+	t.Run("FromInstanceGetInstanceError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		ecloudService := mocks.NewMockECloudService(mockCtrl)
+		cmd := pssIncidentCreateCmd(nil, nil)
+		setFlags(cmd)
+		cmd.Flags().Set("from-instance", "i-abcdef12")
+
+		ecloudService.EXPECT().GetInstance("i-abcdef12").Return(ecloud.Instance{}, errors.New("test error"))
+
+		err := pssIncidentCreate(service, ecloudService, nil, cmd, []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error retrieving instance [i-abcdef12]: test error", err.Error())
+	})
+
+	t.Run("MissingDescription_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		cmd := pssIncidentCreateCmd(nil, nil)
+		setFlags(cmd)
+		cmd.Flags().Set("description", "")
+
+		err := pssIncidentCreate(service, nil, nil, cmd, []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "missing description", err.Error())
+	})
+
 	t.Run("CreateIncidentError_ReturnsError", func(t *testing.T) {
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
 
 		service := mocks.NewMockPSSService(mockCtrl)
-		cmd := pssIncidentCreateCmd(nil)
+		cmd := pssIncidentCreateCmd(nil, nil)
 		setFlags(cmd)
 
 		service.EXPECT().CreateIncidentCase(gomock.Any()).Return("", errors.New("test error"))
 
-		err := pssIncidentCreate(service, cmd, []string{})
+		err := pssIncidentCreate(service, nil, nil, cmd, []string{})
 		assert.Equal(t, "error creating incident: test error", err.Error())
 	})
 
@@ -184,7 +252,7 @@ func Test_pssIncidentCreate(t *testing.T) {
 		defer mockCtrl.Finish()
 
 		service := mocks.NewMockPSSService(mockCtrl)
-		cmd := pssIncidentCreateCmd(nil)
+		cmd := pssIncidentCreateCmd(nil, nil)
 		setFlags(cmd)
 
 		gomock.InOrder(
@@ -192,7 +260,7 @@ func Test_pssIncidentCreate(t *testing.T) {
 			service.EXPECT().GetIncidentCase("INC123456").Return(pss.IncidentCase{}, errors.New("test error")),
 		)
 
-		err := pssIncidentCreate(service, cmd, []string{})
+		err := pssIncidentCreate(service, nil, nil, cmd, []string{})
 		assert.Equal(t, "error retrieving new incident: test error", err.Error())
 	})
 }
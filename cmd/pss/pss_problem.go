@@ -43,12 +43,7 @@ func pssProblemList(service pss.PSSService, cmd *cobra.Command, args []string) e
 		return err
 	}
 
-	paginatedProblems, err := service.GetProblemCasesPaginated(params)
-	if err != nil {
-		return err
-	}
-
-	return output.CommandOutputPaginated(cmd, ProblemCaseCollection(paginatedProblems.Items()), paginatedProblems)
+	return output.CommandOutputStream[ProblemCaseCollection](cmd, params, service.GetProblemCasesPaginated)
 }
 
 func pssProblemShowCmd(f factory.ClientFactory) *cobra.Command {
@@ -60,28 +60,36 @@ func pssReplyAttachmentDownloadCmd(f factory.ClientFactory, fs afero.Fs) *cobra.
 }
 
 func pssReplyAttachmentDownload(service pss.PSSService, fs afero.Fs, cmd *cobra.Command, args []string) error {
-	attachmentStream, err := service.DownloadReplyAttachmentStream(args[0], args[1])
+	path, _ := cmd.Flags().GetString("path")
+	_, err := downloadReplyAttachment(service, fs, args[0], args[1], path)
+
+	return err
+}
+
+// downloadReplyAttachment downloads attachment with name attachmentName from reply with ID replyID
+// to path, returning the path of the written file
+func downloadReplyAttachment(service pss.PSSService, fs afero.Fs, replyID string, attachmentName string, path string) (string, error) {
+	attachmentStream, err := service.DownloadReplyAttachmentStream(replyID, attachmentName)
 	if err != nil {
-		return fmt.Errorf("error downloading reply attachment: %s", err)
+		return "", fmt.Errorf("error downloading reply attachment: %s", err)
 	}
 
-	path, _ := cmd.Flags().GetString("path")
-	targetFilePath, err := helper.GetDestinationFilePath(fs, args[1], path)
+	targetFilePath, err := helper.GetDestinationFilePath(fs, attachmentName, path)
 	if err != nil {
-		return fmt.Errorf("error determining destination file path: %s", err)
+		return "", fmt.Errorf("error determining destination file path: %s", err)
 	}
 
 	_, err = fs.Stat(targetFilePath)
 	if err == nil || !os.IsNotExist(err) {
-		return fmt.Errorf("destination file [%s] exists", targetFilePath)
+		return "", fmt.Errorf("destination file [%s] exists", targetFilePath)
 	}
 
 	err = afero.SafeWriteReader(fs, targetFilePath, attachmentStream)
 	if err != nil {
-		return fmt.Errorf("error writing attachment to [%s]: %s", targetFilePath, err.Error())
+		return "", fmt.Errorf("error writing attachment to [%s]: %s", targetFilePath, err.Error())
 	}
 
-	return nil
+	return targetFilePath, nil
 }
 
 func pssReplyAttachmentUploadCmd(f factory.ClientFactory, fs afero.Fs) *cobra.Command {
@@ -0,0 +1,526 @@
+package pss
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/ans-group/cli/internal/pkg/factory"
+	"github.com/ans-group/cli/internal/pkg/helper"
+	"github.com/ans-group/cli/internal/pkg/output"
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/ans-group/sdk-go/pkg/service/pss"
+	"github.com/spf13/cobra"
+)
+
+// reportNow returns the current time, used for calculating open age
+var reportNow = time.Now
+
+// reportCaseDateLayouts are the layouts attempted when parsing case timestamps
+var reportCaseDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05-0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+}
+
+// reportRequestResolvedStatuses are request statuses considered resolved
+var reportRequestResolvedStatuses = []pss.RequestStatus{
+	pss.RequestStatusCompleted,
+	pss.RequestStatusRepliedAndCompleted,
+}
+
+// reportCaseResolvedStatuses are case statuses considered resolved
+var reportCaseResolvedStatuses = []pss.CaseStatus{
+	pss.CaseStatusResolved,
+	pss.CaseStatusClosed,
+	pss.CaseStatusCaseClosed,
+	pss.CaseStatusDelivered,
+	pss.CaseStatusRejected,
+	pss.CaseStatusCustomerClosure,
+	pss.CaseStatusProblemSolved,
+	pss.CaseStatusClosedWithCustomerConsent,
+	pss.CaseStatusClosedCustomerNotContactable,
+	pss.CaseStatusClosedWithTeamLeaderApproval,
+}
+
+// reportAgeBuckets are the upper bounds of open age buckets, with a final bucket for older items
+var reportAgeBuckets = []time.Duration{
+	7 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+	90 * 24 * time.Hour,
+}
+
+// reportItem represents a request or case normalised for reporting. Items created before the reporting
+// period are only included where open, contributing to open ageing only. ResolvedAt is nil for resolved
+// items where the resolution time isn't known
+type reportItem struct {
+	Type         string
+	Priority     string
+	Category     string
+	Product      string
+	InPeriod     bool
+	Resolved     bool
+	CreatedAt    time.Time
+	FirstReplyAt *time.Time
+	ResolvedAt   *time.Time
+}
+
+// reportCase contains the fields of an incident, problem or change case used for reporting
+type reportCase struct {
+	ID         string
+	Status     pss.CaseStatus
+	Priority   string
+	CategoryID string
+	CreatedAt  string
+	UpdatedAt  string
+}
+
+func pssReportCmd(f factory.ClientFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Reports SLA and ageing statistics",
+		Long: "This command reports statistics for requests, incidents, problems and changes created within the given period. " +
+			"Statistics are grouped overall, and by priority, category and product where applicable. Time to first reply is the " +
+			"time to the first support reply for requests, and the first case update for cases. As resolution times aren't " +
+			"available, resolution is approximated using the last reply time for requests and last update time for cases.\n\n" +
+			"Open counts and open ageing include all open items, regardless of when they were created",
+		Example: "ans pss report --since 90d\nans pss report --since 30d --type request --type incident --output csv",
+		RunE:    pssCobraRunEFunc(f, pssReport),
+	}
+
+	cmd.Flags().String("since", "90d", "Specifies period to report on, e.g. 90d, 4w or 720h")
+	cmd.Flags().StringSlice("type", []string{"request", "incident", "problem", "change"}, "Specifies types to report on {request, incident, problem, change}")
+
+	return cmd
+}
+
+func pssReport(service pss.PSSService, cmd *cobra.Command, args []string) error {
+	sinceFlag, _ := cmd.Flags().GetString("since")
+	since, err := helper.ParseDuration(sinceFlag)
+	if err != nil {
+		return err
+	}
+
+	types, _ := cmd.Flags().GetStringSlice("type")
+	for _, t := range types {
+		if !slices.Contains([]string{"request", "incident", "problem", "change"}, t) {
+			return fmt.Errorf("invalid type [%s]", t)
+		}
+	}
+
+	from := reportNow().Add(-since)
+
+	var categories map[string]string
+	if slices.Contains(types, "incident") || slices.Contains(types, "change") {
+		categories, err = getReportCategories(service)
+		if err != nil {
+			return err
+		}
+	}
+
+	var items []reportItem
+	for _, t := range types {
+		var typeItems []reportItem
+		switch t {
+		case "request":
+			typeItems, err = getReportRequestItems(service, from)
+		case "incident":
+			typeItems, err = getReportIncidentItems(service, from, categories)
+		case "problem":
+			typeItems, err = getReportProblemItems(service, from)
+		case "change":
+			typeItems, err = getReportChangeItems(service, from, categories)
+		}
+		if err != nil {
+			return err
+		}
+
+		items = append(items, typeItems...)
+	}
+
+	return output.CommandOutput(cmd, ReportRowCollection(buildReportRows(types, items, reportNow())))
+}
+
+func getReportCategories(service pss.PSSService) (map[string]string, error) {
+	categories, err := service.GetCaseCategories(connection.APIRequestParameters{})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving case categories: %s", err)
+	}
+
+	categoryNames := make(map[string]string)
+	for _, category := range categories {
+		categoryNames[category.ID] = category.Name
+	}
+
+	return categoryNames, nil
+}
+
+// reportCreatedSinceParameters returns parameters filtering items to those created after from
+func reportCreatedSinceParameters(from time.Time) connection.APIRequestParameters {
+	params := connection.APIRequestParameters{}
+	params.WithFilter(connection.APIRequestFiltering{
+		Property: "created_at",
+		Operator: connection.GTOperator,
+		Value:    []string{from.Format(time.RFC3339)},
+	})
+
+	return params
+}
+
+// reportOpenParameters returns parameters filtering items to those without one of resolvedStatuses
+func reportOpenParameters[T fmt.Stringer](resolvedStatuses []T) connection.APIRequestParameters {
+	var statuses []string
+	for _, status := range resolvedStatuses {
+		statuses = append(statuses, status.String())
+	}
+
+	params := connection.APIRequestParameters{}
+	params.WithFilter(connection.APIRequestFiltering{
+		Property: "status",
+		Operator: connection.NINOperator,
+		Value:    statuses,
+	})
+
+	return params
+}
+
+func getReportRequestItems(service pss.PSSService, from time.Time) ([]reportItem, error) {
+	requests, err := service.GetRequests(reportCreatedSinceParameters(from))
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving requests: %s", err)
+	}
+
+	var items []reportItem
+	for _, request := range requests {
+		createdAt := request.CreatedAt.Time()
+		if createdAt.Before(from) {
+			continue
+		}
+
+		item := reportItem{
+			Type:      "request",
+			Priority:  request.Priority.String(),
+			Product:   request.Product.Name,
+			InPeriod:  true,
+			CreatedAt: createdAt,
+		}
+
+		replies, err := service.GetRequestConversation(request.ID, connection.APIRequestParameters{})
+		if err != nil {
+			output.OutputWithErrorLevelf("Error retrieving replies for request [%d]: %s", request.ID, err)
+		}
+		for _, reply := range replies {
+			replyAt := reply.CreatedAt.Time()
+			if reply.Author.Type == pss.AuthorTypeSupport && (item.FirstReplyAt == nil || replyAt.Before(*item.FirstReplyAt)) {
+				item.FirstReplyAt = &replyAt
+			}
+		}
+
+		if slices.Contains(reportRequestResolvedStatuses, request.Status) {
+			item.Resolved = true
+			resolvedAt := request.LastRepliedAt.Time()
+			if !resolvedAt.IsZero() {
+				item.ResolvedAt = &resolvedAt
+			}
+		}
+
+		items = append(items, item)
+	}
+
+	openRequests, err := service.GetRequests(reportOpenParameters(reportRequestResolvedStatuses))
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving open requests: %s", err)
+	}
+
+	for _, request := range openRequests {
+		createdAt := request.CreatedAt.Time()
+		if !createdAt.Before(from) || slices.Contains(reportRequestResolvedStatuses, request.Status) {
+			continue
+		}
+
+		items = append(items, reportItem{
+			Type:      "request",
+			Priority:  request.Priority.String(),
+			Product:   request.Product.Name,
+			CreatedAt: createdAt,
+		})
+	}
+
+	return items, nil
+}
+
+// getReportCaseItems returns report items for cases of caseType created since from, along with open cases
+// created before from. Case updates are only retrieved for cases created since from
+func getReportCaseItems(service pss.PSSService, caseType string, from time.Time, categories map[string]string, getCases func(params connection.APIRequestParameters) ([]reportCase, error)) ([]reportItem, error) {
+	cases, err := getCases(reportCreatedSinceParameters(from))
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving %ss: %s", caseType, err)
+	}
+
+	var items []reportItem
+	for _, c := range cases {
+		item := newReportCaseItem(caseType, c, categories)
+		if item.CreatedAt.Before(from) {
+			continue
+		}
+		item.InPeriod = true
+
+		updates, err := service.GetCaseUpdates(c.ID, connection.APIRequestParameters{})
+		if err != nil {
+			output.OutputWithErrorLevelf("Error retrieving updates for %s [%s]: %s", caseType, c.ID, err)
+		}
+		for _, update := range updates {
+			updateAt := parseReportCaseDate(update.CreatedAt)
+			if !updateAt.IsZero() && (item.FirstReplyAt == nil || updateAt.Before(*item.FirstReplyAt)) {
+				item.FirstReplyAt = &updateAt
+			}
+		}
+
+		if slices.Contains(reportCaseResolvedStatuses, c.Status) {
+			item.Resolved = true
+			resolvedAt := parseReportCaseDate(c.UpdatedAt)
+			if !resolvedAt.IsZero() {
+				item.ResolvedAt = &resolvedAt
+			}
+		}
+
+		items = append(items, item)
+	}
+
+	openCases, err := getCases(reportOpenParameters(reportCaseResolvedStatuses))
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving open %ss: %s", caseType, err)
+	}
+
+	for _, c := range openCases {
+		item := newReportCaseItem(caseType, c, categories)
+		if !item.CreatedAt.Before(from) || slices.Contains(reportCaseResolvedStatuses, c.Status) {
+			continue
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// newReportCaseItem returns a report item for case c, without first reply or resolution times
+func newReportCaseItem(caseType string, c reportCase, categories map[string]string) reportItem {
+	item := reportItem{
+		Type:      caseType,
+		Priority:  c.Priority,
+		CreatedAt: parseReportCaseDate(c.CreatedAt),
+	}
+	if c.CategoryID != "" {
+		item.Category = getReportCategoryName(categories, c.CategoryID)
+	}
+
+	return item
+}
+
+func getReportIncidentItems(service pss.PSSService, from time.Time, categories map[string]string) ([]reportItem, error) {
+	return getReportCaseItems(service, "incident", from, categories, func(params connection.APIRequestParameters) ([]reportCase, error) {
+		incidents, err := service.GetIncidentCases(params)
+		if err != nil {
+			return nil, err
+		}
+
+		var cases []reportCase
+		for _, incident := range incidents {
+			cases = append(cases, reportCase{
+				ID:         incident.ID,
+				Status:     incident.Status,
+				Priority:   incident.Priority.String(),
+				CategoryID: incident.CategoryID,
+				CreatedAt:  incident.CreatedAt,
+				UpdatedAt:  incident.UpdatedAt,
+			})
+		}
+
+		return cases, nil
+	})
+}
+
+func getReportProblemItems(service pss.PSSService, from time.Time) ([]reportItem, error) {
+	return getReportCaseItems(service, "problem", from, nil, func(params connection.APIRequestParameters) ([]reportCase, error) {
+		problems, err := service.GetProblemCases(params)
+		if err != nil {
+			return nil, err
+		}
+
+		var cases []reportCase
+		for _, problem := range problems {
+			cases = append(cases, reportCase{
+				ID:        problem.ID,
+				Status:    problem.Status,
+				Priority:  problem.Priority.String(),
+				CreatedAt: problem.CreatedAt,
+				UpdatedAt: problem.UpdatedAt,
+			})
+		}
+
+		return cases, nil
+	})
+}
+
+func getReportChangeItems(service pss.PSSService, from time.Time, categories map[string]string) ([]reportItem, error) {
+	return getReportCaseItems(service, "change", from, categories, func(params connection.APIRequestParameters) ([]reportCase, error) {
+		changes, err := service.GetChangeCases(params)
+		if err != nil {
+			return nil, err
+		}
+
+		var cases []reportCase
+		for _, change := range changes {
+			cases = append(cases, reportCase{
+				ID:         change.ID,
+				Status:     change.Status,
+				Priority:   change.Priority.String(),
+				CategoryID: change.CategoryID,
+				CreatedAt:  change.CreatedAt,
+				UpdatedAt:  change.UpdatedAt,
+			})
+		}
+
+		return cases, nil
+	})
+}
+
+func getReportCategoryName(categories map[string]string, categoryID string) string {
+	if name, ok := categories[categoryID]; ok {
+		return name
+	}
+
+	return categoryID
+}
+
+// parseReportCaseDate parses case timestamp s, returning a zero time if s can't be parsed
+func parseReportCaseDate(s string) time.Time {
+	for _, layout := range reportCaseDateLayouts {
+		t, err := time.Parse(layout, s)
+		if err == nil {
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// buildReportRows aggregates items into report rows per type, overall and grouped by priority,
+// category and product
+func buildReportRows(types []string, items []reportItem, now time.Time) []ReportRow {
+	dimensions := []struct {
+		Name  string
+		Value func(item reportItem) string
+	}{
+		{"all", func(item reportItem) string { return "all" }},
+		{"priority", func(item reportItem) string { return item.Priority }},
+		{"category", func(item reportItem) string { return item.Category }},
+		{"product", func(item reportItem) string { return item.Product }},
+	}
+
+	var rows []ReportRow
+	for _, t := range types {
+		var typeItems []reportItem
+		for _, item := range items {
+			if item.Type == t {
+				typeItems = append(typeItems, item)
+			}
+		}
+
+		for _, dimension := range dimensions {
+			groups := make(map[string][]reportItem)
+			var values []string
+			for _, item := range typeItems {
+				value := dimension.Value(item)
+				if value == "" {
+					continue
+				}
+				if _, ok := groups[value]; !ok {
+					values = append(values, value)
+				}
+				groups[value] = append(groups[value], item)
+			}
+			sort.Strings(values)
+
+			if dimension.Name == "all" && len(values) == 0 {
+				values = []string{"all"}
+			}
+
+			for _, value := range values {
+				rows = append(rows, buildReportRow(t, dimension.Name, value, groups[value], now))
+			}
+		}
+	}
+
+	return rows
+}
+
+func buildReportRow(itemType string, dimension string, value string, items []reportItem, now time.Time) ReportRow {
+	row := ReportRow{
+		Type:      itemType,
+		Dimension: dimension,
+		Value:     value,
+	}
+
+	var firstReplyHours []float64
+	var resolutionHours []float64
+	for _, item := range items {
+		if item.InPeriod {
+			row.Total++
+		}
+
+		if item.FirstReplyAt != nil {
+			firstReplyHours = append(firstReplyHours, item.FirstReplyAt.Sub(item.CreatedAt).Hours())
+		}
+
+		if item.Resolved {
+			row.Resolved++
+			if item.ResolvedAt != nil {
+				resolutionHours = append(resolutionHours, item.ResolvedAt.Sub(item.CreatedAt).Hours())
+			}
+			continue
+		}
+
+		row.Open++
+		age := now.Sub(item.CreatedAt)
+		switch {
+		case age < reportAgeBuckets[0]:
+			row.Open0To7d++
+		case age < reportAgeBuckets[1]:
+			row.Open7To30d++
+		case age < reportAgeBuckets[2]:
+			row.Open30To90d++
+		default:
+			row.Open90dPlus++
+		}
+	}
+
+	row.AvgFirstReplyHours, row.MedianFirstReplyHours = reportAverageMedian(firstReplyHours)
+	row.AvgResolutionHours, row.MedianResolutionHours = reportAverageMedian(resolutionHours)
+
+	return row
+}
+
+func reportAverageMedian(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+
+	sorted := slices.Clone(values)
+	sort.Float64s(sorted)
+
+	var total float64
+	for _, v := range sorted {
+		total += v
+	}
+
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+	}
+
+	return total / float64(len(sorted)), median
+}
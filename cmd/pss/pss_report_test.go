@@ -0,0 +1,275 @@
+package pss
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ans-group/cli/test/mocks"
+	"github.com/ans-group/cli/test/test_output"
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/ans-group/sdk-go/pkg/service/pss"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setReportNow(t *testing.T, now time.Time) {
+	oldReportNow := reportNow
+	reportNow = func() time.Time { return now }
+	t.Cleanup(func() { reportNow = oldReportNow })
+}
+
+func Test_pssReport(t *testing.T) {
+	now := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Requests_OutputsReport", func(t *testing.T) {
+		setReportNow(t, now)
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		cmd := pssReportCmd(nil)
+		cmd.Flags().Set("type", "request")
+		cmd.Flags().String("output", "", "")
+		cmd.ParseFlags([]string{"--output", "csv"})
+
+		sinceParams := connection.APIRequestParameters{}
+		sinceParams.WithFilter(connection.APIRequestFiltering{Property: "created_at", Operator: connection.GTOperator, Value: []string{"2024-01-02T00:00:00Z"}})
+		openParams := connection.APIRequestParameters{}
+		openParams.WithFilter(connection.APIRequestFiltering{Property: "status", Operator: connection.NINOperator, Value: []string{"Completed", "Replied and Completed"}})
+
+		gomock.InOrder(
+			service.EXPECT().GetRequests(sinceParams).Return([]pss.Request{
+				{ID: 1, Priority: pss.RequestPriorityNormal, Status: pss.RequestStatusCompleted, CreatedAt: "2024-03-01T00:00:00+0000", LastRepliedAt: "2024-03-02T00:00:00+0000"},
+			}, nil),
+			service.EXPECT().GetRequestConversation(1, gomock.Any()).Return([]pss.Reply{
+				{Author: pss.Author{Type: pss.AuthorTypeClient}, CreatedAt: "2024-03-01T00:00:00+0000"},
+				{Author: pss.Author{Type: pss.AuthorTypeSupport}, CreatedAt: "2024-03-01T02:00:00+0000"},
+			}, nil),
+			service.EXPECT().GetRequests(openParams).Return([]pss.Request{
+				{ID: 2, Priority: pss.RequestPriorityNormal, Status: pss.RequestStatusSubmitted, CreatedAt: "2023-01-01T00:00:00+0000"},
+			}, nil),
+		)
+
+		test_output.AssertOutput(t, "type,dimension,value,total,open,resolved,median_first_reply_hours,median_resolution_hours,open_0_7d,open_7_30d,open_30_90d,open_90d_plus\n"+
+			"request,all,all,1,1,1,2.0,24.0,0,0,0,1\n"+
+			"request,priority,Normal,1,1,1,2.0,24.0,0,0,0,1\n", func() {
+			err := pssReport(service, cmd, []string{})
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("InvalidSince_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		cmd := pssReportCmd(nil)
+		cmd.Flags().Set("since", "invalid")
+
+		err := pssReport(service, cmd, []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "invalid duration [invalid]", err.Error())
+	})
+
+	t.Run("InvalidType_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		cmd := pssReportCmd(nil)
+		cmd.Flags().Set("type", "invalid")
+
+		err := pssReport(service, cmd, []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "invalid type [invalid]", err.Error())
+	})
+
+	t.Run("GetCaseCategoriesError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		cmd := pssReportCmd(nil)
+
+		service.EXPECT().GetCaseCategories(gomock.Any()).Return(nil, errors.New("test error"))
+
+		err := pssReport(service, cmd, []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error retrieving case categories: test error", err.Error())
+	})
+
+	t.Run("ResolvedRequestWithoutReplies_ResolvedWithoutResolutionTime", func(t *testing.T) {
+		setReportNow(t, now)
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		cmd := pssReportCmd(nil)
+		cmd.Flags().Set("type", "request")
+		cmd.Flags().String("output", "", "")
+		cmd.ParseFlags([]string{"--output", "csv"})
+
+		gomock.InOrder(
+			service.EXPECT().GetRequests(gomock.Any()).Return([]pss.Request{
+				{ID: 1, Priority: pss.RequestPriorityNormal, Status: pss.RequestStatusCompleted, CreatedAt: "2024-03-01T00:00:00+0000"},
+			}, nil),
+			service.EXPECT().GetRequestConversation(1, gomock.Any()).Return([]pss.Reply{}, nil),
+			service.EXPECT().GetRequests(gomock.Any()).Return([]pss.Request{}, nil),
+		)
+
+		test_output.AssertOutput(t, "type,dimension,value,total,open,resolved,median_first_reply_hours,median_resolution_hours,open_0_7d,open_7_30d,open_30_90d,open_90d_plus\n"+
+			"request,all,all,1,0,1,0.0,0.0,0,0,0,0\n"+
+			"request,priority,Normal,1,0,1,0.0,0.0,0,0,0,0\n", func() {
+			err := pssReport(service, cmd, []string{})
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("GetOpenRequestsError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		cmd := pssReportCmd(nil)
+		cmd.Flags().Set("type", "request")
+
+		gomock.InOrder(
+			service.EXPECT().GetRequests(gomock.Any()).Return([]pss.Request{}, nil),
+			service.EXPECT().GetRequests(gomock.Any()).Return(nil, errors.New("test error")),
+		)
+
+		err := pssReport(service, cmd, []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error retrieving open requests: test error", err.Error())
+	})
+
+	t.Run("GetRequestsError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		cmd := pssReportCmd(nil)
+		cmd.Flags().Set("type", "request")
+
+		service.EXPECT().GetRequests(gomock.Any()).Return(nil, errors.New("test error"))
+
+		err := pssReport(service, cmd, []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error retrieving requests: test error", err.Error())
+	})
+}
+
+func Test_getReportIncidentItems(t *testing.T) {
+	t.Run("ReturnsItemsWithCategoryAndFirstUpdate", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		gomock.InOrder(
+			service.EXPECT().GetIncidentCases(gomock.Any()).Return([]pss.IncidentCase{
+				{ID: "INC1", Priority: pss.IncidentCasePriorityP2, Status: pss.CaseStatusResolved, CategoryID: "cat1", CreatedAt: "2024-02-01T00:00:00Z", UpdatedAt: "2024-02-03T00:00:00Z"},
+			}, nil),
+			service.EXPECT().GetCaseUpdates("INC1", gomock.Any()).Return([]pss.CaseUpdate{
+				{CreatedAt: "2024-02-01T05:00:00Z"},
+				{CreatedAt: "2024-02-01T01:00:00Z"},
+			}, nil),
+			service.EXPECT().GetIncidentCases(gomock.Any()).Return([]pss.IncidentCase{
+				{ID: "INC2", Status: pss.CaseStatusInProgress, CategoryID: "cat1", CreatedAt: "2023-02-01T00:00:00Z"},
+			}, nil),
+		)
+
+		items, err := getReportIncidentItems(service, from, map[string]string{"cat1": "Network"})
+
+		assert.Nil(t, err)
+		assert.Len(t, items, 2)
+		assert.True(t, items[0].InPeriod)
+		assert.Equal(t, "P2", items[0].Priority)
+		assert.Equal(t, "Network", items[0].Category)
+		assert.Equal(t, time.Hour, items[0].FirstReplyAt.Sub(items[0].CreatedAt))
+		assert.True(t, items[0].Resolved)
+		assert.Equal(t, 48*time.Hour, items[0].ResolvedAt.Sub(items[0].CreatedAt))
+		assert.False(t, items[1].InPeriod)
+		assert.Equal(t, "Network", items[1].Category)
+		assert.Nil(t, items[1].FirstReplyAt)
+		assert.Nil(t, items[1].ResolvedAt)
+	})
+
+	t.Run("GetIncidentCasesError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+
+		service.EXPECT().GetIncidentCases(gomock.Any()).Return(nil, errors.New("test error"))
+
+		_, err := getReportIncidentItems(service, time.Time{}, nil)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error retrieving incidents: test error", err.Error())
+	})
+}
+
+func Test_buildReportRows(t *testing.T) {
+	now := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("OpenItems_BucketedByAge", func(t *testing.T) {
+		items := []reportItem{
+			{Type: "problem", Priority: "PRB1", InPeriod: true, CreatedAt: now.Add(-24 * time.Hour)},
+			{Type: "problem", Priority: "PRB1", InPeriod: true, CreatedAt: now.Add(-10 * 24 * time.Hour)},
+			{Type: "problem", Priority: "PRB2", InPeriod: true, CreatedAt: now.Add(-60 * 24 * time.Hour)},
+			{Type: "problem", Priority: "PRB2", CreatedAt: now.Add(-100 * 24 * time.Hour)},
+		}
+
+		rows := buildReportRows([]string{"problem"}, items, now)
+
+		assert.Len(t, rows, 3)
+		assert.Equal(t, ReportRow{Type: "problem", Dimension: "all", Value: "all", Total: 3, Open: 4, Open0To7d: 1, Open7To30d: 1, Open30To90d: 1, Open90dPlus: 1}, rows[0])
+		assert.Equal(t, "PRB1", rows[1].Value)
+		assert.Equal(t, 2, rows[1].Total)
+		assert.Equal(t, "PRB2", rows[2].Value)
+		assert.Equal(t, 1, rows[2].Total)
+		assert.Equal(t, 2, rows[2].Open)
+	})
+
+	t.Run("NoItems_ReturnsEmptyOverallRow", func(t *testing.T) {
+		rows := buildReportRows([]string{"change"}, nil, now)
+
+		assert.Equal(t, []ReportRow{{Type: "change", Dimension: "all", Value: "all"}}, rows)
+	})
+}
+
+func Test_reportAverageMedian(t *testing.T) {
+	t.Run("EvenCount_ReturnsMeanOfMiddleValues", func(t *testing.T) {
+		avg, median := reportAverageMedian([]float64{4, 1, 3, 2})
+
+		assert.Equal(t, 2.5, avg)
+		assert.Equal(t, 2.5, median)
+	})
+
+	t.Run("OddCount_ReturnsMiddleValue", func(t *testing.T) {
+		avg, median := reportAverageMedian([]float64{1, 10, 4})
+
+		assert.Equal(t, 5.0, avg)
+		assert.Equal(t, 4.0, median)
+	})
+}
+
+func Test_parseReportCaseDate(t *testing.T) {
+	t.Run("Invalid_ReturnsZeroTime", func(t *testing.T) {
+		assert.True(t, parseReportCaseDate("invalid").IsZero())
+	})
+
+	t.Run("DateTimeWithoutZone_Parses", func(t *testing.T) {
+		assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), parseReportCaseDate("2024-01-02 03:04:05"))
+	})
+}
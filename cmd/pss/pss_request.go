@@ -7,13 +7,14 @@ import (
 
 	"github.com/ans-group/cli/internal/pkg/factory"
 	"github.com/ans-group/cli/internal/pkg/helper"
-	"github.com/ans-group/cli/internal/pkg/input"
 	"github.com/ans-group/cli/internal/pkg/output"
+	"github.com/ans-group/sdk-go/pkg/connection"
 	"github.com/ans-group/sdk-go/pkg/service/pss"
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 )
 
-func pssRequestRootCmd(f factory.ClientFactory) *cobra.Command {
+func pssRequestRootCmd(f factory.ClientFactory, fs afero.Fs) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:        "request",
 		Short:      "sub-commands relating to requests",
@@ -23,12 +24,14 @@ func pssRequestRootCmd(f factory.ClientFactory) *cobra.Command {
 	// Child commands
 	cmd.AddCommand(pssRequestListCmd(f))
 	cmd.AddCommand(pssRequestShowCmd(f))
-	cmd.AddCommand(pssRequestCreateCmd(f))
+	cmd.AddCommand(pssRequestCreateCmd(f, fs))
 	cmd.AddCommand(pssRequestUpdateCmd(f))
 	cmd.AddCommand(pssRequestCloseCmd(f))
+	cmd.AddCommand(pssRequestThreadCmd(f, fs))
+	cmd.AddCommand(pssRequestWatchCmd(f, fs))
 
 	// Child root commands
-	cmd.AddCommand(pssRequestReplyRootCmd(f))
+	cmd.AddCommand(pssRequestReplyRootCmd(f, fs))
 	cmd.AddCommand(pssRequestFeedbackRootCmd(f))
 
 	return cmd
@@ -110,19 +113,21 @@ func pssRequestShow(service pss.PSSService, cmd *cobra.Command, args []string) e
 	return output.CommandOutput(cmd, RequestCollection(requests))
 }
 
-func pssRequestCreateCmd(f factory.ClientFactory) *cobra.Command {
+func pssRequestCreateCmd(f factory.ClientFactory, fs afero.Fs) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "create",
-		Short:   "Creates a request",
-		Long:    "This command creates a new request",
-		Example: "ans pss request create --subject 'example ticket' --details 'example' --author 123",
+		Use:   "create",
+		Short: "Creates a request",
+		Long: "This command creates a new request. When --details isn't specified, details are read from " +
+			"piped input, or composed in $EDITOR",
+		Example: "ans pss request create --subject 'example ticket' --details 'example' --author 123\n" +
+			"ans pss request create --subject 'example ticket' --author 123 --template outage --attach /path/to/file",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c, err := f.NewClient()
 			if err != nil {
 				return err
 			}
 
-			return pssRequestCreate(c.PSSService(), cmd, args)
+			return pssRequestCreate(c.PSSService(), fs, cmd, args)
 		},
 	}
 
@@ -140,11 +145,12 @@ func pssRequestCreateCmd(f factory.ClientFactory) *cobra.Command {
 	cmd.Flags().Int("product-id", 0, "Specifies product ID for request")
 	cmd.Flags().String("product-name", "", "Specifies product name for request")
 	cmd.Flags().String("product-type", "", "Specifies product type for request")
+	addComposeFlags(cmd)
 
 	return cmd
 }
 
-func pssRequestCreate(service pss.PSSService, cmd *cobra.Command, args []string) error {
+func pssRequestCreate(service pss.PSSService, fs afero.Fs, cmd *cobra.Command, args []string) error {
 	createRequest := pss.CreateRequestRequest{}
 
 	priority, _ := cmd.Flags().GetString("priority")
@@ -168,13 +174,14 @@ func pssRequestCreate(service pss.PSSService, cmd *cobra.Command, args []string)
 	createRequest.RequestSMS, _ = cmd.Flags().GetBool("request-sms")
 	createRequest.CustomerReference, _ = cmd.Flags().GetString("customer-reference")
 
-	if cmd.Flags().Changed("details") {
-		createRequest.Details, _ = cmd.Flags().GetString("details")
-	} else {
-		createRequest.Details, err = input.ReadInput("details")
-		if err != nil {
-			return err
-		}
+	attachments, err := getAttachmentPaths(cmd, fs)
+	if err != nil {
+		return err
+	}
+
+	createRequest.Details, err = composeInput(cmd, "details", "details")
+	if err != nil {
+		return err
 	}
 
 	requestID, err := service.CreateRequest(createRequest)
@@ -182,6 +189,25 @@ func pssRequestCreate(service pss.PSSService, cmd *cobra.Command, args []string)
 		return fmt.Errorf("error creating request: %s", err)
 	}
 
+	if len(attachments) > 0 {
+		// Attachments are added to the initial reply containing request details
+		replies, err := service.GetRequestConversation(requestID, connection.APIRequestParameters{})
+		if err != nil {
+			output.OutputWithErrorLevelf("Error retrieving request replies for attachments: %s", err)
+		} else if len(replies) < 1 {
+			output.OutputWithErrorLevelf("Error uploading attachments: request has no replies")
+		} else {
+			initialReply := replies[0]
+			for _, reply := range replies[1:] {
+				if reply.CreatedAt.Time().Before(initialReply.CreatedAt.Time()) {
+					initialReply = reply
+				}
+			}
+
+			uploadAttachments(service, fs, initialReply.ID, attachments)
+		}
+	}
+
 	request, err := service.GetRequest(requestID)
 	if err != nil {
 		return fmt.Errorf("error retrieving new request: %s", err)
@@ -7,13 +7,13 @@ import (
 
 	"github.com/ans-group/cli/internal/pkg/factory"
 	"github.com/ans-group/cli/internal/pkg/helper"
-	"github.com/ans-group/cli/internal/pkg/input"
 	"github.com/ans-group/cli/internal/pkg/output"
 	"github.com/ans-group/sdk-go/pkg/service/pss"
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 )
 
-func pssRequestReplyRootCmd(f factory.ClientFactory) *cobra.Command {
+func pssRequestReplyRootCmd(f factory.ClientFactory, fs afero.Fs) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "reply",
 		Short: "sub-commands relating to request replies",
@@ -21,7 +21,7 @@ func pssRequestReplyRootCmd(f factory.ClientFactory) *cobra.Command {
 
 	// Child commands
 	cmd.AddCommand(pssRequestReplyListCmd(f))
-	cmd.AddCommand(pssRequestReplyCreateCmd(f))
+	cmd.AddCommand(pssRequestReplyCreateCmd(f, fs))
 
 	// Child root commands
 
@@ -71,12 +71,14 @@ func pssRequestReplyList(service pss.PSSService, cmd *cobra.Command, args []stri
 	return output.CommandOutput(cmd, ReplyCollection(replies))
 }
 
-func pssRequestReplyCreateCmd(f factory.ClientFactory) *cobra.Command {
+func pssRequestReplyCreateCmd(f factory.ClientFactory, fs afero.Fs) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "create",
-		Short:   "Creates a reply",
-		Long:    "This command creates a new reply",
-		Example: "ans pss request reply create --description 'example' --author 123",
+		Use:   "create",
+		Short: "Creates a reply",
+		Long: "This command creates a new reply. When --description isn't specified, the description is read from " +
+			"piped input, or composed in $EDITOR",
+		Example: "ans pss request reply create 123 --description 'example' --author 123\n" +
+			"ans pss request reply create 123 --author 123 --template update --attach /path/to/file",
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) < 1 {
 				return errors.New("missing request")
@@ -90,7 +92,7 @@ func pssRequestReplyCreateCmd(f factory.ClientFactory) *cobra.Command {
 				return err
 			}
 
-			return pssRequestReplyCreate(c.PSSService(), cmd, args)
+			return pssRequestReplyCreate(c.PSSService(), fs, cmd, args)
 		},
 	}
 
@@ -98,11 +100,12 @@ func pssRequestReplyCreateCmd(f factory.ClientFactory) *cobra.Command {
 	cmd.Flags().String("description", "", "Specifies description for reply")
 	cmd.Flags().Int("author", 0, "Specifies author ID for reply")
 	_ = cmd.MarkFlagRequired("author")
+	addComposeFlags(cmd)
 
 	return cmd
 }
 
-func pssRequestReplyCreate(service pss.PSSService, cmd *cobra.Command, args []string) error {
+func pssRequestReplyCreate(service pss.PSSService, fs afero.Fs, cmd *cobra.Command, args []string) error {
 	requestID, err := strconv.Atoi(args[0])
 	if err != nil {
 		return fmt.Errorf("invalid request ID [%s]", args[0])
@@ -111,13 +114,14 @@ func pssRequestReplyCreate(service pss.PSSService, cmd *cobra.Command, args []st
 	createRequest := pss.CreateReplyRequest{}
 	createRequest.Author.ID, _ = cmd.Flags().GetInt("author")
 
-	if cmd.Flags().Changed("description") {
-		createRequest.Description, _ = cmd.Flags().GetString("description")
-	} else {
-		createRequest.Description, err = input.ReadInput("description")
-		if err != nil {
-			return err
-		}
+	attachments, err := getAttachmentPaths(cmd, fs)
+	if err != nil {
+		return err
+	}
+
+	createRequest.Description, err = composeInput(cmd, "description", "description")
+	if err != nil {
+		return err
 	}
 
 	replyID, err := service.CreateRequestReply(requestID, createRequest)
@@ -125,6 +129,8 @@ func pssRequestReplyCreate(service pss.PSSService, cmd *cobra.Command, args []st
 		return fmt.Errorf("error creating reply: %s", err)
 	}
 
+	uploadAttachments(service, fs, replyID, attachments)
+
 	reply, err := service.GetReply(replyID)
 	if err != nil {
 		return fmt.Errorf("error retrieving new reply: %s", err)
@@ -8,6 +8,7 @@ import (
 	"github.com/ans-group/cli/test/mocks"
 	"github.com/ans-group/sdk-go/pkg/service/pss"
 	gomock "github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
@@ -77,13 +78,13 @@ func Test_pssRequestReplyList(t *testing.T) {
 
 func Test_pssRequestReplyCreateCmd_Args(t *testing.T) {
 	t.Run("ValidArgs_NoError", func(t *testing.T) {
-		err := pssRequestReplyCreateCmd(nil).Args(nil, []string{"123"})
+		err := pssRequestReplyCreateCmd(nil, nil).Args(nil, []string{"123"})
 
 		assert.Nil(t, err)
 	})
 
 	t.Run("InvalidArgs_Error", func(t *testing.T) {
-		err := pssRequestReplyCreateCmd(nil).Args(nil, []string{})
+		err := pssRequestReplyCreateCmd(nil, nil).Args(nil, []string{})
 
 		assert.NotNil(t, err)
 		assert.Equal(t, "missing request", err.Error())
@@ -96,7 +97,7 @@ func Test_pssRequestReplyCreate(t *testing.T) {
 		defer mockCtrl.Finish()
 
 		service := mocks.NewMockPSSService(mockCtrl)
-		cmd := pssRequestReplyCreateCmd(nil)
+		cmd := pssRequestReplyCreateCmd(nil, nil)
 		cmd.Flags().Set("description", "test description")
 		cmd.Flags().Set("author", "456")
 
@@ -112,7 +113,7 @@ func Test_pssRequestReplyCreate(t *testing.T) {
 			service.EXPECT().GetReply("C456").Return(pss.Reply{}, nil),
 		)
 
-		pssRequestReplyCreate(service, cmd, []string{"123"})
+		pssRequestReplyCreate(service, nil, cmd, []string{"123"})
 	})
 
 	t.Run("InvalidRequestID_ReturnsError", func(t *testing.T) {
@@ -120,22 +121,61 @@ func Test_pssRequestReplyCreate(t *testing.T) {
 		defer mockCtrl.Finish()
 
 		service := mocks.NewMockPSSService(mockCtrl)
-		cmd := pssRequestReplyCreateCmd(nil)
+		cmd := pssRequestReplyCreateCmd(nil, nil)
 
-		err := pssRequestReplyCreate(service, cmd, []string{"invalid"})
+		err := pssRequestReplyCreate(service, nil, cmd, []string{"invalid"})
 		assert.Contains(t, err.Error(), "invalid request ID [invalid]")
 	})
 
+	t.Run("Attach_UploadsAttachments", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/test.txt", []byte("test"), 0644)
+		cmd := pssRequestReplyCreateCmd(nil, fs)
+		cmd.Flags().Set("description", "test description")
+		cmd.Flags().Set("author", "456")
+		cmd.Flags().Set("attach", "/test.txt")
+
+		gomock.InOrder(
+			service.EXPECT().CreateRequestReply(123, gomock.Any()).Return("C456", nil),
+			service.EXPECT().UploadReplyAttachmentStream("C456", "test.txt", gomock.Any()).Return(nil),
+			service.EXPECT().GetReply("C456").Return(pss.Reply{}, nil),
+		)
+
+		err := pssRequestReplyCreate(service, fs, cmd, []string{"123"})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("MissingAttachment_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		fs := afero.NewMemMapFs()
+		cmd := pssRequestReplyCreateCmd(nil, fs)
+		cmd.Flags().Set("description", "test description")
+		cmd.Flags().Set("attach", "/missing.txt")
+
+		err := pssRequestReplyCreate(service, fs, cmd, []string{"123"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "attachment file [/missing.txt] not found", err.Error())
+	})
+
 	t.Run("CreateRequestReplyError_ReturnsError", func(t *testing.T) {
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
 
 		service := mocks.NewMockPSSService(mockCtrl)
-		cmd := pssRequestReplyCreateCmd(nil)
+		cmd := pssRequestReplyCreateCmd(nil, nil)
 
 		service.EXPECT().CreateRequestReply(123, gomock.Any()).Return("", errors.New("test error")).Times(1)
 
-		err := pssRequestReplyCreate(service, cmd, []string{"123"})
+		err := pssRequestReplyCreate(service, nil, cmd, []string{"123"})
 		assert.Equal(t, "error creating reply: test error", err.Error())
 	})
 
@@ -144,14 +184,14 @@ func Test_pssRequestReplyCreate(t *testing.T) {
 		defer mockCtrl.Finish()
 
 		service := mocks.NewMockPSSService(mockCtrl)
-		cmd := pssRequestReplyCreateCmd(nil)
+		cmd := pssRequestReplyCreateCmd(nil, nil)
 
 		gomock.InOrder(
 			service.EXPECT().CreateRequestReply(123, gomock.Any()).Return("C123", nil),
 			service.EXPECT().GetReply("C123").Return(pss.Reply{}, errors.New("test error")),
 		)
 
-		err := pssRequestReplyCreate(service, cmd, []string{"123"})
+		err := pssRequestReplyCreate(service, nil, cmd, []string{"123"})
 		assert.Equal(t, "error retrieving new reply: test error", err.Error())
 	})
 }
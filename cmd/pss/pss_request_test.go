@@ -7,8 +7,10 @@ import (
 	"github.com/ans-group/cli/internal/pkg/clierrors"
 	"github.com/ans-group/cli/test/mocks"
 	"github.com/ans-group/cli/test/test_output"
+	"github.com/ans-group/sdk-go/pkg/connection"
 	"github.com/ans-group/sdk-go/pkg/service/pss"
 	gomock "github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
@@ -123,7 +125,7 @@ func Test_pssRequestCreate(t *testing.T) {
 		defer mockCtrl.Finish()
 
 		service := mocks.NewMockPSSService(mockCtrl)
-		cmd := pssRequestCreateCmd(nil)
+		cmd := pssRequestCreateCmd(nil, nil)
 		cmd.Flags().Set("subject", "test subject")
 		cmd.Flags().Set("product-id", "456")
 		cmd.Flags().Set("product-name", "testname")
@@ -139,7 +141,7 @@ func Test_pssRequestCreate(t *testing.T) {
 			service.EXPECT().GetRequest(123).Return(pss.Request{}, nil),
 		)
 
-		pssRequestCreate(service, cmd, []string{})
+		pssRequestCreate(service, nil, cmd, []string{})
 	})
 
 	t.Run("InvalidPriority_ReturnsError", func(t *testing.T) {
@@ -147,23 +149,72 @@ func Test_pssRequestCreate(t *testing.T) {
 		defer mockCtrl.Finish()
 
 		service := mocks.NewMockPSSService(mockCtrl)
-		cmd := pssRequestCreateCmd(nil)
+		cmd := pssRequestCreateCmd(nil, nil)
 		cmd.Flags().Set("priority", "invalid")
 
-		err := pssRequestCreate(service, cmd, []string{})
+		err := pssRequestCreate(service, nil, cmd, []string{})
 		assert.Contains(t, err.Error(), "Invalid pss.RequestPriority")
 	})
 
+	t.Run("Attach_UploadsAttachmentsToInitialReply", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/test.txt", []byte("test"), 0644)
+		cmd := pssRequestCreateCmd(nil, fs)
+		cmd.Flags().Set("subject", "test subject")
+		cmd.Flags().Set("details", "test details")
+		cmd.Flags().Set("attach", "/test.txt")
+
+		gomock.InOrder(
+			service.EXPECT().CreateRequest(gomock.Any()).Return(123, nil),
+			service.EXPECT().GetRequestConversation(123, gomock.Any()).Return([]pss.Reply{
+				{ID: "C2", CreatedAt: connection.DateTime("2024-01-02T10:00:00+0000")},
+				{ID: "C1", CreatedAt: connection.DateTime("2024-01-01T10:00:00+0000")},
+			}, nil),
+			service.EXPECT().UploadReplyAttachmentStream("C1", "test.txt", gomock.Any()).Return(nil),
+			service.EXPECT().GetRequest(123).Return(pss.Request{}, nil),
+		)
+
+		err := pssRequestCreate(service, fs, cmd, []string{})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("AttachGetRequestConversationError_OutputsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/test.txt", []byte("test"), 0644)
+		cmd := pssRequestCreateCmd(nil, fs)
+		cmd.Flags().Set("details", "test details")
+		cmd.Flags().Set("attach", "/test.txt")
+
+		gomock.InOrder(
+			service.EXPECT().CreateRequest(gomock.Any()).Return(123, nil),
+			service.EXPECT().GetRequestConversation(123, gomock.Any()).Return(nil, errors.New("test error")),
+			service.EXPECT().GetRequest(123).Return(pss.Request{}, nil),
+		)
+
+		test_output.AssertErrorOutput(t, "Error retrieving request replies for attachments: test error\n", func() {
+			pssRequestCreate(service, fs, cmd, []string{})
+		})
+	})
+
 	t.Run("CreateRequestError_ReturnsError", func(t *testing.T) {
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
 
 		service := mocks.NewMockPSSService(mockCtrl)
-		cmd := pssRequestCreateCmd(nil)
+		cmd := pssRequestCreateCmd(nil, nil)
 
 		service.EXPECT().CreateRequest(gomock.Any()).Return(0, errors.New("test error")).Times(1)
 
-		err := pssRequestCreate(service, cmd, []string{})
+		err := pssRequestCreate(service, nil, cmd, []string{})
 		assert.Equal(t, "error creating request: test error", err.Error())
 	})
 
@@ -172,14 +223,14 @@ func Test_pssRequestCreate(t *testing.T) {
 		defer mockCtrl.Finish()
 
 		service := mocks.NewMockPSSService(mockCtrl)
-		cmd := pssRequestCreateCmd(nil)
+		cmd := pssRequestCreateCmd(nil, nil)
 
 		gomock.InOrder(
 			service.EXPECT().CreateRequest(gomock.Any()).Return(123, nil),
 			service.EXPECT().GetRequest(123).Return(pss.Request{}, errors.New("test error")),
 		)
 
-		err := pssRequestCreate(service, cmd, []string{})
+		err := pssRequestCreate(service, nil, cmd, []string{})
 		assert.Equal(t, "error retrieving new request: test error", err.Error())
 	})
 }
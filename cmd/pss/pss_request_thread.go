@@ -0,0 +1,189 @@
+package pss
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ans-group/cli/internal/pkg/factory"
+	"github.com/ans-group/cli/internal/pkg/output"
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/ans-group/sdk-go/pkg/service/pss"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+// threadWrapWidth is the maximum line width for reply bodies in text thread output
+const threadWrapWidth = 80
+
+// threadDateFormat is the format used for reply timestamps in thread output
+const threadDateFormat = "2006-01-02 15:04:05 -0700"
+
+func pssRequestThreadCmd(f factory.ClientFactory, fs afero.Fs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "thread <request: id>",
+		Short: "Shows the conversation for a request",
+		Long: "This command shows the full conversation for a request in chronological order, with attachments listed " +
+			"against each reply. Output format 'markdown' renders the conversation as Markdown, and other output formats " +
+			"output the replies as with 'pss request reply list'. The conversation is output as text where no output format " +
+			"is specified or configured as the default",
+		Example: "ans pss request thread 123\nans pss request thread 123 --output markdown\nans pss request thread 123 --download-attachments ./attachments",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("missing request")
+			}
+
+			return nil
+		},
+		RunE: pssCobraRunEFunc(f, func(service pss.PSSService, cmd *cobra.Command, args []string) error {
+			return pssRequestThread(service, cmd, fs, args)
+		}),
+	}
+
+	cmd.Flags().String("download-attachments", "", "Specifies directory to download all reply attachments to. Attachments are saved within a directory per reply")
+
+	return cmd
+}
+
+func pssRequestThread(service pss.PSSService, cmd *cobra.Command, fs afero.Fs, args []string) error {
+	requestID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid request ID [%s]", args[0])
+	}
+
+	request, err := service.GetRequest(requestID)
+	if err != nil {
+		return fmt.Errorf("error retrieving request: %s", err)
+	}
+
+	replies, err := service.GetRequestConversation(requestID, connection.APIRequestParameters{})
+	if err != nil {
+		return fmt.Errorf("error retrieving request replies: %s", err)
+	}
+
+	sort.SliceStable(replies, func(i, j int) bool {
+		return replies[i].CreatedAt.Time().Before(replies[j].CreatedAt.Time())
+	})
+
+	if cmd.Flags().Changed("download-attachments") {
+		dir, _ := cmd.Flags().GetString("download-attachments")
+		downloadThreadAttachments(service, fs, dir, replies)
+	}
+
+	format, _ := output.GetOutputFormat(cmd)
+	switch format {
+	case "":
+		fmt.Print(formatThreadText(request, replies))
+		return nil
+	case "markdown":
+		fmt.Print(formatThreadMarkdown(request, replies))
+		return nil
+	}
+
+	return output.CommandOutput(cmd, ReplyCollection(replies))
+}
+
+// downloadThreadAttachments downloads attachments for replies to a directory per reply within dir
+func downloadThreadAttachments(service pss.PSSService, fs afero.Fs, dir string, replies []pss.Reply) {
+	for _, reply := range replies {
+		if len(reply.Attachments) < 1 {
+			continue
+		}
+
+		replyDir := filepath.Join(dir, reply.ID)
+		err := fs.MkdirAll(replyDir, 0755)
+		if err != nil {
+			output.OutputWithErrorLevelf("Error creating directory [%s]: %s", replyDir, err)
+			continue
+		}
+
+		for _, attachment := range reply.Attachments {
+			path, err := downloadReplyAttachment(service, fs, reply.ID, attachment.Name, replyDir)
+			if err != nil {
+				output.OutputWithErrorLevelf("Error downloading attachment [%s] for reply [%s]: %s", attachment.Name, reply.ID, err)
+				continue
+			}
+
+			output.Errorf("Downloaded attachment [%s]", path)
+		}
+	}
+}
+
+func formatThreadText(request pss.Request, replies []pss.Reply) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Request #%d: %s\n", request.ID, request.Subject)
+	fmt.Fprintf(&b, "Status: %s\n", request.Status)
+
+	for _, reply := range replies {
+		fmt.Fprintf(&b, "\n--- %s (%s) ---\n", reply.Author.Name, reply.CreatedAt.Time().Format(threadDateFormat))
+		b.WriteString(wrapThreadText(reply.Description, threadWrapWidth))
+		b.WriteString("\n")
+
+		if len(reply.Attachments) > 0 {
+			fmt.Fprintf(&b, "Attachments: %s\n", strings.Join(threadAttachmentNames(reply), ", "))
+		}
+	}
+
+	return b.String()
+}
+
+func formatThreadMarkdown(request pss.Request, replies []pss.Reply) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Request #%d: %s\n\n", request.ID, request.Subject)
+	fmt.Fprintf(&b, "**Status:** %s\n", request.Status)
+
+	for _, reply := range replies {
+		fmt.Fprintf(&b, "\n## %s (%s)\n\n", reply.Author.Name, reply.CreatedAt.Time().Format(threadDateFormat))
+		b.WriteString(strings.TrimSpace(reply.Description))
+		b.WriteString("\n")
+
+		if len(reply.Attachments) > 0 {
+			b.WriteString("\n**Attachments:**\n\n")
+			for _, name := range threadAttachmentNames(reply) {
+				fmt.Fprintf(&b, "- `%s`\n", name)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+func threadAttachmentNames(reply pss.Reply) []string {
+	var names []string
+	for _, attachment := range reply.Attachments {
+		names = append(names, attachment.Name)
+	}
+
+	return names
+}
+
+// wrapThreadText wraps each line of s at word boundaries, so lines don't exceed width where possible
+func wrapThreadText(s string, width int) string {
+	var wrapped []string
+	for _, line := range strings.Split(strings.TrimSpace(s), "\n") {
+		words := strings.Fields(line)
+		if len(words) < 1 {
+			wrapped = append(wrapped, "")
+			continue
+		}
+
+		current := words[0]
+		for _, word := range words[1:] {
+			if len(current)+1+len(word) > width {
+				wrapped = append(wrapped, current)
+				current = word
+				continue
+			}
+
+			current += " " + word
+		}
+		wrapped = append(wrapped, current)
+	}
+
+	return strings.Join(wrapped, "\n")
+}
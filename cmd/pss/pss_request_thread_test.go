@@ -0,0 +1,218 @@
+package pss
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ans-group/cli/test/mocks"
+	"github.com/ans-group/cli/test/test_output"
+	"github.com/ans-group/sdk-go/pkg/config"
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/ans-group/sdk-go/pkg/service/pss"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_pssRequestThreadCmd_Args(t *testing.T) {
+	t.Run("ValidArgs_NoError", func(t *testing.T) {
+		err := pssRequestThreadCmd(nil, nil).Args(nil, []string{"123"})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("InvalidArgs_Error", func(t *testing.T) {
+		err := pssRequestThreadCmd(nil, nil).Args(nil, []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "missing request", err.Error())
+	})
+}
+
+func testThreadReplies() []pss.Reply {
+	return []pss.Reply{
+		{
+			ID:          "C2",
+			Author:      pss.Author{Name: "Support"},
+			Description: "Resolved",
+			CreatedAt:   connection.DateTime("2024-01-02T10:00:00+0000"),
+			Attachments: []pss.Attachment{{Name: "log.txt"}},
+		},
+		{
+			ID:          "C1",
+			Author:      pss.Author{Name: "Customer"},
+			Description: "Site is down",
+			CreatedAt:   connection.DateTime("2024-01-01T10:00:00+0000"),
+		},
+	}
+}
+
+func Test_pssRequestThread(t *testing.T) {
+	t.Run("DefaultOutput_OutputsChronologicalThread", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		cmd := pssRequestThreadCmd(nil, nil)
+
+		service.EXPECT().GetRequest(123).Return(pss.Request{ID: 123, Subject: "Outage", Status: pss.RequestStatusCompleted}, nil)
+		service.EXPECT().GetRequestConversation(123, gomock.Any()).Return(testThreadReplies(), nil)
+
+		test_output.AssertOutput(t, "Request #123: Outage\n"+
+			"Status: Completed\n"+
+			"\n--- Customer (2024-01-01 10:00:00 +0000) ---\n"+
+			"Site is down\n"+
+			"\n--- Support (2024-01-02 10:00:00 +0000) ---\n"+
+			"Resolved\n"+
+			"Attachments: log.txt\n", func() {
+			err := pssRequestThread(service, cmd, nil, []string{"123"})
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("MarkdownOutput_OutputsMarkdown", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		cmd := pssRequestThreadCmd(nil, nil)
+		cmd.Flags().String("output", "", "")
+		cmd.ParseFlags([]string{"--output", "markdown"})
+
+		service.EXPECT().GetRequest(123).Return(pss.Request{ID: 123, Subject: "Outage", Status: pss.RequestStatusCompleted}, nil)
+		service.EXPECT().GetRequestConversation(123, gomock.Any()).Return(testThreadReplies(), nil)
+
+		test_output.AssertOutput(t, "# Request #123: Outage\n\n"+
+			"**Status:** Completed\n"+
+			"\n## Customer (2024-01-01 10:00:00 +0000)\n\n"+
+			"Site is down\n"+
+			"\n## Support (2024-01-02 10:00:00 +0000)\n\n"+
+			"Resolved\n"+
+			"\n**Attachments:**\n\n"+
+			"- `log.txt`\n", func() {
+			err := pssRequestThread(service, cmd, nil, []string{"123"})
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("ConfiguredDefaultOutput_UsesConfiguredFormat", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		config.Set("test", "output.default", "value")
+		config.SwitchCurrentContext("test")
+		defer config.Reset()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		cmd := pssRequestThreadCmd(nil, nil)
+		cmd.Flags().StringSlice("property", []string{}, "")
+		cmd.ParseFlags([]string{"--property", "id"})
+
+		service.EXPECT().GetRequest(123).Return(pss.Request{ID: 123, Subject: "Outage", Status: pss.RequestStatusCompleted}, nil)
+		service.EXPECT().GetRequestConversation(123, gomock.Any()).Return(testThreadReplies(), nil)
+
+		test_output.AssertOutput(t, "C1\nC2\n", func() {
+			err := pssRequestThread(service, cmd, nil, []string{"123"})
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("DownloadAttachments_WritesAttachments", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		fs := afero.NewMemMapFs()
+		cmd := pssRequestThreadCmd(nil, fs)
+		cmd.Flags().String("output", "", "")
+		cmd.ParseFlags([]string{"--download-attachments", "/attachments", "--output", "json"})
+
+		service.EXPECT().GetRequest(123).Return(pss.Request{ID: 123}, nil)
+		service.EXPECT().GetRequestConversation(123, gomock.Any()).Return(testThreadReplies(), nil)
+		service.EXPECT().DownloadReplyAttachmentStream("C2", "log.txt").Return(io.NopCloser(strings.NewReader("content")), nil)
+
+		test_output.AssertErrorOutput(t, "Downloaded attachment [/attachments/C2/log.txt]\n", func() {
+			err := pssRequestThread(service, cmd, fs, []string{"123"})
+			assert.Nil(t, err)
+		})
+
+		content, _ := afero.ReadFile(fs, "/attachments/C2/log.txt")
+		assert.Equal(t, "content", string(content))
+	})
+
+	t.Run("DownloadAttachmentError_OutputsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		fs := afero.NewMemMapFs()
+		cmd := pssRequestThreadCmd(nil, fs)
+		cmd.Flags().String("output", "", "")
+		cmd.ParseFlags([]string{"--download-attachments", "/attachments", "--output", "json"})
+
+		service.EXPECT().GetRequest(123).Return(pss.Request{ID: 123}, nil)
+		service.EXPECT().GetRequestConversation(123, gomock.Any()).Return(testThreadReplies(), nil)
+		service.EXPECT().DownloadReplyAttachmentStream("C2", "log.txt").Return(nil, errors.New("test error"))
+
+		test_output.AssertErrorOutput(t, "Error downloading attachment [log.txt] for reply [C2]: error downloading reply attachment: test error\n", func() {
+			pssRequestThread(service, cmd, fs, []string{"123"})
+		})
+	})
+
+	t.Run("InvalidRequestID_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+
+		err := pssRequestThread(service, pssRequestThreadCmd(nil, nil), nil, []string{"abc"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "invalid request ID [abc]", err.Error())
+	})
+
+	t.Run("GetRequestError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+
+		service.EXPECT().GetRequest(123).Return(pss.Request{}, errors.New("test error"))
+
+		err := pssRequestThread(service, pssRequestThreadCmd(nil, nil), nil, []string{"123"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error retrieving request: test error", err.Error())
+	})
+
+	t.Run("GetRequestConversationError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+
+		service.EXPECT().GetRequest(123).Return(pss.Request{}, nil)
+		service.EXPECT().GetRequestConversation(123, gomock.Any()).Return(nil, errors.New("test error"))
+
+		err := pssRequestThread(service, pssRequestThreadCmd(nil, nil), nil, []string{"123"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error retrieving request replies: test error", err.Error())
+	})
+}
+
+func Test_wrapThreadText(t *testing.T) {
+	t.Run("LongLine_Wraps", func(t *testing.T) {
+		s := wrapThreadText("one two three four", 9)
+
+		assert.Equal(t, "one two\nthree\nfour", s)
+	})
+
+	t.Run("PreservesBlankLines", func(t *testing.T) {
+		s := wrapThreadText("one\n\ntwo", 80)
+
+		assert.Equal(t, "one\n\ntwo", s)
+	})
+}
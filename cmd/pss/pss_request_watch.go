@@ -0,0 +1,363 @@
+package pss
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/ans-group/cli/internal/pkg/factory"
+	"github.com/ans-group/cli/internal/pkg/output"
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/ans-group/sdk-go/pkg/service/pss"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+// pssWatchHTTPClient is the HTTP client used for sending webhook notifications
+var pssWatchHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// pssWatchStateFileName is the name of the file within the user's home directory used for
+// persisting watch state when no state file is specified
+const pssWatchStateFileName = ".ans_pss_watch.json"
+
+// pssWatchState represents persisted watch state, keyed by request ID
+type pssWatchState map[string]pssWatchRequestState
+
+type pssWatchRequestState struct {
+	Status  string   `json:"status"`
+	Replies []string `json:"replies"`
+	// Pending contains events which failed to be delivered, keyed by sink name
+	Pending map[string][]RequestWatchEvent `json:"pending,omitempty"`
+}
+
+// pssWatchSink is a destination events are delivered to, in addition to being output
+type pssWatchSink struct {
+	name    string
+	action  string
+	deliver func(event RequestWatchEvent) error
+}
+
+func pssRequestWatchCmd(f factory.ClientFactory, fs afero.Fs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watch <request: id>...",
+		Short: "Watches requests for new replies and status changes",
+		Long: "This command polls one or more requests, outputting new replies and status changes as they occur. " +
+			"Seen replies are persisted, so only new events are output across restarts. Replies existing when a request " +
+			"is first watched are treated as seen. Events can optionally be sent to a Slack-compatible webhook, or passed " +
+			"to a hook command via environment variables (ANS_PSS_EVENT, ANS_PSS_REQUEST_ID, ANS_PSS_REPLY_ID, " +
+			"ANS_PSS_STATUS) and as JSON on stdin. Hook commands are executed with 'sh -c', or 'cmd /C' on Windows. " +
+			"Events which fail to be sent to the webhook or hook are persisted, and retried for the failed destination only on " +
+			"subsequent polls without being output again",
+		Example: "ans pss request watch 123\nans pss request watch 123 456 --webhook https://hooks.slack.com/services/xxx\nans pss request watch 123 --hook 'notify-send \"PSS $ANS_PSS_REQUEST_ID\"'",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("missing request")
+			}
+
+			return nil
+		},
+		RunE: pssCobraRunEFunc(f, func(service pss.PSSService, cmd *cobra.Command, args []string) error {
+			return pssRequestWatch(service, cmd, fs, args)
+		}),
+	}
+
+	cmd.Flags().Int("interval", 30, "Specifies polling interval in seconds")
+	cmd.Flags().String("webhook", "", "Specifies Slack-compatible webhook URL to send events to")
+	cmd.Flags().String("hook", "", "Specifies command to execute for each event")
+	cmd.Flags().String("state-file", "", fmt.Sprintf("Specifies file for persisting seen replies (default is $HOME/%s)", pssWatchStateFileName))
+	cmd.Flags().Bool("once", false, "Specifies requests should be polled once, rather than continuously")
+
+	return cmd
+}
+
+func pssRequestWatch(service pss.PSSService, cmd *cobra.Command, fs afero.Fs, args []string) error {
+	var requestIDs []int
+	for _, arg := range args {
+		requestID, err := strconv.Atoi(arg)
+		if err != nil {
+			return fmt.Errorf("invalid request ID [%s]", arg)
+		}
+		requestIDs = append(requestIDs, requestID)
+	}
+
+	interval, _ := cmd.Flags().GetInt("interval")
+	if interval < 1 {
+		return errors.New("interval must be greater than 0")
+	}
+	webhook, _ := cmd.Flags().GetString("webhook")
+	hook, _ := cmd.Flags().GetString("hook")
+	once, _ := cmd.Flags().GetBool("once")
+
+	statePath, err := getPSSWatchStatePath(cmd)
+	if err != nil {
+		return err
+	}
+
+	state, err := readPSSWatchState(fs, statePath)
+	if err != nil {
+		return err
+	}
+
+	var sinks []pssWatchSink
+	if webhook != "" {
+		sinks = append(sinks, pssWatchSink{name: "webhook", action: "sending webhook", deliver: func(event RequestWatchEvent) error {
+			return sendPSSWatchWebhook(pssWatchHTTPClient, webhook, event)
+		}})
+	}
+	if hook != "" {
+		sinks = append(sinks, pssWatchSink{name: "hook", action: "executing hook", deliver: func(event RequestWatchEvent) error {
+			return runPSSWatchHook(hook, event)
+		}})
+	}
+
+	for {
+		var events []RequestWatchEvent
+		for _, requestID := range requestIDs {
+			requestEvents, err := pollPSSRequest(service, state, requestID)
+			if err != nil {
+				output.OutputWithErrorLevelf("Error polling request [%d]: %s", requestID, err)
+				continue
+			}
+
+			for _, event := range requestEvents {
+				markPSSWatchEventSeen(state, event)
+			}
+			deliverPSSWatchEvents(state, requestID, sinks, requestEvents)
+
+			events = append(events, requestEvents...)
+		}
+
+		err = writePSSWatchState(fs, statePath, state)
+		if err != nil {
+			return err
+		}
+
+		if len(events) > 0 {
+			err = output.CommandOutput(cmd, RequestWatchEventCollection(events))
+			if err != nil {
+				return err
+			}
+		}
+
+		if once {
+			return nil
+		}
+
+		time.Sleep(time.Duration(interval) * time.Second)
+	}
+}
+
+// pollPSSRequest retrieves request with ID requestID and its replies, returning events for
+// replies and status changes not present in state. Where the request isn't yet present in state,
+// its existing replies and status are added to state without returning events. Otherwise state
+// isn't updated, with events marked as seen via markPSSWatchEventSeen
+func pollPSSRequest(service pss.PSSService, state pssWatchState, requestID int) ([]RequestWatchEvent, error) {
+	request, err := service.GetRequest(requestID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving request: %s", err)
+	}
+
+	replies, err := service.GetRequestConversation(requestID, connection.APIRequestParameters{})
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving request replies: %s", err)
+	}
+
+	key := strconv.Itoa(requestID)
+	requestState, watched := state[key]
+	if !watched {
+		for _, reply := range replies {
+			requestState.Replies = append(requestState.Replies, reply.ID)
+		}
+		requestState.Status = request.Status.String()
+		state[key] = requestState
+
+		return nil, nil
+	}
+
+	var events []RequestWatchEvent
+	for _, reply := range replies {
+		if slices.Contains(requestState.Replies, reply.ID) {
+			continue
+		}
+
+		events = append(events, RequestWatchEvent{
+			Event:       "reply",
+			RequestID:   requestID,
+			Subject:     request.Subject,
+			Status:      request.Status.String(),
+			ReplyID:     reply.ID,
+			AuthorName:  reply.Author.Name,
+			Description: reply.Description,
+			CreatedAt:   reply.CreatedAt,
+		})
+	}
+
+	if requestState.Status != request.Status.String() {
+		events = append(events, RequestWatchEvent{
+			Event:     "status",
+			RequestID: requestID,
+			Subject:   request.Subject,
+			Status:    request.Status.String(),
+		})
+	}
+
+	return events, nil
+}
+
+// markPSSWatchEventSeen updates state so event isn't returned by subsequent polls
+func markPSSWatchEventSeen(state pssWatchState, event RequestWatchEvent) {
+	key := strconv.Itoa(event.RequestID)
+	requestState := state[key]
+
+	switch event.Event {
+	case "reply":
+		requestState.Replies = append(requestState.Replies, event.ReplyID)
+	case "status":
+		requestState.Status = event.Status
+	}
+
+	state[key] = requestState
+}
+
+// deliverPSSWatchEvents delivers events for request with ID requestID to each of sinks, along with
+// events which previously failed to be delivered to each sink. Events which fail to be delivered are
+// recorded in state as pending for the failed sink only, to be retried on the next poll
+func deliverPSSWatchEvents(state pssWatchState, requestID int, sinks []pssWatchSink, events []RequestWatchEvent) {
+	key := strconv.Itoa(requestID)
+	requestState := state[key]
+
+	pending := make(map[string][]RequestWatchEvent)
+	for _, sink := range sinks {
+		for _, event := range slices.Concat(requestState.Pending[sink.name], events) {
+			err := sink.deliver(event)
+			if err != nil {
+				output.OutputWithErrorLevelf("Error %s for request [%d]: %s", sink.action, event.RequestID, err)
+				pending[sink.name] = append(pending[sink.name], event)
+			}
+		}
+	}
+
+	requestState.Pending = nil
+	if len(pending) > 0 {
+		requestState.Pending = pending
+	}
+	state[key] = requestState
+}
+
+func getPSSWatchStatePath(cmd *cobra.Command) (string, error) {
+	statePath, _ := cmd.Flags().GetString("state-file")
+	if statePath != "" {
+		return statePath, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine home directory for state file: %s", err)
+	}
+
+	return filepath.Join(home, pssWatchStateFileName), nil
+}
+
+func readPSSWatchState(fs afero.Fs, path string) (pssWatchState, error) {
+	state := make(pssWatchState)
+
+	exists, err := afero.Exists(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %s", err)
+	}
+	if !exists {
+		return state, nil
+	}
+
+	content, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %s", err)
+	}
+
+	err = json.Unmarshal(content, &state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse state file: %s", err)
+	}
+
+	return state, nil
+}
+
+func writePSSWatchState(fs afero.Fs, path string, state pssWatchState) error {
+	content, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %s", err)
+	}
+
+	err = afero.WriteFile(fs, path, content, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to write state file: %s", err)
+	}
+
+	return nil
+}
+
+// pssWatchEventText returns a human readable summary of event
+func pssWatchEventText(event RequestWatchEvent) string {
+	if event.Event == "status" {
+		return fmt.Sprintf("Request #%d (%s) status changed to '%s'", event.RequestID, event.Subject, event.Status)
+	}
+
+	return fmt.Sprintf("New reply on request #%d (%s) from %s:\n%s", event.RequestID, event.Subject, event.AuthorName, event.Description)
+}
+
+func sendPSSWatchWebhook(client *http.Client, url string, event RequestWatchEvent) error {
+	body, err := json.Marshal(map[string]string{"text": pssWatchEventText(event)})
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code [%d]", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func runPSSWatchHook(hook string, event RequestWatchEvent) error {
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	hookCmd := pssWatchHookCommand(hook)
+	hookCmd.Env = append(os.Environ(),
+		"ANS_PSS_EVENT="+event.Event,
+		"ANS_PSS_REQUEST_ID="+strconv.Itoa(event.RequestID),
+		"ANS_PSS_REPLY_ID="+event.ReplyID,
+		"ANS_PSS_STATUS="+event.Status,
+	)
+	hookCmd.Stdin = bytes.NewReader(eventJSON)
+	hookCmd.Stdout = os.Stderr
+	hookCmd.Stderr = os.Stderr
+
+	return hookCmd.Run()
+}
+
+// pssWatchHookCommand returns a command executing hook using the shell for the current platform
+func pssWatchHookCommand(hook string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", hook)
+	}
+
+	return exec.Command("sh", "-c", hook)
+}
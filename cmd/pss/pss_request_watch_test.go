@@ -0,0 +1,320 @@
+package pss
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ans-group/cli/test/mocks"
+	"github.com/ans-group/cli/test/test_output"
+	"github.com/ans-group/sdk-go/pkg/service/pss"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_pssRequestWatchCmd_Args(t *testing.T) {
+	t.Run("ValidArgs_NoError", func(t *testing.T) {
+		err := pssRequestWatchCmd(nil, nil).Args(nil, []string{"123"})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("InvalidArgs_Error", func(t *testing.T) {
+		err := pssRequestWatchCmd(nil, nil).Args(nil, []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "missing request", err.Error())
+	})
+}
+
+func Test_pssRequestWatch(t *testing.T) {
+	t.Run("FirstPoll_PersistsStateWithoutEvents", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		fs := afero.NewMemMapFs()
+		cmd := pssRequestWatchCmd(nil, fs)
+		cmd.ParseFlags([]string{"--once", "--state-file", "/state.json"})
+
+		service.EXPECT().GetRequest(123).Return(pss.Request{ID: 123, Status: pss.RequestStatusSubmitted}, nil)
+		service.EXPECT().GetRequestConversation(123, gomock.Any()).Return([]pss.Reply{{ID: "C1"}}, nil)
+
+		err := pssRequestWatch(service, cmd, fs, []string{"123"})
+
+		assert.Nil(t, err)
+
+		state, _ := readPSSWatchState(fs, "/state.json")
+		assert.Equal(t, pssWatchRequestState{Status: "Submitted", Replies: []string{"C1"}}, state["123"])
+	})
+
+	t.Run("InvalidRequestID_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		fs := afero.NewMemMapFs()
+		cmd := pssRequestWatchCmd(nil, fs)
+
+		err := pssRequestWatch(service, cmd, fs, []string{"abc"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "invalid request ID [abc]", err.Error())
+	})
+
+	t.Run("InvalidInterval_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		fs := afero.NewMemMapFs()
+		cmd := pssRequestWatchCmd(nil, fs)
+		cmd.ParseFlags([]string{"--interval", "0"})
+
+		err := pssRequestWatch(service, cmd, fs, []string{"123"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "interval must be greater than 0", err.Error())
+	})
+
+	t.Run("InvalidStateFile_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		fs := afero.NewMemMapFs()
+		afero.WriteFile(fs, "/state.json", []byte("invalid"), 0600)
+		cmd := pssRequestWatchCmd(nil, fs)
+		cmd.ParseFlags([]string{"--once", "--state-file", "/state.json"})
+
+		err := pssRequestWatch(service, cmd, fs, []string{"123"})
+
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "failed to parse state file")
+	})
+
+	t.Run("GetRequestError_OutputsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		fs := afero.NewMemMapFs()
+		cmd := pssRequestWatchCmd(nil, fs)
+		cmd.ParseFlags([]string{"--once", "--state-file", "/state.json"})
+
+		service.EXPECT().GetRequest(123).Return(pss.Request{}, errors.New("test error"))
+
+		test_output.AssertErrorOutput(t, "Error polling request [123]: error retrieving request: test error\n", func() {
+			pssRequestWatch(service, cmd, fs, []string{"123"})
+		})
+	})
+
+	t.Run("Webhook_SendsEvents", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		var texts []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			payload := map[string]string{}
+			json.Unmarshal(body, &payload)
+			texts = append(texts, payload["text"])
+		}))
+		defer server.Close()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		fs := afero.NewMemMapFs()
+		writePSSWatchState(fs, "/state.json", pssWatchState{"123": {Status: "Submitted", Replies: []string{"C1"}}})
+		cmd := pssRequestWatchCmd(nil, fs)
+		cmd.ParseFlags([]string{"--once", "--state-file", "/state.json", "--webhook", server.URL})
+
+		service.EXPECT().GetRequest(123).Return(pss.Request{ID: 123, Subject: "test", Status: pss.RequestStatusCompleted}, nil)
+		service.EXPECT().GetRequestConversation(123, gomock.Any()).Return([]pss.Reply{{ID: "C1"}, {ID: "C2", Author: pss.Author{Name: "support"}, Description: "done"}}, nil)
+
+		err := pssRequestWatch(service, cmd, fs, []string{"123"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{
+			"New reply on request #123 (test) from support:\ndone",
+			"Request #123 (test) status changed to 'Completed'",
+		}, texts)
+
+		state, _ := readPSSWatchState(fs, "/state.json")
+		assert.Equal(t, pssWatchRequestState{Status: "Completed", Replies: []string{"C1", "C2"}}, state["123"])
+	})
+
+	t.Run("WebhookError_EventsPendingForWebhook", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		fs := afero.NewMemMapFs()
+		writePSSWatchState(fs, "/state.json", pssWatchState{"123": {Status: "Submitted", Replies: []string{"C1"}}})
+		cmd := pssRequestWatchCmd(nil, fs)
+		cmd.ParseFlags([]string{"--once", "--state-file", "/state.json", "--webhook", server.URL})
+
+		service.EXPECT().GetRequest(123).Return(pss.Request{ID: 123, Subject: "test", Status: pss.RequestStatusCompleted}, nil)
+		service.EXPECT().GetRequestConversation(123, gomock.Any()).Return([]pss.Reply{{ID: "C1"}, {ID: "C2"}}, nil)
+
+		test_output.AssertErrorOutput(t, "Error sending webhook for request [123]: unexpected status code [500]\nError sending webhook for request [123]: unexpected status code [500]\n", func() {
+			pssRequestWatch(service, cmd, fs, []string{"123"})
+		})
+
+		state, _ := readPSSWatchState(fs, "/state.json")
+		assert.Equal(t, "Completed", state["123"].Status)
+		assert.Equal(t, []string{"C1", "C2"}, state["123"].Replies)
+		assert.Len(t, state["123"].Pending["webhook"], 2)
+	})
+
+	t.Run("WebhookErrorThenSuccess_RetriesWebhookOnly", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		fail := true
+		var texts []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if fail {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			body, _ := io.ReadAll(r.Body)
+			payload := map[string]string{}
+			json.Unmarshal(body, &payload)
+			texts = append(texts, payload["text"])
+		}))
+		defer server.Close()
+
+		outPath := filepath.Join(t.TempDir(), "hook.out")
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		fs := afero.NewMemMapFs()
+		writePSSWatchState(fs, "/state.json", pssWatchState{"123": {Status: "Submitted", Replies: []string{"C1"}}})
+		cmd := pssRequestWatchCmd(nil, fs)
+		cmd.Flags().String("output", "", "")
+		cmd.ParseFlags([]string{"--once", "--state-file", "/state.json", "--webhook", server.URL, "--hook", "echo $ANS_PSS_REPLY_ID >> " + outPath, "--output", "value"})
+
+		service.EXPECT().GetRequest(123).Return(pss.Request{ID: 123, Subject: "test", Status: pss.RequestStatusSubmitted}, nil).Times(2)
+		service.EXPECT().GetRequestConversation(123, gomock.Any()).Return([]pss.Reply{{ID: "C1"}, {ID: "C2", Author: pss.Author{Name: "support"}, Description: "done"}}, nil).Times(2)
+
+		test_output.AssertCombinedOutput(t, "reply 123 Submitted C2 support \n", "Error sending webhook for request [123]: unexpected status code [500]\n", func() {
+			pssRequestWatch(service, cmd, fs, []string{"123"})
+		})
+
+		fail = false
+		test_output.AssertCombinedOutput(t, "", "", func() {
+			err := pssRequestWatch(service, cmd, fs, []string{"123"})
+			assert.Nil(t, err)
+		})
+
+		assert.Equal(t, []string{"New reply on request #123 (test) from support:\ndone"}, texts)
+
+		content, _ := os.ReadFile(outPath)
+		assert.Equal(t, "C2\n", string(content))
+
+		state, _ := readPSSWatchState(fs, "/state.json")
+		assert.Equal(t, pssWatchRequestState{Status: "Submitted", Replies: []string{"C1", "C2"}}, state["123"])
+	})
+
+	t.Run("Hook_ExecutesForEvents", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		outPath := filepath.Join(t.TempDir(), "hook.out")
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		fs := afero.NewMemMapFs()
+		writePSSWatchState(fs, "/state.json", pssWatchState{"123": {Status: "Submitted"}})
+		cmd := pssRequestWatchCmd(nil, fs)
+		cmd.ParseFlags([]string{"--once", "--state-file", "/state.json", "--hook", "echo $ANS_PSS_EVENT $ANS_PSS_REQUEST_ID $ANS_PSS_REPLY_ID >> " + outPath})
+
+		service.EXPECT().GetRequest(123).Return(pss.Request{ID: 123, Status: pss.RequestStatusSubmitted}, nil)
+		service.EXPECT().GetRequestConversation(123, gomock.Any()).Return([]pss.Reply{{ID: "C1"}}, nil)
+
+		err := pssRequestWatch(service, cmd, fs, []string{"123"})
+
+		assert.Nil(t, err)
+
+		content, _ := os.ReadFile(outPath)
+		assert.Equal(t, "reply 123 C1\n", string(content))
+	})
+}
+
+func Test_pollPSSRequest(t *testing.T) {
+	t.Run("SeenReplies_NoEvents", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		state := pssWatchState{"123": {Status: "Submitted", Replies: []string{"C1"}}}
+
+		service.EXPECT().GetRequest(123).Return(pss.Request{ID: 123, Status: pss.RequestStatusSubmitted}, nil)
+		service.EXPECT().GetRequestConversation(123, gomock.Any()).Return([]pss.Reply{{ID: "C1"}}, nil)
+
+		events, err := pollPSSRequest(service, state, 123)
+
+		assert.Nil(t, err)
+		assert.Len(t, events, 0)
+	})
+
+	t.Run("NewReplyAndStatus_ReturnsEvents", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+		state := pssWatchState{"123": {Status: "Submitted", Replies: []string{"C1"}}}
+
+		service.EXPECT().GetRequest(123).Return(pss.Request{ID: 123, Status: pss.RequestStatusAwaitingCustomerResponse}, nil)
+		service.EXPECT().GetRequestConversation(123, gomock.Any()).Return([]pss.Reply{{ID: "C1"}, {ID: "C2"}}, nil)
+
+		events, err := pollPSSRequest(service, state, 123)
+
+		assert.Nil(t, err)
+		assert.Len(t, events, 2)
+		assert.Equal(t, "reply", events[0].Event)
+		assert.Equal(t, "C2", events[0].ReplyID)
+		assert.Equal(t, "status", events[1].Event)
+		assert.Equal(t, "Awaiting Customer Response", events[1].Status)
+		assert.Equal(t, pssWatchRequestState{Status: "Submitted", Replies: []string{"C1"}}, state["123"])
+	})
+
+	t.Run("GetRequestConversationError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockPSSService(mockCtrl)
+
+		service.EXPECT().GetRequest(123).Return(pss.Request{}, nil)
+		service.EXPECT().GetRequestConversation(123, gomock.Any()).Return(nil, errors.New("test error"))
+
+		_, err := pollPSSRequest(service, pssWatchState{}, 123)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error retrieving request replies: test error", err.Error())
+	})
+}
+
+func Test_sendPSSWatchWebhook(t *testing.T) {
+	t.Run("ErrorStatus_ReturnsError", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		err := sendPSSWatchWebhook(server.Client(), server.URL, RequestWatchEvent{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "unexpected status code [500]", err.Error())
+	})
+}
@@ -73,3 +73,30 @@ func (w WhoisCollection) Fields() []*output.OrderedFields {
 
 	return data
 }
+
+// AuditFinding represents an issue found when auditing a domain
+type AuditFinding struct {
+	Domain string `json:"domain"`
+	Check  string `json:"check"`
+	Detail string `json:"detail"`
+}
+
+type AuditFindingCollection []AuditFinding
+
+func (a AuditFindingCollection) DefaultColumns() []string {
+	return []string{"domain", "check", "detail"}
+}
+
+func (a AuditFindingCollection) Fields() []*output.OrderedFields {
+	var data []*output.OrderedFields
+	for _, finding := range a {
+		fields := output.NewOrderedFields()
+		fields.Set("domain", finding.Domain)
+		fields.Set("check", finding.Check)
+		fields.Set("detail", finding.Detail)
+
+		data = append(data, fields)
+	}
+
+	return data
+}
@@ -11,6 +11,9 @@ func RegistrarRootCmd(f factory.ClientFactory) *cobra.Command {
 		Short: "Commands relating to Registrar service",
 	}
 
+	// Child commands
+	cmd.AddCommand(registrarAuditCmd(f))
+
 	// Child root commands
 	cmd.AddCommand(registrarDomainRootCmd(f))
 	cmd.AddCommand(registrarWhoisRootCmd(f))
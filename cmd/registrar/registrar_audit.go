@@ -0,0 +1,165 @@
+package registrar
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ans-group/cli/internal/pkg/factory"
+	"github.com/ans-group/cli/internal/pkg/output"
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/ans-group/sdk-go/pkg/service/registrar"
+	"github.com/ans-group/sdk-go/pkg/service/safedns"
+	"github.com/spf13/cobra"
+)
+
+// auditNow is overridden in tests
+var auditNow = time.Now
+
+func registrarAuditCmd(f factory.ClientFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit [domain: name...]",
+		Short: "Audits domains for expiry and nameserver issues",
+		Long: "This command audits all domains, or the specified domains, outputting a finding for each domain which expires " +
+			"within the specified number of days, has auto-renew disabled, has no SafeDNS zone, or has registered nameservers " +
+			"which don't match the NS records at the apex of its SafeDNS zone. The command exits with a non-zero status when " +
+			"there are findings",
+		Example: "ans registrar audit\nans registrar audit --expiry-days 60\nans registrar audit example.com",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := f.NewClient()
+			if err != nil {
+				return err
+			}
+
+			return registrarAudit(c.RegistrarService(), c.SafeDNSService(), cmd, args)
+		},
+	}
+
+	cmd.Flags().Int("expiry-days", 30, "Specifies number of days within which an expiring domain is reported")
+
+	return cmd
+}
+
+func registrarAudit(service registrar.RegistrarService, safednsService safedns.SafeDNSService, cmd *cobra.Command, args []string) error {
+	expiryDays, _ := cmd.Flags().GetInt("expiry-days")
+
+	var domains []registrar.Domain
+	if len(args) > 0 {
+		for _, arg := range args {
+			domain, err := service.GetDomain(arg)
+			if err != nil {
+				output.OutputWithErrorLevelf("Error retrieving domain [%s]: %s", arg, err)
+				continue
+			}
+
+			domains = append(domains, domain)
+		}
+	} else {
+		var err error
+		domains, err = service.GetDomains(connection.APIRequestParameters{})
+		if err != nil {
+			return fmt.Errorf("error retrieving domains: %s", err)
+		}
+	}
+
+	expiryCutoff := auditNow().AddDate(0, 0, expiryDays)
+
+	var findings []AuditFinding
+	for _, domain := range domains {
+		renewalAt := domain.RenewalAt.Time()
+		if !renewalAt.IsZero() && renewalAt.Before(expiryCutoff) {
+			findings = append(findings, AuditFinding{
+				Domain: domain.Name,
+				Check:  "expiry",
+				Detail: fmt.Sprintf("domain expires on %s", domain.RenewalAt),
+			})
+		}
+
+		if !domain.AutoRenew {
+			findings = append(findings, AuditFinding{
+				Domain: domain.Name,
+				Check:  "auto_renew",
+				Detail: "auto-renew is disabled",
+			})
+		}
+
+		finding, err := auditDomainNameservers(service, safednsService, domain.Name)
+		if err != nil {
+			output.OutputWithErrorLevelf("Error auditing nameservers for domain [%s]: %s", domain.Name, err)
+			continue
+		}
+		if finding != nil {
+			findings = append(findings, *finding)
+		}
+	}
+
+	err := output.CommandOutput(cmd, AuditFindingCollection(findings))
+	if err != nil {
+		return err
+	}
+
+	if len(findings) > 0 {
+		output.OutputWithErrorLevelf("Audit found %d issue(s)", len(findings))
+	}
+
+	return nil
+}
+
+// auditDomainNameservers returns a finding if domain has no SafeDNS zone, or its registered nameservers
+// don't match the NS records at the apex of its SafeDNS zone
+func auditDomainNameservers(service registrar.RegistrarService, safednsService safedns.SafeDNSService, domainName string) (*AuditFinding, error) {
+	nameservers, err := service.GetDomainNameservers(domainName)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving domain nameservers: %s", err)
+	}
+
+	params := connection.APIRequestParameters{}
+	params.WithFilter(connection.APIRequestFiltering{
+		Property: "type",
+		Operator: connection.EQOperator,
+		Value:    []string{safedns.RecordTypeNS.String()},
+	})
+
+	records, err := safednsService.GetZoneRecords(domainName, params)
+	if err != nil {
+		switch err.(type) {
+		case *safedns.ZoneNotFoundError:
+			return &AuditFinding{
+				Domain: domainName,
+				Check:  "safedns_zone",
+				Detail: "domain has no SafeDNS zone",
+			}, nil
+		default:
+			return nil, fmt.Errorf("error retrieving SafeDNS zone records: %s", err)
+		}
+	}
+
+	var registered []string
+	for _, nameserver := range nameservers {
+		registered = append(registered, normaliseNameserverHost(nameserver.Host))
+	}
+
+	var zone []string
+	for _, record := range records {
+		if record.Type == safedns.RecordTypeNS && normaliseNameserverHost(record.Name) == normaliseNameserverHost(domainName) {
+			zone = append(zone, normaliseNameserverHost(record.Content))
+		}
+	}
+
+	slices.Sort(registered)
+	slices.Sort(zone)
+	if !slices.Equal(slices.Compact(registered), slices.Compact(zone)) {
+		return &AuditFinding{
+			Domain: domainName,
+			Check:  "nameservers",
+			Detail: fmt.Sprintf("registered nameservers [%s] don't match SafeDNS NS records [%s]", strings.Join(registered, ", "), strings.Join(zone, ", ")),
+		}, nil
+	}
+
+	return nil, nil
+}
+
+func normaliseNameserverHost(host string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(host), "."))
+}
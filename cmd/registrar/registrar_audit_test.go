@@ -0,0 +1,133 @@
+package registrar
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ans-group/cli/test/mocks"
+	"github.com/ans-group/cli/test/test_output"
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/ans-group/sdk-go/pkg/service/registrar"
+	"github.com/ans-group/sdk-go/pkg/service/safedns"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setAuditNow(t *testing.T) {
+	oldAuditNow := auditNow
+	auditNow = func() time.Time { return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() { auditNow = oldAuditNow })
+}
+
+func Test_registrarAudit(t *testing.T) {
+	t.Run("Findings_OutputsFindings", func(t *testing.T) {
+		setAuditNow(t)
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockRegistrarService(mockCtrl)
+		safednsService := mocks.NewMockSafeDNSService(mockCtrl)
+		cmd := registrarAuditCmd(nil)
+		cmd.Flags().String("output", "", "")
+		cmd.ParseFlags([]string{"--output", "csv"})
+
+		service.EXPECT().GetDomains(gomock.Any()).Return([]registrar.Domain{
+			{Name: "example.com", RenewalAt: "2024-01-10", AutoRenew: false},
+			{Name: "example.org", RenewalAt: "2025-01-10", AutoRenew: true},
+		}, nil)
+		service.EXPECT().GetDomainNameservers("example.com").Return([]registrar.Nameserver{{Host: "ns0.ans.uk"}}, nil)
+		safednsService.EXPECT().GetZoneRecords("example.com", gomock.Any()).Return(nil, &safedns.ZoneNotFoundError{ZoneName: "example.com"})
+		service.EXPECT().GetDomainNameservers("example.org").Return([]registrar.Nameserver{{Host: "ns0.ans.uk"}, {Host: "ns1.ans.uk"}}, nil)
+		safednsService.EXPECT().GetZoneRecords("example.org", gomock.Any()).Return([]safedns.Record{
+			{Name: "example.org", Type: safedns.RecordTypeNS, Content: "ns1.ans.uk."},
+			{Name: "example.org", Type: safedns.RecordTypeNS, Content: "NS0.ans.uk"},
+			{Name: "sub.example.org", Type: safedns.RecordTypeNS, Content: "ns.other.com"},
+			{Name: "example.org", Type: safedns.RecordTypeA, Content: "1.2.3.4"},
+		}, nil)
+
+		test_output.AssertCombinedOutput(t, "domain,check,detail\n"+
+			"example.com,expiry,domain expires on 2024-01-10\n"+
+			"example.com,auto_renew,auto-renew is disabled\n"+
+			"example.com,safedns_zone,domain has no SafeDNS zone\n",
+			"Audit found 3 issue(s)\n", func() {
+				err := registrarAudit(service, safednsService, cmd, []string{})
+				assert.Nil(t, err)
+			})
+	})
+
+	t.Run("NameserverMismatch_OutputsFinding", func(t *testing.T) {
+		setAuditNow(t)
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockRegistrarService(mockCtrl)
+		safednsService := mocks.NewMockSafeDNSService(mockCtrl)
+		cmd := registrarAuditCmd(nil)
+		cmd.Flags().String("output", "", "")
+		cmd.ParseFlags([]string{"--output", "csv", "--expiry-days", "5"})
+
+		service.EXPECT().GetDomain("example.com").Return(registrar.Domain{Name: "example.com", RenewalAt: "2024-01-10", AutoRenew: true}, nil)
+		service.EXPECT().GetDomainNameservers("example.com").Return([]registrar.Nameserver{{Host: "ns1.other.com"}}, nil)
+		params := connection.APIRequestParameters{}
+		params.WithFilter(connection.APIRequestFiltering{Property: "type", Operator: connection.EQOperator, Value: []string{"NS"}})
+		safednsService.EXPECT().GetZoneRecords("example.com", params).Return([]safedns.Record{
+			{Name: "example.com", Type: safedns.RecordTypeNS, Content: "ns0.ans.uk"},
+		}, nil)
+
+		test_output.AssertOutput(t, "domain,check,detail\n"+
+			"example.com,nameservers,registered nameservers [ns1.other.com] don't match SafeDNS NS records [ns0.ans.uk]\n", func() {
+			err := registrarAudit(service, safednsService, cmd, []string{"example.com"})
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("GetDomainError_OutputsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockRegistrarService(mockCtrl)
+		safednsService := mocks.NewMockSafeDNSService(mockCtrl)
+
+		service.EXPECT().GetDomain("example.com").Return(registrar.Domain{}, errors.New("test error"))
+
+		test_output.AssertErrorOutput(t, "Error retrieving domain [example.com]: test error\n", func() {
+			registrarAudit(service, safednsService, registrarAuditCmd(nil), []string{"example.com"})
+		})
+	})
+
+	t.Run("GetZoneRecordsError_OutputsError", func(t *testing.T) {
+		setAuditNow(t)
+
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockRegistrarService(mockCtrl)
+		safednsService := mocks.NewMockSafeDNSService(mockCtrl)
+
+		service.EXPECT().GetDomain("example.com").Return(registrar.Domain{Name: "example.com", AutoRenew: true}, nil)
+		service.EXPECT().GetDomainNameservers("example.com").Return([]registrar.Nameserver{}, nil)
+		safednsService.EXPECT().GetZoneRecords("example.com", gomock.Any()).Return(nil, errors.New("test error"))
+
+		test_output.AssertErrorOutput(t, "Error auditing nameservers for domain [example.com]: error retrieving SafeDNS zone records: test error\n", func() {
+			registrarAudit(service, safednsService, registrarAuditCmd(nil), []string{"example.com"})
+		})
+	})
+
+	t.Run("GetDomainsError_ReturnsError", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		service := mocks.NewMockRegistrarService(mockCtrl)
+		safednsService := mocks.NewMockSafeDNSService(mockCtrl)
+
+		service.EXPECT().GetDomains(gomock.Any()).Return(nil, errors.New("test error"))
+
+		err := registrarAudit(service, safednsService, registrarAuditCmd(nil), []string{})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "error retrieving domains: test error", err.Error())
+	})
+}
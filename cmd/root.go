@@ -44,12 +44,21 @@ func Execute(build build.BuildInfo) {
 	// Global flags
 	rootCmd.PersistentFlags().String("config", "", "config file (default is $HOME/.ans.yml)")
 	rootCmd.PersistentFlags().String("context", "", "specific context to use")
-	rootCmd.PersistentFlags().StringP("output", "o", "table", "output type {table, json, yaml, jsonpath, template, value, csv, list}, with optional argument provided as 'outputname=outputargument'")
+	rootCmd.PersistentFlags().StringP("output", "o", "table", "output type {table, json, ndjson, yaml, jsonpath, template, gotemplate, value, csv, tsv, list}, with optional argument provided as 'outputname=outputargument'")
+	rootCmd.PersistentFlags().String("template-file", "", "path to Go text template file for 'gotemplate' output, implies '--output gotemplate'")
 	rootCmd.PersistentFlags().String("sort", "", "output sorting, e.g. 'name', 'name:asc', 'name:desc'")
-	rootCmd.PersistentFlags().StringSlice("property", []string{}, "property to output (used with several formats), can be repeated")
+	rootCmd.PersistentFlags().StringSlice("property", []string{}, "property to output (used with several formats), can be repeated, with optional header alias provided as 'property:alias'")
 	rootCmd.PersistentFlags().StringArray("filter", []string{}, "filter for list commands, can be repeated, e.g. 'property=somevalue', 'property:gt=3', 'property=valu*'")
 	rootCmd.PersistentFlags().StringArray("localfilter", []string{}, "local filter for list commands, can be repeated, e.g. 'property=somevalue', 'property:gt=3'")
+	rootCmd.PersistentFlags().String("where", "", "local filter expression for list commands, e.g. 'status == \"Complete\" && (ram_gb > 8 || name =~ \"^web\")'")
+	rootCmd.PersistentFlags().StringSlice("group-by", []string{}, "property to group list output by, can be repeated, used with --aggregate")
+	rootCmd.PersistentFlags().StringSlice("aggregate", []string{}, "aggregate to calculate for list output, e.g. 'count', 'sum(ram_mb)', 'avg(ram_mb)', 'min(created_at)', 'max(created_at)'")
 	rootCmd.PersistentFlags().Int("page", 0, "page to retrieve for paginated requests")
+	rootCmd.PersistentFlags().Int("limit", 0, "maximum number of items to output for list commands, stopping retrieval early where supported")
+	rootCmd.PersistentFlags().Duration("watch", 0, "re-run list and show commands at interval, e.g. '--watch' or '--watch=10s'")
+	rootCmd.PersistentFlags().Lookup("watch").NoOptDefVal = "5s"
+	rootCmd.PersistentFlags().String("until", "", "stop watching once all output items match expression, e.g. 'status=Complete'")
+	rootCmd.PersistentFlags().Bool("no-pager", false, "don't pipe table output through the pager specified by $PAGER")
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Enables verbose output")
 
 	cobra.OnInitialize(initConfig)
@@ -67,7 +76,7 @@ func Execute(build build.BuildInfo) {
 	rootCmd.AddCommand(configcmd.ConfigRootCmd(fs))
 	rootCmd.AddCommand(CompletionRootCmd())
 	rootCmd.AddCommand(rawCmd(connectionFactory))
-	rootCmd.AddCommand(accountcmd.AccountRootCmd(clientFactory))
+	rootCmd.AddCommand(accountcmd.AccountRootCmd(clientFactory, fs))
 	rootCmd.AddCommand(billingcmd.BillingRootCmd(clientFactory))
 	rootCmd.AddCommand(ddosxcmd.DDoSXRootCmd(clientFactory, fs))
 	rootCmd.AddCommand(draascmd.DRaaSRootCmd(clientFactory))
@@ -80,6 +89,8 @@ func Execute(build build.BuildInfo) {
 	rootCmd.AddCommand(sslcmd.SSLRootCmd(clientFactory, fs))
 	rootCmd.AddCommand(storagecmd.StorageRootCmd(clientFactory))
 
+	addWatchSupport(rootCmd)
+
 	if err := rootCmd.Execute(); err != nil {
 		output.Fatal(err.Error())
 	}
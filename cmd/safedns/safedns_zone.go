@@ -57,12 +57,12 @@ func safednsZoneList(service safedns.SafeDNSService, cmd *cobra.Command, args []
 		return err
 	}
 
-	zones, err := service.GetZones(params)
+	err = output.CommandOutputStream[ZoneCollection](cmd, params, service.GetZonesPaginated)
 	if err != nil {
 		return fmt.Errorf("error retrieving zones: %s", err)
 	}
 
-	return output.CommandOutput(cmd, ZoneCollection(zones))
+	return nil
 }
 
 func safednsZoneShowCmd(f factory.ClientFactory) *cobra.Command {
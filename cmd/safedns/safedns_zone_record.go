@@ -9,6 +9,7 @@ import (
 	"github.com/ans-group/cli/internal/pkg/factory"
 	"github.com/ans-group/cli/internal/pkg/helper"
 	"github.com/ans-group/cli/internal/pkg/output"
+	"github.com/ans-group/sdk-go/pkg/connection"
 	"github.com/ans-group/sdk-go/pkg/ptr"
 	"github.com/ans-group/sdk-go/pkg/service/safedns"
 	"github.com/spf13/cobra"
@@ -70,12 +71,14 @@ func safednsZoneRecordList(service safedns.SafeDNSService, cmd *cobra.Command, a
 		return err
 	}
 
-	zoneRecords, err := service.GetZoneRecords(args[0], params)
+	err = output.CommandOutputStream[RecordCollection](cmd, params, func(parameters connection.APIRequestParameters) (*connection.Paginated[safedns.Record], error) {
+		return service.GetZoneRecordsPaginated(args[0], parameters)
+	})
 	if err != nil {
 		return fmt.Errorf("error retrieving records for zone: %s", err)
 	}
 
-	return output.CommandOutput(cmd, RecordCollection(zoneRecords))
+	return nil
 }
 
 func safednsZoneRecordShowCmd(f factory.ClientFactory) *cobra.Command {
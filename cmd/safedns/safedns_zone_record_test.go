@@ -38,7 +38,7 @@ func Test_safednsZoneRecordList(t *testing.T) {
 
 		service := mocks.NewMockSafeDNSService(mockCtrl)
 
-		service.EXPECT().GetZoneRecords("testdomain1.com", gomock.Any()).Return([]safedns.Record{}, nil).Times(1)
+		service.EXPECT().GetZoneRecordsPaginated("testdomain1.com", gomock.Any()).Return(connection.NewPaginated(&connection.APIResponseBodyData[[]safedns.Record]{}, connection.APIRequestParameters{}, nil), nil).Times(1)
 
 		safednsZoneRecordList(service, &cobra.Command{}, []string{"testdomain1.com"})
 	})
@@ -71,9 +71,12 @@ func Test_safednsZoneRecordList(t *testing.T) {
 					Value:    []string{"1.2.3.4"},
 				},
 			},
+			Pagination: connection.APIRequestPagination{
+				Page: 1,
+			},
 		}
 
-		service.EXPECT().GetZoneRecords("testdomain1.com", gomock.Eq(expectedParams)).Return([]safedns.Record{}, nil)
+		service.EXPECT().GetZoneRecordsPaginated("testdomain1.com", gomock.Eq(expectedParams)).Return(connection.NewPaginated(&connection.APIResponseBodyData[[]safedns.Record]{}, connection.APIRequestParameters{}, nil), nil)
 
 		safednsZoneRecordList(service, cmd, []string{"testdomain1.com"})
 	})
@@ -98,7 +101,7 @@ func Test_safednsZoneRecordList(t *testing.T) {
 
 		service := mocks.NewMockSafeDNSService(mockCtrl)
 
-		service.EXPECT().GetZoneRecords("testdomain1.com", gomock.Any()).Return([]safedns.Record{}, errors.New("test error")).Times(1)
+		service.EXPECT().GetZoneRecordsPaginated("testdomain1.com", gomock.Any()).Return(nil, errors.New("test error")).Times(1)
 
 		err := safednsZoneRecordList(service, &cobra.Command{}, []string{"testdomain1.com"})
 
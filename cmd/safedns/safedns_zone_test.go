@@ -22,7 +22,7 @@ func Test_safednsZoneList(t *testing.T) {
 
 		service := mocks.NewMockSafeDNSService(mockCtrl)
 
-		service.EXPECT().GetZones(gomock.Any()).Return([]safedns.Zone{}, nil).Times(1)
+		service.EXPECT().GetZonesPaginated(gomock.Any()).Return(connection.NewPaginated(&connection.APIResponseBodyData[[]safedns.Zone]{}, connection.APIRequestParameters{}, nil), nil).Times(1)
 
 		safednsZoneList(service, &cobra.Command{}, []string{})
 	})
@@ -43,9 +43,12 @@ func Test_safednsZoneList(t *testing.T) {
 					Value:    []string{"testdomain1.co.uk"},
 				},
 			},
+			Pagination: connection.APIRequestPagination{
+				Page: 1,
+			},
 		}
 
-		service.EXPECT().GetZones(gomock.Eq(expectedParams)).Return([]safedns.Zone{}, nil).Times(1)
+		service.EXPECT().GetZonesPaginated(gomock.Eq(expectedParams)).Return(connection.NewPaginated(&connection.APIResponseBodyData[[]safedns.Zone]{}, connection.APIRequestParameters{}, nil), nil).Times(1)
 
 		safednsZoneList(service, cmd, []string{})
 	})
@@ -69,7 +72,7 @@ func Test_safednsZoneList(t *testing.T) {
 
 		service := mocks.NewMockSafeDNSService(mockCtrl)
 
-		service.EXPECT().GetZones(gomock.Any()).Return([]safedns.Zone{}, errors.New("test error")).Times(1)
+		service.EXPECT().GetZonesPaginated(gomock.Any()).Return(nil, errors.New("test error")).Times(1)
 
 		err := safednsZoneList(service, &cobra.Command{}, []string{})
 
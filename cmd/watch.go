@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/ans-group/cli/internal/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// watchableCommands are the names of commands which support the 'watch' flag
+var watchableCommands = map[string]bool{
+	"list": true,
+	"show": true,
+}
+
+// addWatchSupport wraps the RunE of cmd and its children, so list and show commands are re-run at an interval
+// when the 'watch' flag is set
+func addWatchSupport(cmd *cobra.Command) {
+	for _, child := range cmd.Commands() {
+		addWatchSupport(child)
+	}
+
+	if cmd.RunE == nil {
+		return
+	}
+
+	runE := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if !cmd.Flags().Changed("watch") {
+			if cmd.Flags().Changed("until") {
+				return errors.New("--until can only be used with --watch")
+			}
+
+			return runE(cmd, args)
+		}
+
+		if !watchableCommands[cmd.Name()] {
+			return errors.New("--watch is only supported by list and show commands")
+		}
+
+		return output.Watch(cmd, args, func() error {
+			return runE(cmd, args)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package helper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseDuration parses a duration string as with time.ParseDuration, additionally supporting
+// whole day (e.g. 90d) and week (e.g. 2w) durations
+func ParseDuration(s string) (time.Duration, error) {
+	units := map[string]time.Duration{
+		"d": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour,
+	}
+
+	for suffix, unit := range units {
+		if value, found := strings.CutSuffix(s, suffix); found {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("invalid duration [%s]", s)
+			}
+
+			return time.Duration(n) * unit, nil
+		}
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration [%s]", s)
+	}
+
+	return d, nil
+}
@@ -0,0 +1,37 @@
+package helper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ans-group/cli/internal/pkg/helper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDuration_Days_ReturnsDuration(t *testing.T) {
+	d, err := helper.ParseDuration("90d")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 90*24*time.Hour, d)
+}
+
+func TestParseDuration_Weeks_ReturnsDuration(t *testing.T) {
+	d, err := helper.ParseDuration("2w")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 14*24*time.Hour, d)
+}
+
+func TestParseDuration_GoDuration_ReturnsDuration(t *testing.T) {
+	d, err := helper.ParseDuration("1h30m")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 90*time.Minute, d)
+}
+
+func TestParseDuration_Invalid_ReturnsError(t *testing.T) {
+	_, err := helper.ParseDuration("xd")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "invalid duration [xd]", err.Error())
+}
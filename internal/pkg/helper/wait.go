@@ -1,6 +1,7 @@
 package helper
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
@@ -11,6 +12,12 @@ import (
 type WaitFunc func() (finished bool, err error)
 
 func WaitForCommand(f WaitFunc) error {
+	return WaitForCommandContext(context.Background(), f)
+}
+
+// WaitForCommandContext behaves as WaitForCommand, however returns as soon as ctx is done,
+// including whilst sleeping between attempts
+func WaitForCommandContext(ctx context.Context, f WaitFunc) error {
 	waitTimeout := 1200
 	if config.GetInt("command_wait_timeout_seconds") > 0 {
 		waitTimeout = config.GetInt("command_wait_timeout_seconds")
@@ -23,6 +30,9 @@ func WaitForCommand(f WaitFunc) error {
 	timeStart := time.Now()
 
 	for {
+		if ctx.Err() != nil {
+			return errors.New("interrupted waiting for command")
+		}
 		if time.Since(timeStart).Seconds() > float64(waitTimeout) {
 			return errors.New("timed out waiting for command")
 		}
@@ -35,7 +45,13 @@ func WaitForCommand(f WaitFunc) error {
 			break
 		}
 
-		time.Sleep(time.Duration(sleepTimeout) * time.Second)
+		timer := time.NewTimer(time.Duration(sleepTimeout) * time.Second)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.New("interrupted waiting for command")
+		case <-timer.C:
+		}
 	}
 
 	return nil
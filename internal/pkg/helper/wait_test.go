@@ -1,8 +1,10 @@
 package helper
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/ans-group/sdk-go/pkg/config"
 	"github.com/stretchr/testify/assert"
@@ -53,4 +55,25 @@ func TestWaitForCommand(t *testing.T) {
 		assert.NotNil(t, r)
 		assert.Equal(t, 3, attempt)
 	})
+	t.Run("ContextCancelledWhilstSleeping_ReturnsImmediately", func(t *testing.T) {
+		config.Reset()
+		config.Set("test", "command_wait_sleep_seconds", 30)
+		config.SwitchCurrentContext("test")
+		defer config.Reset()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		attempt := 0
+		f := func() (bool, error) {
+			attempt++
+			time.AfterFunc(10*time.Millisecond, cancel)
+			return false, nil
+		}
+
+		start := time.Now()
+		r := WaitForCommandContext(ctx, f)
+
+		assert.Equal(t, "interrupted waiting for command", r.Error())
+		assert.Equal(t, 1, attempt)
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
 }
@@ -3,9 +3,11 @@ package input
 import (
 	"bufio"
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
 	"strings"
 
 	"github.com/ans-group/cli/internal/pkg/output"
@@ -15,6 +17,16 @@ var InputReader = func() io.Reader {
 	return os.Stdin
 }
 
+// IsInteractive returns true if stdin is a terminal, rather than piped input
+var IsInteractive = func() bool {
+	stat, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+
+	return stat.Mode()&os.ModeCharDevice != 0
+}
+
 func ReadInput(name string) (string, error) {
 	buf := bytes.Buffer{}
 
@@ -34,3 +46,112 @@ func ReadInput(name string) (string, error) {
 
 	return strings.TrimSuffix(buf.String(), "\n"), nil
 }
+
+// Confirm prompts for confirmation with given prompt, returning true if input is 'y' or 'yes'
+func Confirm(prompt string) (bool, error) {
+	output.Errorf("%s [y/N]:", prompt)
+
+	reader := bufio.NewReader(InputReader())
+	text, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("error reading confirmation from stdin input: %s", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(text)) {
+	case "y", "yes":
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// ComposeInput reads input with given name. Piped input is read in full. Otherwise, the editor specified
+// by $VISUAL or $EDITOR is opened on a temporary file pre-filled with template. When no editor is set, input
+// is read via ReadInput. As template can only be used with an editor, an error is returned if template is
+// specified for piped input or when no editor is set. An error is returned if the resulting input is empty
+func ComposeInput(name string, template string) (string, error) {
+	if !IsInteractive() {
+		if len(template) > 0 {
+			return "", fmt.Errorf("template can't be used when %s is piped to stdin", name)
+		}
+
+		content, err := io.ReadAll(InputReader())
+		if err != nil {
+			return "", fmt.Errorf("error reading %s from stdin input: %s", name, err)
+		}
+
+		result := strings.TrimSpace(string(content))
+		if len(result) < 1 {
+			return "", fmt.Errorf("aborting due to empty %s", name)
+		}
+
+		return result, nil
+	}
+
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		if len(template) > 0 {
+			return "", errors.New("template requires an editor, set $VISUAL or $EDITOR")
+		}
+
+		return ReadInput(name)
+	}
+
+	return ReadInputFromEditor(editor, name, template)
+}
+
+// ReadInputFromEditor opens editor on a temporary file pre-filled with template, returning the saved
+// content with comment lines (lines starting with '#') removed. An error is returned if the resulting
+// content is empty
+func ReadInputFromEditor(editor string, name string, template string) (string, error) {
+	file, err := os.CreateTemp("", "ans-*.txt")
+	if err != nil {
+		return "", fmt.Errorf("error creating temporary file for %s: %s", name, err)
+	}
+	defer os.Remove(file.Name())
+
+	content := template
+	if len(content) > 0 && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	content += fmt.Sprintf("\n# Enter %s above. Lines starting with '#' are ignored, and an empty %s aborts\n", name, name)
+
+	_, err = file.WriteString(content)
+	file.Close()
+	if err != nil {
+		return "", fmt.Errorf("error writing temporary file for %s: %s", name, err)
+	}
+
+	editorArgs := strings.Fields(editor)
+	editorCmd := exec.Command(editorArgs[0], append(editorArgs[1:], file.Name())...)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+
+	err = editorCmd.Run()
+	if err != nil {
+		return "", fmt.Errorf("error running editor [%s]: %s", editor, err)
+	}
+
+	edited, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", fmt.Errorf("error reading temporary file for %s: %s", name, err)
+	}
+
+	var lines []string
+	for _, line := range strings.Split(string(edited), "\n") {
+		if !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+
+	result := strings.TrimSpace(strings.Join(lines, "\n"))
+	if len(result) < 1 {
+		return "", fmt.Errorf("aborting due to empty %s", name)
+	}
+
+	return result, nil
+}
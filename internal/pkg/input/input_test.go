@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/ans-group/cli/test/test_input"
@@ -54,3 +56,201 @@ func TestReadInput(t *testing.T) {
 		assert.Equal(t, "test text", text)
 	})
 }
+
+func TestConfirm(t *testing.T) {
+	t.Run("Yes_ReturnsTrue", func(t *testing.T) {
+		oldReader := InputReader
+		defer func() { InputReader = oldReader }()
+
+		InputReader = func() io.Reader {
+			return bytes.NewReader([]byte("yes\n"))
+		}
+
+		confirmed, err := Confirm("test")
+
+		assert.Nil(t, err)
+		assert.True(t, confirmed)
+	})
+
+	t.Run("Y_ReturnsTrue", func(t *testing.T) {
+		oldReader := InputReader
+		defer func() { InputReader = oldReader }()
+
+		InputReader = func() io.Reader {
+			return bytes.NewReader([]byte("Y"))
+		}
+
+		confirmed, _ := Confirm("test")
+
+		assert.True(t, confirmed)
+	})
+
+	t.Run("Empty_ReturnsFalse", func(t *testing.T) {
+		oldReader := InputReader
+		defer func() { InputReader = oldReader }()
+
+		InputReader = func() io.Reader {
+			return bytes.NewReader([]byte("\n"))
+		}
+
+		confirmed, _ := Confirm("test")
+
+		assert.False(t, confirmed)
+	})
+
+	t.Run("StdinReadError_ReturnsError", func(t *testing.T) {
+		oldReader := InputReader
+		defer func() { InputReader = oldReader }()
+
+		InputReader = func() io.Reader {
+			return &test_input.TestReadCloser{
+				ReadError: errors.New("test error"),
+			}
+		}
+
+		_, err := Confirm("test")
+
+		assert.Equal(t, "error reading confirmation from stdin input: test error", err.Error())
+	})
+}
+
+// writeTestEditor writes an editor script which appends text to the file being edited, returning its path
+func writeTestEditor(t *testing.T, script string) string {
+	path := filepath.Join(t.TempDir(), "editor.sh")
+	err := os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestComposeInput(t *testing.T) {
+	t.Run("PipedInput_ReturnsInput", func(t *testing.T) {
+		oldReader := InputReader
+		oldIsInteractive := IsInteractive
+		defer func() { InputReader = oldReader; IsInteractive = oldIsInteractive }()
+
+		IsInteractive = func() bool { return false }
+		InputReader = func() io.Reader {
+			return bytes.NewReader([]byte("test text\nmore text\n"))
+		}
+
+		text, err := ComposeInput("test", "")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "test text\nmore text", text)
+	})
+
+	t.Run("PipedInputEmpty_ReturnsError", func(t *testing.T) {
+		oldReader := InputReader
+		oldIsInteractive := IsInteractive
+		defer func() { InputReader = oldReader; IsInteractive = oldIsInteractive }()
+
+		IsInteractive = func() bool { return false }
+		InputReader = func() io.Reader {
+			return bytes.NewReader([]byte(" \n"))
+		}
+
+		_, err := ComposeInput("test", "")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "aborting due to empty test", err.Error())
+	})
+
+	t.Run("PipedInputWithTemplate_ReturnsError", func(t *testing.T) {
+		oldIsInteractive := IsInteractive
+		defer func() { IsInteractive = oldIsInteractive }()
+
+		IsInteractive = func() bool { return false }
+
+		_, err := ComposeInput("test", "template")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "template can't be used when test is piped to stdin", err.Error())
+	})
+
+	t.Run("Interactive_UsesEditor", func(t *testing.T) {
+		oldIsInteractive := IsInteractive
+		defer func() { IsInteractive = oldIsInteractive }()
+
+		IsInteractive = func() bool { return true }
+		t.Setenv("VISUAL", "")
+		t.Setenv("EDITOR", writeTestEditor(t, `printf "edited text\n" | cat - "$1" > "$1.tmp" && mv "$1.tmp" "$1"`))
+
+		text, err := ComposeInput("test", "template")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "edited text\ntemplate", text)
+	})
+
+	t.Run("InteractiveNoEditor_ReadsInput", func(t *testing.T) {
+		oldReader := InputReader
+		oldIsInteractive := IsInteractive
+		defer func() { InputReader = oldReader; IsInteractive = oldIsInteractive }()
+
+		IsInteractive = func() bool { return true }
+		InputReader = func() io.Reader {
+			return bytes.NewReader([]byte("test text\n.\n"))
+		}
+		t.Setenv("VISUAL", "")
+		t.Setenv("EDITOR", "")
+
+		text, err := ComposeInput("test", "")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "test text", text)
+	})
+
+	t.Run("InteractiveNoEditorWithTemplate_ReturnsError", func(t *testing.T) {
+		oldIsInteractive := IsInteractive
+		defer func() { IsInteractive = oldIsInteractive }()
+
+		IsInteractive = func() bool { return true }
+		t.Setenv("VISUAL", "")
+		t.Setenv("EDITOR", "")
+
+		_, err := ComposeInput("test", "template")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "template requires an editor, set $VISUAL or $EDITOR", err.Error())
+	})
+}
+
+func TestReadInputFromEditor(t *testing.T) {
+	t.Run("StripsCommentLines", func(t *testing.T) {
+		editor := writeTestEditor(t, `printf "# Heading\nline one\n" | cat - "$1" > "$1.tmp" && mv "$1.tmp" "$1"`)
+
+		text, err := ReadInputFromEditor(editor, "test", "# Template comment\ntemplate line\n  # indented\n")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "line one\ntemplate line\n  # indented", text)
+	})
+
+	t.Run("OnlyCommentLines_ReturnsError", func(t *testing.T) {
+		editor := writeTestEditor(t, "true")
+
+		_, err := ReadInputFromEditor(editor, "test", "# Template comment")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "aborting due to empty test", err.Error())
+	})
+
+	t.Run("EmptyContent_ReturnsError", func(t *testing.T) {
+		editor := writeTestEditor(t, "true")
+
+		_, err := ReadInputFromEditor(editor, "test", "")
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "aborting due to empty test", err.Error())
+	})
+
+	t.Run("EditorError_ReturnsError", func(t *testing.T) {
+		editor := writeTestEditor(t, "exit 1")
+
+		_, err := ReadInputFromEditor(editor, "test", "template")
+
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "error running editor")
+	})
+}
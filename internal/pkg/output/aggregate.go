@@ -0,0 +1,268 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// aggregateFunctionRegexp matches aggregate functions in format 'function(property)'
+var aggregateFunctionRegexp = regexp.MustCompile(`^(sum|avg|min|max)\(([^()]+)\)$`)
+
+// aggregateFunction is a parsed aggregate, with name being the output column name, e.g. 'sum_ram_mb'
+type aggregateFunction struct {
+	name     string
+	function string
+	property string
+}
+
+// aggregateRow is a single row of aggregated output, marshalled as an object with ordered keys and numeric
+// aggregate values
+type aggregateRow struct {
+	fields     *OrderedFields
+	aggregates map[string]bool
+}
+
+func (r aggregateRow) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("{")
+	for i, key := range r.fields.Keys() {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+
+		keyJSON, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		valueJSON, err := json.Marshal(r.value(key))
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(keyJSON)
+		buf.WriteString(":")
+		buf.Write(valueJSON)
+	}
+	buf.WriteString("}")
+
+	return buf.Bytes(), nil
+}
+
+func (r aggregateRow) MarshalYAML() (any, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, key := range r.fields.Keys() {
+		valueNode := &yaml.Node{}
+		err := valueNode.Encode(r.value(key))
+		if err != nil {
+			return nil, err
+		}
+
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, valueNode)
+	}
+
+	return node, nil
+}
+
+// value returns the value of key, as a number for numeric aggregate values
+func (r aggregateRow) value(key string) any {
+	value := r.fields.Get(key)
+	if r.aggregates[key] {
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			return number
+		}
+	}
+
+	return value
+}
+
+type aggregateRows []aggregateRow
+
+func (r aggregateRows) Fields() []*OrderedFields {
+	fields := make([]*OrderedFields, len(r))
+	for i, row := range r {
+		fields[i] = row.fields
+	}
+
+	return fields
+}
+
+// isAggregated returns true where either of the 'group-by' or 'aggregate' flags are set
+func isAggregated(cmd *cobra.Command) bool {
+	for _, flag := range []string{"group-by", "aggregate"} {
+		if cmd.Flags().Lookup(flag) != nil && cmd.Flags().Changed(flag) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseAggregateFunctions parses the 'aggregate' flag values, in format 'count' or 'function(property)'
+func parseAggregateFunctions(values []string) ([]aggregateFunction, error) {
+	var functions []aggregateFunction
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "count" {
+			functions = append(functions, aggregateFunction{name: value, function: value})
+			continue
+		}
+
+		matches := aggregateFunctionRegexp.FindStringSubmatch(value)
+		if matches == nil {
+			return nil, fmt.Errorf("invalid aggregate [%s], expected count, sum(property), avg(property), min(property) or max(property)", value)
+		}
+
+		property := strings.TrimSpace(matches[2])
+		functions = append(functions, aggregateFunction{name: matches[1] + "_" + property, function: matches[1], property: property})
+	}
+
+	return functions, nil
+}
+
+// applyAggregation groups the rows of d by the properties specified by the 'group-by' flag, and calculates
+// the aggregates specified by the 'aggregate' flag for each group. Where no aggregates are specified, rows are
+// counted
+func (o *OutputHandler) applyAggregation(cmd *cobra.Command, d any) (any, error) {
+	if !isAggregated(cmd) {
+		return d, nil
+	}
+
+	var groupBy []string
+	if cmd.Flags().Lookup("group-by") != nil {
+		groupBy, _ = cmd.Flags().GetStringSlice("group-by")
+	}
+
+	var aggregateValues []string
+	if cmd.Flags().Lookup("aggregate") != nil {
+		aggregateValues, _ = cmd.Flags().GetStringSlice("aggregate")
+	}
+	if len(aggregateValues) == 0 {
+		aggregateValues = []string{"count"}
+	}
+
+	functions, err := parseAggregateFunctions(aggregateValues)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := o.convert(d, reflect.ValueOf(d))
+	columns := make(map[string]bool)
+	for _, column := range getColumns(rows) {
+		columns[column] = true
+	}
+
+	for i, property := range groupBy {
+		groupBy[i] = strings.ToLower(strings.TrimSpace(property))
+		if len(rows) > 0 && !columns[groupBy[i]] {
+			return nil, fmt.Errorf("unknown group-by property [%s]", property)
+		}
+	}
+	for _, function := range functions {
+		if len(rows) > 0 && len(function.property) > 0 && !columns[function.property] {
+			return nil, fmt.Errorf("unknown property [%s] for aggregate [%s(%s)]", function.property, function.function, function.property)
+		}
+	}
+
+	// Group rows by their group-by property values, maintaining the order in which groups first appear
+	var groupKeys []string
+	groups := make(map[string][]*OrderedFields)
+	for _, row := range rows {
+		values := make([]string, len(groupBy))
+		for i, property := range groupBy {
+			values[i] = row.Get(property)
+		}
+
+		key := strings.Join(values, "\x00")
+		if _, exists := groups[key]; !exists {
+			groupKeys = append(groupKeys, key)
+		}
+		groups[key] = append(groups[key], row)
+	}
+
+	if len(groupBy) == 0 && len(groupKeys) == 0 {
+		groupKeys = append(groupKeys, "")
+	}
+
+	result := aggregateRows{}
+	for _, key := range groupKeys {
+		groupRows := groups[key]
+
+		fields := NewOrderedFields()
+		aggregates := make(map[string]bool)
+		for _, property := range groupBy {
+			fields.Set(property, groupRows[0].Get(property))
+		}
+
+		for _, function := range functions {
+			value, err := aggregate(function, groupRows)
+			if err != nil {
+				return nil, err
+			}
+
+			fields.Set(function.name, value)
+			aggregates[function.name] = true
+		}
+
+		result = append(result, aggregateRow{fields: fields, aggregates: aggregates})
+	}
+
+	return result, nil
+}
+
+// aggregate calculates function for rows. Null (empty) values are ignored by all functions other than count
+func aggregate(function aggregateFunction, rows []*OrderedFields) (string, error) {
+	if function.function == "count" {
+		return strconv.Itoa(len(rows)), nil
+	}
+
+	var values []string
+	for _, row := range rows {
+		value := row.Get(function.property)
+		if value != "" && value != "<nil>" {
+			values = append(values, value)
+		}
+	}
+
+	switch function.function {
+	case "min", "max":
+		if len(values) == 0 {
+			return "", nil
+		}
+
+		result := values[0]
+		for _, value := range values[1:] {
+			cmp := compareWhereValues(value, result)
+			if (function.function == "min" && cmp < 0) || (function.function == "max" && cmp > 0) {
+				result = value
+			}
+		}
+
+		return result, nil
+	}
+
+	var sum float64
+	for _, value := range values {
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", fmt.Errorf("cannot calculate %s of non-numeric value [%s] for property [%s]", function.function, value, function.property)
+		}
+		sum += number
+	}
+
+	if function.function == "avg" {
+		if len(values) == 0 {
+			return "", nil
+		}
+		sum = sum / float64(len(values))
+	}
+
+	return strconv.FormatFloat(sum, 'f', -1, 64), nil
+}
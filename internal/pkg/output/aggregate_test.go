@@ -0,0 +1,164 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/ans-group/cli/test"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+type testInstance struct {
+	Name      string `json:"name"`
+	VPCID     string `json:"vpc_id"`
+	RAMMB     int    `json:"ram_mb"`
+	CreatedAt string `json:"created_at"`
+}
+
+var testInstances = []testInstance{
+	{"web-01", "vpc-a", 2048, "2024-03-01T10:00:00+00:00"},
+	{"db-01", "vpc-b", 8192, "2024-01-15T10:00:00+00:00"},
+	{"web-02", "vpc-a", 4096, "2024-02-01T10:00:00+00:00"},
+}
+
+func newTestAggregateCommand(args ...string) *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("output", "", "")
+	cmd.Flags().StringSlice("property", nil, "")
+	cmd.Flags().StringSlice("group-by", nil, "")
+	cmd.Flags().StringSlice("aggregate", nil, "")
+	cmd.ParseFlags(args)
+
+	return cmd
+}
+
+func TestOutputHandler_Aggregate(t *testing.T) {
+	t.Run("GroupByWithAggregates_CSV", func(t *testing.T) {
+		cmd := newTestAggregateCommand("--output", "csv", "--group-by", "vpc_id", "--aggregate", "count,sum(ram_mb),avg(ram_mb),max(created_at)")
+
+		output := test.CatchStdOut(t, func() {
+			err := NewOutputHandler().Output(cmd, testInstances)
+			assert.NoError(t, err)
+		})
+
+		assert.Equal(t, "vpc_id,count,sum_ram_mb,avg_ram_mb,max_created_at\nvpc-a,2,6144,3072,2024-03-01T10:00:00+00:00\nvpc-b,1,8192,8192,2024-01-15T10:00:00+00:00\n", output)
+	})
+
+	t.Run("MinMax_SDKDateTimes_ComparedChronologically", func(t *testing.T) {
+		cmd := newTestAggregateCommand("--output", "value", "--aggregate", "min(created_at),max(created_at)")
+		instances := []testInstance{
+			{Name: "web-01", CreatedAt: "2024-03-01T10:00:00+0200"},
+			{Name: "web-02", CreatedAt: "2024-03-01T09:00:00+0000"},
+			{Name: "web-03", CreatedAt: "2024-03-01T08:30:00+0000"},
+		}
+
+		output := test.CatchStdOut(t, func() {
+			err := NewOutputHandler().Output(cmd, instances)
+			assert.NoError(t, err)
+		})
+
+		assert.Equal(t, "2024-03-01T10:00:00+0200 2024-03-01T09:00:00+0000\n", output)
+	})
+
+	t.Run("GroupByWithoutAggregate_Counts", func(t *testing.T) {
+		cmd := newTestAggregateCommand("--output", "value", "--group-by", "vpc_id")
+
+		output := test.CatchStdOut(t, func() {
+			err := NewOutputHandler().Output(cmd, testInstances)
+			assert.NoError(t, err)
+		})
+
+		assert.Equal(t, "vpc-a 2\nvpc-b 1\n", output)
+	})
+
+	t.Run("AggregateWithoutGroupBy_Totals", func(t *testing.T) {
+		cmd := newTestAggregateCommand("--output", "value", "--aggregate", "count,min(ram_mb)")
+
+		output := test.CatchStdOut(t, func() {
+			err := NewOutputHandler().Output(cmd, testInstances)
+			assert.NoError(t, err)
+		})
+
+		assert.Equal(t, "3 2048\n", output)
+	})
+
+	t.Run("NoRows_CountsZero", func(t *testing.T) {
+		cmd := newTestAggregateCommand("--output", "value", "--aggregate", "count")
+
+		output := test.CatchStdOut(t, func() {
+			err := NewOutputHandler().Output(cmd, []testInstance{})
+			assert.NoError(t, err)
+		})
+
+		assert.Equal(t, "0\n", output)
+	})
+
+	t.Run("PropertyAlias_RenamesAggregateHeader", func(t *testing.T) {
+		cmd := newTestAggregateCommand("--output", "csv", "--group-by", "vpc_id", "--aggregate", "sum(ram_mb)", "--property", "vpc_id,sum_ram_mb:total_ram_mb")
+
+		output := test.CatchStdOut(t, func() {
+			err := NewOutputHandler().Output(cmd, testInstances)
+			assert.NoError(t, err)
+		})
+
+		assert.Equal(t, "vpc_id,total_ram_mb\nvpc-a,6144\nvpc-b,8192\n", output)
+	})
+
+	t.Run("JSON_NumericAggregates", func(t *testing.T) {
+		cmd := newTestAggregateCommand("--output", "json", "--group-by", "vpc_id", "--aggregate", "count,sum(ram_mb)")
+
+		output := test.CatchStdOut(t, func() {
+			err := NewOutputHandler().Output(cmd, testInstances)
+			assert.NoError(t, err)
+		})
+
+		assert.Equal(t, `[{"vpc_id":"vpc-a","count":2,"sum_ram_mb":6144},{"vpc_id":"vpc-b","count":1,"sum_ram_mb":8192}]`, output)
+	})
+
+	t.Run("YAML_OrderedKeys", func(t *testing.T) {
+		cmd := newTestAggregateCommand("--output", "yaml", "--group-by", "vpc_id")
+
+		output := test.CatchStdOut(t, func() {
+			err := NewOutputHandler().Output(cmd, testInstances)
+			assert.NoError(t, err)
+		})
+
+		assert.Equal(t, "- vpc_id: vpc-a\n  count: 2\n- vpc_id: vpc-b\n  count: 1\n", output)
+	})
+
+	t.Run("UnknownGroupByProperty_ReturnsError", func(t *testing.T) {
+		cmd := newTestAggregateCommand("--group-by", "invalid")
+
+		err := NewOutputHandler().Output(cmd, testInstances)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "unknown group-by property [invalid]", err.Error())
+	})
+
+	t.Run("UnknownAggregateProperty_ReturnsError", func(t *testing.T) {
+		cmd := newTestAggregateCommand("--aggregate", "sum(invalid)")
+
+		err := NewOutputHandler().Output(cmd, testInstances)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "unknown property [invalid] for aggregate [sum(invalid)]", err.Error())
+	})
+
+	t.Run("InvalidAggregate_ReturnsError", func(t *testing.T) {
+		cmd := newTestAggregateCommand("--aggregate", "median(ram_mb)")
+
+		err := NewOutputHandler().Output(cmd, testInstances)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "invalid aggregate [median(ram_mb)], expected count, sum(property), avg(property), min(property) or max(property)", err.Error())
+	})
+
+	t.Run("NonNumericSum_ReturnsError", func(t *testing.T) {
+		cmd := newTestAggregateCommand("--aggregate", "sum(name)")
+
+		err := NewOutputHandler().Output(cmd, testInstances)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "cannot calculate sum of non-numeric value [web-01] for property [name]", err.Error())
+	})
+}
@@ -112,6 +112,79 @@ func CommandOutputPaginated[T any](cmd *cobra.Command, d any, paginated *connect
 	return nil
 }
 
+// CommandOutputStream retrieves items with getFunc and outputs them as collection C. Where the 'page' flag is
+// set, only the requested page is retrieved. Otherwise all pages are retrieved, with each page output as it
+// arrives for formats which support streaming (csv, tsv, value and ndjson), and other formats (or aggregated and
+// watched output) output once all pages have been retrieved. Local filters and the where expression are applied
+// to each page as it arrives, and retrieval stops once the number of items specified by the 'limit' flag have
+// been output
+func CommandOutputStream[C ~[]T, T any](cmd *cobra.Command, params connection.APIRequestParameters, getFunc connection.PaginatedGetFunc[T]) error {
+	if params.Pagination.Page > 0 {
+		paginated, err := getFunc(params)
+		if err != nil {
+			return err
+		}
+
+		return CommandOutputPaginated(cmd, C(paginated.Items()), paginated)
+	}
+
+	handler := NewOutputHandler()
+	format, _ := handler.getFormat(cmd)
+	stream := (format == "csv" || format == "tsv" || format == "value" || format == "ndjson") && !isAggregated(cmd) && activeWatcher == nil
+	limit := getLimit(cmd)
+
+	_, err := getWhereExpression(cmd)
+	if err != nil {
+		return err
+	}
+
+	items := C{}
+	params.Pagination.Page = 1
+	paginated, err := getFunc(params)
+	if err != nil {
+		return err
+	}
+
+	for {
+		filtered, err := handler.applyLocalFilter(cmd, C(paginated.Items()))
+		if err != nil {
+			return err
+		}
+
+		pageItems := filtered.(C)
+		if limit > 0 && len(items)+len(pageItems) > limit {
+			pageItems = pageItems[:limit-len(items)]
+		}
+
+		if stream && len(pageItems) > 0 {
+			err = handler.outputStreamPage(cmd, format, pageItems, len(items) == 0)
+			if err != nil {
+				return err
+			}
+		}
+		items = append(items, pageItems...)
+
+		if limit > 0 && len(items) >= limit {
+			break
+		}
+
+		next, err := paginated.Next()
+		if err != nil {
+			return err
+		}
+		if next == nil {
+			break
+		}
+		paginated = next
+	}
+
+	if stream {
+		return nil
+	}
+
+	return handler.outputFiltered(cmd, items)
+}
+
 func CommandOutput(cmd *cobra.Command, d any, opts ...OutputHandlerOption) error {
 	return NewOutputHandler(opts...).Output(cmd, d)
 }
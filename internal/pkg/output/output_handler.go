@@ -8,8 +8,10 @@ import (
 	"html/template"
 	"os"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	texttemplate "text/template"
 
 	"github.com/ans-group/cli/internal/pkg/helper"
 	"github.com/ans-group/sdk-go/pkg/config"
@@ -46,6 +48,12 @@ type OutputHandlerOpts map[string]any
 
 type OutputHandler struct {
 	additionalColumns []string
+
+	// streamColumns and streamHeaders are the columns of the first page of streamed output, used for all
+	// subsequent pages so that rows remain consistent with the header row
+	streamColumns    []string
+	streamHeaders    []string
+	streamColumnsSet bool
 }
 
 func NewOutputHandler(opts ...OutputHandlerOption) *OutputHandler {
@@ -57,41 +65,50 @@ func NewOutputHandler(opts ...OutputHandlerOption) *OutputHandler {
 }
 
 func (o *OutputHandler) Output(cmd *cobra.Command, d any) error {
-	var flag string
-
-	if cmd.Flags().Changed("output") {
-		flag, _ = cmd.Flags().GetString("output")
+	d, err := o.applyLocalFilter(cmd, d)
+	if err != nil {
+		return err
 	}
 
-	if len(flag) == 0 {
-		flag = "table"
-		outputDefault := config.GetString("output.default")
-		if len(outputDefault) > 0 {
-			flag = outputDefault
-		}
-	}
+	return o.outputFiltered(cmd, o.applyLimit(cmd, d))
+}
 
-	format, arg := ParseOutputFlag(flag)
+// outputFiltered outputs d, which has already had local filters and the 'limit' flag applied
+func (o *OutputHandler) outputFiltered(cmd *cobra.Command, d any) error {
+	format, arg := o.getFormat(cmd)
+
+	d, err := o.applyAggregation(cmd, d)
+	if err != nil {
+		return err
+	}
 
-	d = o.applyLocalFilter(cmd, d)
+	if activeWatcher != nil {
+		d = activeWatcher.observe(o, d)
+	}
 
 	switch format {
 	case "json":
 		return o.JSON(d, false)
 	case "json-pretty":
 		return o.JSON(d, true)
+	case "ndjson":
+		return o.NDJSON(d)
 	case "list":
 		return o.List(cmd, d)
 	case "value":
 		return o.Value(cmd, d)
 	case "csv":
 		return o.CSV(cmd, d)
+	case "tsv":
+		return o.TSV(cmd, d)
 	case "yaml":
 		return o.YAML(d)
 	case "jsonpath":
 		return o.JSONPath(arg, d)
 	case "template":
 		return o.Template(arg, d)
+	case "gotemplate":
+		return o.GoTemplate(cmd, arg, d)
 	default:
 		Errorf("invalid output format [%s], defaulting to 'table'", format)
 		fallthrough
@@ -100,6 +117,43 @@ func (o *OutputHandler) Output(cmd *cobra.Command, d any) error {
 	}
 }
 
+// getFormat returns the output format and optional argument from the 'output' flag, falling back to
+// 'gotemplate' where flag 'template-file' is set, then to the configured default format
+func (o *OutputHandler) getFormat(cmd *cobra.Command) (string, string) {
+	format, arg := GetOutputFormat(cmd)
+	if len(format) == 0 {
+		format = "table"
+	}
+
+	return format, arg
+}
+
+// GetOutputFormat returns the output format and argument for cmd, resolved from the output flag, the
+// template-file flag and the configured default output format in turn. An empty format is returned where
+// none of these are set, allowing commands to provide their own default output
+func GetOutputFormat(cmd *cobra.Command) (string, string) {
+	var flag string
+
+	if cmd.Flags().Changed("output") {
+		flag, _ = cmd.Flags().GetString("output")
+	}
+
+	if len(flag) == 0 && cmd.Flags().Lookup("template-file") != nil && cmd.Flags().Changed("template-file") {
+		flag = "gotemplate"
+	}
+
+	if len(flag) == 0 {
+		flag = config.GetString("output.default")
+	}
+
+	format, arg := ParseOutputFlag(flag)
+	if format == "jsonl" {
+		format = "ndjson"
+	}
+
+	return format, arg
+}
+
 func (o *OutputHandler) JSON(d any, pretty bool) error {
 	var out []byte
 	var err error
@@ -117,6 +171,54 @@ func (o *OutputHandler) JSON(d any, pretty bool) error {
 	return err
 }
 
+// outputStreamPage outputs a single page of d with streaming format, with the CSV header row written only
+// for the first page. Columns are determined from the first page, and used for all subsequent pages
+func (o *OutputHandler) outputStreamPage(cmd *cobra.Command, format string, d any, first bool) error {
+	if first {
+		o.streamColumns, o.streamHeaders = o.getDataColumns(cmd, d, o.convert(d, reflect.ValueOf(d)))
+		o.streamColumnsSet = true
+	}
+
+	switch format {
+	case "csv":
+		return o.writeCSV(cmd, d, first)
+	case "tsv":
+		return o.writeTSV(cmd, d, first)
+	case "value":
+		return o.Value(cmd, d)
+	default:
+		return o.NDJSON(d)
+	}
+}
+
+// NDJSON outputs d as newline-delimited JSON, with each item of a slice marshalled on its own line
+func (o *OutputHandler) NDJSON(d any) error {
+	reflectedValue := reflect.ValueOf(d)
+	if reflectedValue.Kind() != reflect.Slice {
+		return o.writeNDJSONLine(d)
+	}
+
+	for i := 0; i < reflectedValue.Len(); i++ {
+		err := o.writeNDJSONLine(reflectedValue.Index(i).Interface())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *OutputHandler) writeNDJSONLine(d any) error {
+	out, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("failed to marshal json: %s", err)
+	}
+
+	_, err = fmt.Println(string(out))
+
+	return err
+}
+
 // Table takes an array of mapped fields (key being lowercased name), and outputs a table
 func (o *OutputHandler) Table(cmd *cobra.Command, d any) error {
 	columns, rows := o.getData(cmd, d)
@@ -139,7 +241,10 @@ func (o *OutputHandler) Table(cmd *cobra.Command, d any) error {
 		colMaxWidth = 50
 	}
 
-	table := tablewriter.NewTable(os.Stdout,
+	w, closePager := pagerWriter(cmd)
+	defer closePager()
+
+	table := tablewriter.NewTable(w,
 		tablewriter.WithHeaderAlignment(tw.AlignCenter),
 		tablewriter.WithRowAlignment(tw.AlignLeft),
 		tablewriter.WithRenderer(renderer.NewBlueprint(tw.Rendition{Symbols: symbols})),
@@ -237,6 +342,11 @@ func (o *OutputHandler) JSONPath(query string, d any) error {
 
 // CSV outputs provided rows as CSV to stdout
 func (o *OutputHandler) CSV(cmd *cobra.Command, d any) error {
+	return o.writeCSV(cmd, d, true)
+}
+
+// writeCSV outputs provided rows as CSV to stdout, with the header row written only where header is true
+func (o *OutputHandler) writeCSV(cmd *cobra.Command, d any, header bool) error {
 	columns, rows := o.getData(cmd, d)
 	if len(rows) < 1 {
 		return nil
@@ -245,9 +355,11 @@ func (o *OutputHandler) CSV(cmd *cobra.Command, d any) error {
 	w := csv.NewWriter(os.Stdout)
 
 	// First retrieve properties and write to CSV buffer
-	err := w.Write(columns)
-	if err != nil {
-		return err
+	if header {
+		err := w.Write(columns)
+		if err != nil {
+			return err
+		}
 	}
 
 	for _, row := range rows {
@@ -272,6 +384,52 @@ func (o *OutputHandler) CSV(cmd *cobra.Command, d any) error {
 	return nil
 }
 
+// tsvReplacer escapes characters which can't appear within TSV values
+var tsvReplacer = strings.NewReplacer("\\", "\\\\", "\t", "\\t", "\n", "\\n", "\r", "\\r")
+
+// TSV outputs provided rows as tab-separated values to stdout. Backslashes, tabs and newlines within
+// values are escaped as \\, \t and \n respectively
+func (o *OutputHandler) TSV(cmd *cobra.Command, d any) error {
+	return o.writeTSV(cmd, d, true)
+}
+
+// writeTSV outputs provided rows as TSV to stdout, with the header row written only where header is true
+func (o *OutputHandler) writeTSV(cmd *cobra.Command, d any, header bool) error {
+	columns, rows := o.getData(cmd, d)
+	if len(rows) < 1 {
+		return nil
+	}
+
+	w := bufio.NewWriter(os.Stdout)
+	defer func() { _ = w.Flush() }()
+
+	writeRow := func(values []string) error {
+		escaped := make([]string, len(values))
+		for i, value := range values {
+			escaped[i] = tsvReplacer.Replace(value)
+		}
+
+		_, err := fmt.Fprintln(w, strings.Join(escaped, "\t"))
+		return err
+	}
+
+	if header {
+		err := writeRow(columns)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, row := range rows {
+		err := writeRow(row)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Template will format i with given Golang template t, and output resulting string
 // to stdout
 func (o *OutputHandler) Template(t string, d any) error {
@@ -301,12 +459,63 @@ func (o *OutputHandler) Template(t string, d any) error {
 	return nil
 }
 
-func (o *OutputHandler) getData(cmd *cobra.Command, d any) (filteredColumns []string, filteredRows [][]string) {
+// GoTemplate formats d with given text template t, or the template read from the path specified
+// by flag 'template-file', and outputs the resulting string to stdout. The template is executed
+// once with d, and has access to the functions from TemplateFuncs
+func (o *OutputHandler) GoTemplate(cmd *cobra.Command, t string, d any) error {
+	if cmd.Flags().Lookup("template-file") != nil && cmd.Flags().Changed("template-file") {
+		templateFile, _ := cmd.Flags().GetString("template-file")
+		content, err := os.ReadFile(templateFile)
+		if err != nil {
+			return fmt.Errorf("failed to read template file: %s", err)
+		}
+		t = string(content)
+	}
+
+	if len(t) < 1 {
+		return fmt.Errorf("missing template, provide with '--output gotemplate=<template>' or '--template-file'")
+	}
+
+	tmpl, err := texttemplate.New("output").Funcs(TemplateFuncs()).Parse(t)
+	if err != nil {
+		return fmt.Errorf("failed to create template: %s", err)
+	}
+
+	err = tmpl.Execute(os.Stdout, d)
+	if err != nil {
+		return fmt.Errorf("failed to execute template: %s", err)
+	}
+
+	return nil
+}
+
+// getData converts d into rows, returning the headers and values of the columns specified by the 'property'
+// flag (or default columns). Properties may be provided in format 'name:alias' to rename the column header
+func (o *OutputHandler) getData(cmd *cobra.Command, d any) (headers []string, filteredRows [][]string) {
 	rows := o.convert(d, reflect.ValueOf(d))
 	if len(rows) == 0 {
 		return
 	}
 
+	filteredColumns, headers := o.streamColumns, o.streamHeaders
+	if !o.streamColumnsSet {
+		filteredColumns, headers = o.getDataColumns(cmd, d, rows)
+	}
+
+	for _, row := range rows {
+		filteredRow := make([]string, len(filteredColumns))
+		for filteredColumnIndex, filteredColumn := range filteredColumns {
+			filteredRow[filteredColumnIndex] = row.Get(filteredColumn)
+		}
+		filteredRows = append(filteredRows, filteredRow)
+	}
+
+	return headers, filteredRows
+}
+
+// getDataColumns returns the columns of rows specified by the 'property' flag (or default columns), along
+// with their headers
+func (o *OutputHandler) getDataColumns(cmd *cobra.Command, d any, rows []*OrderedFields) (filteredColumns []string, headers []string) {
 	var filteredColumnNames []string
 	if cmd.Flags().Changed("property") {
 		filteredColumnNames, _ = cmd.Flags().GetStringSlice("property")
@@ -320,26 +529,41 @@ func (o *OutputHandler) getData(cmd *cobra.Command, d any) (filteredColumns []st
 	}
 
 	if len(filteredColumnNames) > 0 {
-		for _, column := range rows[0].Keys() {
+		for _, column := range getColumns(rows) {
 			for _, filteredColumnName := range filteredColumnNames {
-				if glob.Glob(strings.ToLower(filteredColumnName), column) {
+				pattern, alias, _ := strings.Cut(filteredColumnName, ":")
+				if glob.Glob(strings.ToLower(pattern), column) {
 					filteredColumns = append(filteredColumns, column)
+					if len(alias) == 0 {
+						alias = column
+					}
+					headers = append(headers, alias)
 				}
 			}
 		}
 	} else {
-		filteredColumns = rows[0].Keys()
+		filteredColumns = getColumns(rows)
+		headers = filteredColumns
 	}
 
+	return filteredColumns, headers
+}
+
+// getColumns returns the keys of all rows, in order of first appearance. Rows may have differing keys where
+// nested maps have been flattened
+func getColumns(rows []*OrderedFields) []string {
+	var columns []string
+	seen := make(map[string]bool)
 	for _, row := range rows {
-		filteredRow := make([]string, len(filteredColumns))
-		for filteredColumnIndex, filteredColumn := range filteredColumns {
-			filteredRow[filteredColumnIndex] = row.Get(filteredColumn)
+		for _, key := range row.Keys() {
+			if !seen[key] {
+				seen[key] = true
+				columns = append(columns, key)
+			}
 		}
-		filteredRows = append(filteredRows, filteredRow)
 	}
 
-	return filteredColumns, filteredRows
+	return columns
 }
 
 func (o *OutputHandler) convert(d any, reflectedValue reflect.Value) []*OrderedFields {
@@ -384,7 +608,10 @@ func (o *OutputHandler) convertField(d any, v *OrderedFields, fieldName string,
 			}
 			childFieldName := ""
 			if !reflectedValueTypeField.Anonymous {
-				jsonTag := reflectedValueTypeField.Tag.Get("json")
+				jsonTag, _, _ := strings.Cut(reflectedValueTypeField.Tag.Get("json"), ",")
+				if jsonTag == "-" {
+					continue
+				}
 				if jsonTag != "" {
 					childFieldName = jsonTag
 				} else {
@@ -400,6 +627,19 @@ func (o *OutputHandler) convertField(d any, v *OrderedFields, fieldName string,
 		}
 
 		return v
+	case reflect.Map:
+		if reflectedValue.Len() > 0 && len(fieldName) > 0 {
+			keys := reflectedValue.MapKeys()
+			sort.Slice(keys, func(i, j int) bool {
+				return fmt.Sprintf("%v", keys[i].Interface()) < fmt.Sprintf("%v", keys[j].Interface())
+			})
+
+			for _, key := range keys {
+				o.convertField(d, v, fmt.Sprintf("%s.%v", fieldName, key.Interface()), reflectedValue.MapIndex(key))
+			}
+
+			return v
+		}
 	case reflect.String:
 		v.Set(fieldName, reflectedValue.String())
 		return v
@@ -415,7 +655,7 @@ func (o *OutputHandler) convertField(d any, v *OrderedFields, fieldName string,
 	case reflect.Float32, reflect.Float64:
 		v.Set(fieldName, fmt.Sprintf("%f", reflectedValue.Float()))
 		return v
-	case reflect.Pointer:
+	case reflect.Pointer, reflect.Interface:
 		if !reflectedValue.IsNil() {
 			return o.convertField(d, v, fieldName, reflectedValue.Elem())
 		}
@@ -443,35 +683,83 @@ func getLocalFilters(cmd *cobra.Command) []connection.APIRequestFiltering {
 	return filters
 }
 
-// applyLocalFilter filters the original data using local filters.
-func (o *OutputHandler) applyLocalFilter(cmd *cobra.Command, d interface{}) interface{} {
-	filters := getLocalFilters(cmd)
-	if len(filters) == 0 {
+// getLimit returns the value of the 'limit' flag, or 0 where no limit is specified
+func getLimit(cmd *cobra.Command) int {
+	if cmd.Flags().Lookup("limit") == nil {
+		return 0
+	}
+
+	limit, _ := cmd.Flags().GetInt("limit")
+	return limit
+}
+
+// applyLimit truncates slice d to the number of items specified by the 'limit' flag
+func (o *OutputHandler) applyLimit(cmd *cobra.Command, d any) any {
+	limit := getLimit(cmd)
+	if limit <= 0 {
 		return d
 	}
 
+	reflectedValue := reflect.ValueOf(d)
+	if reflectedValue.Kind() != reflect.Slice || reflectedValue.Len() <= limit {
+		return d
+	}
+
+	return reflectedValue.Slice(0, limit).Interface()
+}
+
+// getWhereExpression parses the 'where' flag value into an expression, returning nil where not specified
+func getWhereExpression(cmd *cobra.Command) (whereNode, error) {
+	if cmd.Flags().Lookup("where") == nil || !cmd.Flags().Changed("where") {
+		return nil, nil
+	}
+
+	flagValue, _ := cmd.Flags().GetString("where")
+	expression, err := parseWhereExpression(flagValue)
+	if err != nil {
+		return nil, fmt.Errorf("invalid where expression: %s", err)
+	}
+
+	return expression, nil
+}
+
+// applyLocalFilter filters the original data using local filters and the where expression.
+func (o *OutputHandler) applyLocalFilter(cmd *cobra.Command, d interface{}) (interface{}, error) {
+	filters := getLocalFilters(cmd)
+	where, err := getWhereExpression(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if len(filters) == 0 && where == nil {
+		return d, nil
+	}
+
+	matches := func(row *OrderedFields) bool {
+		return matchesFilters(row, filters) && (where == nil || where.evaluate(row))
+	}
+
 	rows := o.convert(d, reflect.ValueOf(d))
 	if len(rows) == 0 {
-		return d
+		return d, nil
 	}
 
 	reflectedValue := reflect.ValueOf(d)
 	if reflectedValue.Kind() != reflect.Slice {
 		// For non-slice data, return as-is if it matches, otherwise return nil
-		if len(rows) == 1 && matchesFilters(rows[0], filters) {
-			return d
+		if len(rows) == 1 && matches(rows[0]) {
+			return d, nil
 		}
-		return reflect.MakeSlice(reflect.SliceOf(reflectedValue.Type()), 0, 0).Interface()
+		return reflect.MakeSlice(reflect.SliceOf(reflectedValue.Type()), 0, 0).Interface(), nil
 	}
 
 	// Build a filtered slice keeping only items whose rows match
 	resultSlice := reflect.MakeSlice(reflectedValue.Type(), 0, reflectedValue.Len())
 	for i := 0; i < reflectedValue.Len(); i++ {
-		if i < len(rows) && matchesFilters(rows[i], filters) {
+		if i < len(rows) && matches(rows[i]) {
 			resultSlice = reflect.Append(resultSlice, reflectedValue.Index(i))
 		}
 	}
-	return resultSlice.Interface()
+	return resultSlice.Interface(), nil
 }
 
 func formatListPropertyValue(property string, value string, maxPropertyLength int) string {
@@ -1,6 +1,8 @@
 package output
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/ans-group/cli/test"
@@ -196,6 +198,84 @@ func TestOutputHandler_Template(t *testing.T) {
 	assert.Equal(t, "Row1TestValue1\n", output)
 }
 
+func TestOutputHandler_GoTemplate(t *testing.T) {
+	t.Run("InlineTemplate_ExecutesOnceWithoutEscaping", func(t *testing.T) {
+		o := NewOutputHandler()
+
+		output := test.CatchStdOut(t, func() {
+			err := o.GoTemplate(&cobra.Command{}, "{{range .}}{{upper .TestProperty1}} & {{.TestProperty2}}\n{{end}}", collectionMultipleRows)
+			assert.NoError(t, err)
+		})
+
+		assert.Equal(t, "ROW1TESTVALUE1 & Row1TestValue2\nROW2TESTVALUE1 & Row2TestValue2\n", output)
+	})
+
+	t.Run("TemplateFile_ReadsTemplateFromFile", func(t *testing.T) {
+		o := NewOutputHandler()
+		path := filepath.Join(t.TempDir(), "output.tmpl")
+		os.WriteFile(path, []byte("{{len .}} rows"), 0644)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("template-file", "", "")
+		cmd.ParseFlags([]string{"--template-file", path})
+
+		output := test.CatchStdOut(t, func() {
+			err := o.GoTemplate(cmd, "", collectionMultipleRows)
+			assert.NoError(t, err)
+		})
+
+		assert.Equal(t, "2 rows", output)
+	})
+
+	t.Run("TemplateFileFlag_ImpliesGoTemplateOutput", func(t *testing.T) {
+		o := NewOutputHandler()
+		path := filepath.Join(t.TempDir(), "output.tmpl")
+		os.WriteFile(path, []byte("{{(index . 0).TestProperty3}}"), 0644)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("output", "", "")
+		cmd.Flags().String("template-file", "", "")
+		cmd.ParseFlags([]string{"--template-file", path})
+
+		output := test.CatchStdOut(t, func() {
+			err := o.Output(cmd, collectionSingleRow)
+			assert.NoError(t, err)
+		})
+
+		assert.Equal(t, "Row1TestValue3", output)
+	})
+
+	t.Run("MissingTemplateFile_ReturnsError", func(t *testing.T) {
+		o := NewOutputHandler()
+		cmd := &cobra.Command{}
+		cmd.Flags().String("template-file", "", "")
+		cmd.ParseFlags([]string{"--template-file", filepath.Join(t.TempDir(), "missing.tmpl")})
+
+		err := o.GoTemplate(cmd, "", collectionSingleRow)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read template file")
+	})
+
+	t.Run("MissingTemplate_ReturnsError", func(t *testing.T) {
+		o := NewOutputHandler()
+
+		err := o.GoTemplate(&cobra.Command{}, "", collectionSingleRow)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "missing template")
+	})
+
+	t.Run("InvalidTemplate_ReturnsError", func(t *testing.T) {
+		o := NewOutputHandler()
+
+		err := o.GoTemplate(&cobra.Command{}, "{{", collectionSingleRow)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to create template")
+	})
+}
+
 func TestOutputHandler_WithAdditionalColumns(t *testing.T) {
 	t.Run("NewOutputHandler_WithAdditionalColumns", func(t *testing.T) {
 		o := NewOutputHandler(WithAdditionalColumns("testproperty1", "testproperty2"))
@@ -448,3 +528,215 @@ func TestOutputHandler_LocalFilter_YAML(t *testing.T) {
 	assert.Contains(t, output, "Row1TestValue1")
 	assert.NotContains(t, output, "Row2TestValue1")
 }
+
+func TestOutputHandler_NDJSON(t *testing.T) {
+	t.Run("Slice_OutputsItemPerLine", func(t *testing.T) {
+		o := NewOutputHandler()
+
+		output := test.CatchStdOut(t, func() {
+			err := o.NDJSON(collectionMultipleRows)
+			assert.NoError(t, err)
+		})
+
+		assert.Equal(t, "{\"testproperty1\":\"Row1TestValue1\",\"testproperty2\":\"Row1TestValue2\",\"testproperty3\":\"Row1TestValue3\"}\n{\"testproperty1\":\"Row2TestValue1\",\"testproperty2\":\"Row2TestValue2\",\"testproperty3\":\"Row2TestValue3\"}\n", output)
+	})
+
+	t.Run("Struct_OutputsSingleLine", func(t *testing.T) {
+		o := NewOutputHandler()
+
+		output := test.CatchStdOut(t, func() {
+			err := o.NDJSON(testModel{"Value1", "Value2", "Value3"})
+			assert.NoError(t, err)
+		})
+
+		assert.Equal(t, "{\"testproperty1\":\"Value1\",\"testproperty2\":\"Value2\",\"testproperty3\":\"Value3\"}\n", output)
+	})
+
+	t.Run("JSONLAlias_OutputsNDJSON", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("output", "", "")
+		cmd.Flags().Set("output", "jsonl")
+
+		output := test.CatchStdOut(t, func() {
+			err := NewOutputHandler().Output(cmd, collectionSingleRow)
+			assert.NoError(t, err)
+		})
+
+		assert.Equal(t, "{\"testproperty1\":\"Row1TestValue1\",\"testproperty2\":\"Row1TestValue2\",\"testproperty3\":\"Row1TestValue3\"}\n", output)
+	})
+}
+
+func TestOutputHandler_Limit(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("output", "", "")
+	cmd.Flags().Int("limit", 0, "")
+	cmd.Flags().Set("output", "value")
+	cmd.Flags().Set("limit", "1")
+
+	output := test.CatchStdOut(t, func() {
+		err := NewOutputHandler().Output(cmd, collectionMultipleRows)
+		assert.NoError(t, err)
+	})
+
+	assert.Equal(t, "Row1TestValue1 Row1TestValue2 Row1TestValue3\n", output)
+}
+
+// writeLESSPagerScript writes a pager script which outputs the value of LESS, returning its path
+func writeLESSPagerScript(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "pager.sh")
+	err := os.WriteFile(path, []byte("echo \"LESS=$LESS\"\ncat >/dev/null\n"), 0600)
+	assert.NoError(t, err)
+
+	return path
+}
+
+func TestOutputHandler_Table_Pager(t *testing.T) {
+	t.Run("Terminal_PipesThroughPager", func(t *testing.T) {
+		t.Setenv("PAGER", "sed s/^/paged:/")
+		oldStdoutIsTerminal := stdoutIsTerminal
+		stdoutIsTerminal = func() bool { return true }
+		defer func() { stdoutIsTerminal = oldStdoutIsTerminal }()
+
+		output := test.CatchStdOut(t, func() {
+			err := NewOutputHandler().Table(&cobra.Command{}, collectionSingleRow)
+			assert.NoError(t, err)
+		})
+
+		assert.Equal(t, "paged:+----------------+----------------+----------------+\npaged:| TESTPROPERTY 1 | TESTPROPERTY 2 | TESTPROPERTY 3 |\npaged:+----------------+----------------+----------------+\npaged:| Row1TestValue1 | Row1TestValue2 | Row1TestValue3 |\npaged:+----------------+----------------+----------------+\n", output)
+	})
+
+	t.Run("LESSUnset_DefaultsLESS", func(t *testing.T) {
+		t.Setenv("PAGER", "sh "+writeLESSPagerScript(t))
+		t.Setenv("LESS", "")
+		os.Unsetenv("LESS")
+		oldStdoutIsTerminal := stdoutIsTerminal
+		stdoutIsTerminal = func() bool { return true }
+		defer func() { stdoutIsTerminal = oldStdoutIsTerminal }()
+
+		output := test.CatchStdOut(t, func() {
+			err := NewOutputHandler().Table(&cobra.Command{}, collectionSingleRow)
+			assert.NoError(t, err)
+		})
+
+		assert.Equal(t, "LESS=FRX\n", output)
+	})
+
+	t.Run("LESSSet_Retained", func(t *testing.T) {
+		t.Setenv("PAGER", "sh "+writeLESSPagerScript(t))
+		t.Setenv("LESS", "S")
+		oldStdoutIsTerminal := stdoutIsTerminal
+		stdoutIsTerminal = func() bool { return true }
+		defer func() { stdoutIsTerminal = oldStdoutIsTerminal }()
+
+		output := test.CatchStdOut(t, func() {
+			err := NewOutputHandler().Table(&cobra.Command{}, collectionSingleRow)
+			assert.NoError(t, err)
+		})
+
+		assert.Equal(t, "LESS=S\n", output)
+	})
+
+	t.Run("NoPagerFlag_WritesStdout", func(t *testing.T) {
+		t.Setenv("PAGER", "sed s/^/paged:/")
+		oldStdoutIsTerminal := stdoutIsTerminal
+		stdoutIsTerminal = func() bool { return true }
+		defer func() { stdoutIsTerminal = oldStdoutIsTerminal }()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("no-pager", false, "")
+		cmd.Flags().Set("no-pager", "true")
+
+		output := test.CatchStdOut(t, func() {
+			err := NewOutputHandler().Table(cmd, collectionSingleRow)
+			assert.NoError(t, err)
+		})
+
+		assert.NotContains(t, output, "paged:")
+		assert.Contains(t, output, "Row1TestValue1")
+	})
+
+	t.Run("NotTerminal_WritesStdout", func(t *testing.T) {
+		t.Setenv("PAGER", "sed s/^/paged:/")
+
+		output := test.CatchStdOut(t, func() {
+			err := NewOutputHandler().Table(&cobra.Command{}, collectionSingleRow)
+			assert.NoError(t, err)
+		})
+
+		assert.NotContains(t, output, "paged:")
+	})
+}
+
+func TestOutputHandler_TSV(t *testing.T) {
+	t.Run("MultipleRows_ExpectedStdout", func(t *testing.T) {
+		output := test.CatchStdOut(t, func() {
+			err := NewOutputHandler().TSV(&cobra.Command{}, collectionMultipleRows)
+			assert.NoError(t, err)
+		})
+
+		assert.Equal(t, "testproperty1\ttestproperty2\ttestproperty3\nRow1TestValue1\tRow1TestValue2\tRow1TestValue3\nRow2TestValue1\tRow2TestValue2\tRow2TestValue3\n", output)
+	})
+
+	t.Run("SpecialCharacters_Escaped", func(t *testing.T) {
+		output := test.CatchStdOut(t, func() {
+			err := NewOutputHandler().TSV(&cobra.Command{}, testModelCollection{{"tab\there", "line1\nline2", `C:\path`}})
+			assert.NoError(t, err)
+		})
+
+		assert.Equal(t, "testproperty1\ttestproperty2\ttestproperty3\ntab\\there\tline1\\nline2\tC:\\\\path\n", output)
+	})
+}
+
+func TestOutputHandler_PropertyAlias(t *testing.T) {
+	t.Run("CSV_RenamesHeader", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		cmd.Flags().StringSlice("property", nil, "")
+		cmd.ParseFlags([]string{"--property", "testproperty2:second,testproperty1"})
+
+		output := test.CatchStdOut(t, func() {
+			err := NewOutputHandler().CSV(cmd, collectionSingleRow)
+			assert.NoError(t, err)
+		})
+
+		assert.Equal(t, "testproperty1,second\nRow1TestValue1,Row1TestValue2\n", output)
+	})
+
+	t.Run("TSV_RenamesHeader", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		cmd.Flags().StringSlice("property", nil, "")
+		cmd.ParseFlags([]string{"--property", "testproperty3:third"})
+
+		output := test.CatchStdOut(t, func() {
+			err := NewOutputHandler().TSV(cmd, collectionSingleRow)
+			assert.NoError(t, err)
+		})
+
+		assert.Equal(t, "third\nRow1TestValue3\n", output)
+	})
+}
+
+type testNestedModel struct {
+	Name     string            `json:"name"`
+	Location testLocation      `json:"location,omitempty"`
+	Labels   map[string]string `json:"labels"`
+	Internal string            `json:"-"`
+}
+
+type testLocation struct {
+	Region string `json:"region"`
+	Zone   string `json:"zone,omitempty"`
+}
+
+func TestOutputHandler_NestedFieldsFlattened(t *testing.T) {
+	d := []testNestedModel{
+		{Name: "one", Location: testLocation{Region: "uk", Zone: "a"}, Labels: map[string]string{"team": "web", "env": "prod"}, Internal: "secret"},
+		{Name: "two", Location: testLocation{Region: "us", Zone: "b"}, Labels: map[string]string{"owner": "ops"}},
+	}
+
+	output := test.CatchStdOut(t, func() {
+		err := NewOutputHandler().CSV(&cobra.Command{}, d)
+		assert.NoError(t, err)
+	})
+
+	assert.Equal(t, "name,location.region,location.zone,labels.env,labels.team,labels.owner\none,uk,a,prod,web,\ntwo,us,b,,,ops\n", output)
+}
@@ -1,9 +1,12 @@
 package output
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/ans-group/cli/test"
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -172,3 +175,223 @@ func TestExitWithErrorLevel_ExpectedExitCode(t *testing.T) {
 
 	assert.Equal(t, 5, code)
 }
+
+// newTestPaginatedGetFunc returns a get func returning pages, and a pointer to the pages requested
+func newTestPaginatedGetFunc[T any](pages ...[]T) (connection.PaginatedGetFunc[T], *[]int) {
+	var requested []int
+	var getFunc connection.PaginatedGetFunc[T]
+	getFunc = func(parameters connection.APIRequestParameters) (*connection.Paginated[T], error) {
+		requested = append(requested, parameters.Pagination.Page)
+		if parameters.Pagination.Page > len(pages) {
+			return nil, errors.New("test error")
+		}
+
+		body := &connection.APIResponseBodyData[[]T]{Data: pages[parameters.Pagination.Page-1]}
+		body.Metadata.Pagination.TotalPages = len(pages)
+
+		return connection.NewPaginated(body, parameters, getFunc), nil
+	}
+
+	return getFunc, &requested
+}
+
+func newTestStreamCommand(args ...string) *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("output", "", "")
+	cmd.Flags().Int("limit", 0, "")
+	cmd.Flags().StringSlice("property", nil, "")
+	cmd.Flags().StringArray("localfilter", nil, "")
+	cmd.Flags().String("where", "", "")
+	cmd.Flags().StringSlice("group-by", nil, "")
+	cmd.Flags().StringSlice("aggregate", nil, "")
+	cmd.ParseFlags(args)
+
+	return cmd
+}
+
+func TestCommandOutputStream(t *testing.T) {
+	row1 := testModel{"Row1TestValue1", "Row1TestValue2", "Row1TestValue3"}
+	row2 := testModel{"Row2TestValue1", "Row2TestValue2", "Row2TestValue3"}
+	row3 := testModel{"Row3TestValue1", "Row3TestValue2", "Row3TestValue3"}
+
+	t.Run("CSV_OutputsHeaderOnce", func(t *testing.T) {
+		getFunc, requested := newTestPaginatedGetFunc([]testModel{row1, row2}, []testModel{row3})
+		cmd := newTestStreamCommand("--output", "csv")
+
+		output := test.CatchStdOut(t, func() {
+			err := CommandOutputStream[testModelCollection](cmd, connection.APIRequestParameters{}, getFunc)
+			assert.Nil(t, err)
+		})
+
+		assert.Equal(t, "testproperty1,testproperty2,testproperty3\nRow1TestValue1,Row1TestValue2,Row1TestValue3\nRow2TestValue1,Row2TestValue2,Row2TestValue3\nRow3TestValue1,Row3TestValue2,Row3TestValue3\n", output)
+		assert.Equal(t, []int{1, 2}, *requested)
+	})
+
+	t.Run("CSV_FirstPageFilteredOut_OutputsHeaderOnce", func(t *testing.T) {
+		getFunc, _ := newTestPaginatedGetFunc([]testModel{row1}, []testModel{row2}, []testModel{row3})
+		cmd := newTestStreamCommand("--output", "csv", "--localfilter", "testproperty1:neq=Row1TestValue1")
+
+		output := test.CatchStdOut(t, func() {
+			err := CommandOutputStream[testModelCollection](cmd, connection.APIRequestParameters{}, getFunc)
+			assert.Nil(t, err)
+		})
+
+		assert.Equal(t, "testproperty1,testproperty2,testproperty3\nRow2TestValue1,Row2TestValue2,Row2TestValue3\nRow3TestValue1,Row3TestValue2,Row3TestValue3\n", output)
+	})
+
+	t.Run("CSV_LaterPageAdditionalColumns_UsesFirstPageColumns", func(t *testing.T) {
+		type taggedModel struct {
+			Name string            `json:"name"`
+			Tags map[string]string `json:"tags"`
+		}
+
+		getFunc, _ := newTestPaginatedGetFunc(
+			[]taggedModel{{Name: "first", Tags: map[string]string{"env": "prod"}}},
+			[]taggedModel{{Name: "second", Tags: map[string]string{"env": "dev", "owner": "test"}}},
+		)
+		cmd := newTestStreamCommand("--output", "csv")
+
+		output := test.CatchStdOut(t, func() {
+			err := CommandOutputStream[[]taggedModel](cmd, connection.APIRequestParameters{}, getFunc)
+			assert.Nil(t, err)
+		})
+
+		assert.Equal(t, "name,tags.env\nfirst,prod\nsecond,dev\n", output)
+	})
+
+	t.Run("NDJSON_OutputsItemPerLine", func(t *testing.T) {
+		getFunc, _ := newTestPaginatedGetFunc([]testModel{row1}, []testModel{row2})
+		cmd := newTestStreamCommand("--output", "ndjson")
+
+		output := test.CatchStdOut(t, func() {
+			err := CommandOutputStream[testModelCollection](cmd, connection.APIRequestParameters{}, getFunc)
+			assert.Nil(t, err)
+		})
+
+		assert.Equal(t, "{\"testproperty1\":\"Row1TestValue1\",\"testproperty2\":\"Row1TestValue2\",\"testproperty3\":\"Row1TestValue3\"}\n{\"testproperty1\":\"Row2TestValue1\",\"testproperty2\":\"Row2TestValue2\",\"testproperty3\":\"Row2TestValue3\"}\n", output)
+	})
+
+	t.Run("JSON_OutputsAllPagesOnce", func(t *testing.T) {
+		getFunc, _ := newTestPaginatedGetFunc([]testModel{row1}, []testModel{row2})
+		cmd := newTestStreamCommand("--output", "json")
+
+		output := test.CatchStdOut(t, func() {
+			err := CommandOutputStream[testModelCollection](cmd, connection.APIRequestParameters{}, getFunc)
+			assert.Nil(t, err)
+		})
+
+		assert.Equal(t, "[{\"testproperty1\":\"Row1TestValue1\",\"testproperty2\":\"Row1TestValue2\",\"testproperty3\":\"Row1TestValue3\"},{\"testproperty1\":\"Row2TestValue1\",\"testproperty2\":\"Row2TestValue2\",\"testproperty3\":\"Row2TestValue3\"}]", output)
+	})
+
+	t.Run("Aggregate_OutputsSummaryOfAllPages", func(t *testing.T) {
+		getFunc, requested := newTestPaginatedGetFunc([]testModel{row1, row2}, []testModel{row3})
+		cmd := newTestStreamCommand("--output", "csv", "--aggregate", "count")
+
+		output := test.CatchStdOut(t, func() {
+			err := CommandOutputStream[testModelCollection](cmd, connection.APIRequestParameters{}, getFunc)
+			assert.Nil(t, err)
+		})
+
+		assert.Equal(t, "count\n3\n", output)
+		assert.Equal(t, []int{1, 2}, *requested)
+	})
+
+	t.Run("NoItems_OutputsEmptyJSONArray", func(t *testing.T) {
+		getFunc, _ := newTestPaginatedGetFunc([]testModel{})
+		cmd := newTestStreamCommand("--output", "json")
+
+		output := test.CatchStdOut(t, func() {
+			err := CommandOutputStream[testModelCollection](cmd, connection.APIRequestParameters{}, getFunc)
+			assert.Nil(t, err)
+		})
+
+		assert.Equal(t, "[]", output)
+	})
+
+	t.Run("Limit_StopsRetrieval", func(t *testing.T) {
+		getFunc, requested := newTestPaginatedGetFunc([]testModel{row1, row2}, []testModel{row3})
+		cmd := newTestStreamCommand("--output", "value", "--property", "testproperty1", "--limit", "1")
+
+		output := test.CatchStdOut(t, func() {
+			err := CommandOutputStream[testModelCollection](cmd, connection.APIRequestParameters{}, getFunc)
+			assert.Nil(t, err)
+		})
+
+		assert.Equal(t, "Row1TestValue1\n", output)
+		assert.Equal(t, []int{1}, *requested)
+	})
+
+	t.Run("Limit_SpansPages", func(t *testing.T) {
+		getFunc, requested := newTestPaginatedGetFunc([]testModel{row1}, []testModel{row2}, []testModel{row3})
+		cmd := newTestStreamCommand("--output", "value", "--property", "testproperty1", "--limit", "2")
+
+		output := test.CatchStdOut(t, func() {
+			err := CommandOutputStream[testModelCollection](cmd, connection.APIRequestParameters{}, getFunc)
+			assert.Nil(t, err)
+		})
+
+		assert.Equal(t, "Row1TestValue1\nRow2TestValue1\n", output)
+		assert.Equal(t, []int{1, 2}, *requested)
+	})
+
+	t.Run("PageParameter_RetrievesSinglePage", func(t *testing.T) {
+		getFunc, requested := newTestPaginatedGetFunc([]testModel{row1}, []testModel{row2})
+		cmd := newTestStreamCommand("--output", "value", "--property", "testproperty1")
+		params := connection.APIRequestParameters{Pagination: connection.APIRequestPagination{Page: 2}}
+
+		var output string
+		stdErr := test.CatchStdErr(t, func() {
+			output = test.CatchStdOut(t, func() {
+				err := CommandOutputStream[testModelCollection](cmd, params, getFunc)
+				assert.Nil(t, err)
+			})
+		})
+
+		assert.Equal(t, "Row2TestValue1\n", output)
+		assert.Equal(t, "page 2/2\n", stdErr)
+		assert.Equal(t, []int{2}, *requested)
+	})
+
+	t.Run("NextPageRetrievalError_ReturnsError", func(t *testing.T) {
+		var getFunc connection.PaginatedGetFunc[testModel]
+		getFunc = func(parameters connection.APIRequestParameters) (*connection.Paginated[testModel], error) {
+			if parameters.Pagination.Page > 1 {
+				return nil, errors.New("test error")
+			}
+
+			body := &connection.APIResponseBodyData[[]testModel]{Data: []testModel{row1}}
+			body.Metadata.Pagination.TotalPages = 2
+
+			return connection.NewPaginated(body, parameters, getFunc), nil
+		}
+		cmd := newTestStreamCommand("--output", "value", "--property", "testproperty1")
+
+		var err error
+		output := test.CatchStdOut(t, func() {
+			err = CommandOutputStream[testModelCollection](cmd, connection.APIRequestParameters{}, getFunc)
+		})
+
+		assert.Equal(t, "Row1TestValue1\n", output)
+		assert.Equal(t, "test error", err.Error())
+	})
+
+	t.Run("InvalidWhereExpression_ReturnsErrorBeforeRetrieval", func(t *testing.T) {
+		getFunc, requested := newTestPaginatedGetFunc([]testModel{row1})
+		cmd := newTestStreamCommand("--where", "testproperty1 ==")
+
+		err := CommandOutputStream[testModelCollection](cmd, connection.APIRequestParameters{}, getFunc)
+
+		assert.Equal(t, "invalid where expression: unexpected end of expression at position 17", err.Error())
+		assert.Empty(t, *requested)
+	})
+
+	t.Run("RetrievalError_ReturnsError", func(t *testing.T) {
+		getFunc, _ := newTestPaginatedGetFunc([]testModel{row1})
+		cmd := newTestStreamCommand("--output", "csv")
+		params := connection.APIRequestParameters{Pagination: connection.APIRequestPagination{Page: 3}}
+
+		err := CommandOutputStream[testModelCollection](cmd, params, getFunc)
+
+		assert.Equal(t, "test error", err.Error())
+	})
+}
@@ -0,0 +1,61 @@
+package output
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// stdoutIsTerminal returns true where stdout is attached to a terminal
+var stdoutIsTerminal = func() bool {
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// pagerWriter returns a writer to the pager specified by environment variable PAGER where stdout is a
+// terminal, the command isn't being watched and the 'no-pager' flag isn't set, otherwise stdout. As with git,
+// environment variable LESS defaults to 'FRX' so that less exits immediately where output fits on a single
+// screen. The returned function must be called once output is complete, and waits for the pager to exit
+func pagerWriter(c *cobra.Command) (io.Writer, func()) {
+	pager := strings.Fields(os.Getenv("PAGER"))
+	if len(pager) == 0 || !stdoutIsTerminal() || activeWatcher != nil || noPager(c) {
+		return os.Stdout, func() {}
+	}
+
+	cmd := exec.Command(pager[0], pager[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if _, ok := os.LookupEnv("LESS"); !ok {
+		cmd.Env = append(os.Environ(), "LESS=FRX")
+	}
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		Errorf("failed to start pager: %s", err)
+		return os.Stdout, func() {}
+	}
+
+	err = cmd.Start()
+	if err != nil {
+		Errorf("failed to start pager: %s", err)
+		return os.Stdout, func() {}
+	}
+
+	return stdin, func() {
+		_ = stdin.Close()
+		_ = cmd.Wait()
+	}
+}
+
+// noPager returns true where the 'no-pager' flag is set for c
+func noPager(c *cobra.Command) bool {
+	if c == nil || c.Flags().Lookup("no-pager") == nil {
+		return false
+	}
+
+	disabled, _ := c.Flags().GetBool("no-pager")
+	return disabled
+}
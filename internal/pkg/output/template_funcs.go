@@ -0,0 +1,237 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"gopkg.in/yaml.v3"
+)
+
+// TemplateFuncs returns the function library available to gotemplate output
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"join":          templateJoin,
+		"split":         func(sep, s string) []string { return strings.Split(s, sep) },
+		"upper":         strings.ToUpper,
+		"lower":         strings.ToLower,
+		"trim":          strings.TrimSpace,
+		"replace":       func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+		"contains":      func(substr, s string) bool { return strings.Contains(s, substr) },
+		"hasPrefix":     func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+		"hasSuffix":     func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+		"padLeft":       func(width int, v any) string { return fmt.Sprintf("%*v", width, v) },
+		"padRight":      func(width int, v any) string { return fmt.Sprintf("%-*v", width, v) },
+		"indent":        templateIndent,
+		"quote":         func(v any) string { return fmt.Sprintf("%q", fmt.Sprint(v)) },
+		"default":       templateDefault,
+		"toJSON":        templateToJSON,
+		"toPrettyJSON":  templateToPrettyJSON,
+		"toYAML":        templateToYAML,
+		"date":          templateDate,
+		"now":           time.Now,
+		"humanizeBytes": templateHumanizeBytes,
+		"lookup":        templateLookup,
+	}
+}
+
+// templateJoin joins elements of slice v with sep, formatting each element with fmt
+func templateJoin(sep string, v any) string {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return fmt.Sprint(v)
+	}
+
+	var elements []string
+	for i := 0; i < value.Len(); i++ {
+		elements = append(elements, fmt.Sprint(value.Index(i).Interface()))
+	}
+
+	return strings.Join(elements, sep)
+}
+
+func templateIndent(spaces int, s string) string {
+	padding := strings.Repeat(" ", spaces)
+	return padding + strings.ReplaceAll(s, "\n", "\n"+padding)
+}
+
+// templateDefault returns def if v is nil or the zero value for its type, otherwise v
+func templateDefault(def any, v any) any {
+	if v == nil {
+		return def
+	}
+
+	value := reflect.ValueOf(v)
+	if value.IsZero() {
+		return def
+	}
+	if (value.Kind() == reflect.Slice || value.Kind() == reflect.Map) && value.Len() == 0 {
+		return def
+	}
+
+	return v
+}
+
+func templateToJSON(v any) (string, error) {
+	out, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+func templateToPrettyJSON(v any) (string, error) {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+func templateToYAML(v any) (string, error) {
+	out, err := yaml.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+// templateDate formats date v (time.Time, connection.DateTime, connection.Date or RFC3339 string)
+// with Go layout. An empty string is returned for values which can't be parsed
+func templateDate(layout string, v any) string {
+	var t time.Time
+	switch date := v.(type) {
+	case time.Time:
+		t = date
+	case *time.Time:
+		if date != nil {
+			t = *date
+		}
+	case connection.DateTime:
+		t = date.Time()
+	case connection.Date:
+		t = date.Time()
+	case string:
+		t, _ = time.Parse(time.RFC3339, date)
+	}
+
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(layout)
+}
+
+// templateHumanizeBytes formats byte count v using binary (IEC) units
+func templateHumanizeBytes(v any) string {
+	var bytes float64
+	value := reflect.ValueOf(v)
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		bytes = float64(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		bytes = float64(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		bytes = value.Float()
+	default:
+		return fmt.Sprint(v)
+	}
+
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB"}
+	unit := 0
+	for bytes >= 1024 && unit < len(units)-1 {
+		bytes /= 1024
+		unit++
+	}
+
+	if unit == 0 {
+		return fmt.Sprintf("%.0f %s", bytes, units[unit])
+	}
+
+	return fmt.Sprintf("%.1f %s", bytes, units[unit])
+}
+
+// templateLookup returns the value at dot-separated path within v, matching struct fields by name or
+// JSON tag (case-insensitive) and map keys. nil is returned if the path doesn't exist
+func templateLookup(path string, v any) any {
+	value := reflect.ValueOf(v)
+	for _, part := range strings.Split(path, ".") {
+		for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+			if value.IsNil() {
+				return nil
+			}
+			value = value.Elem()
+		}
+
+		switch value.Kind() {
+		case reflect.Map:
+			key, ok := templateMapKey(part, value.Type().Key())
+			if !ok {
+				return nil
+			}
+			value = value.MapIndex(key)
+		case reflect.Struct:
+			value = lookupStructField(value, part)
+		default:
+			return nil
+		}
+
+		if !value.IsValid() {
+			return nil
+		}
+	}
+
+	return value.Interface()
+}
+
+// templateMapKey returns part as a map key of type keyType, parsing integer keys. false is returned if
+// part can't be used as a key of keyType
+func templateMapKey(part string, keyType reflect.Type) (reflect.Value, bool) {
+	switch keyType.Kind() {
+	case reflect.String:
+		return reflect.ValueOf(part).Convert(keyType), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(part, 10, keyType.Bits())
+		if err != nil {
+			return reflect.Value{}, false
+		}
+		return reflect.ValueOf(i).Convert(keyType), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(part, 10, keyType.Bits())
+		if err != nil {
+			return reflect.Value{}, false
+		}
+		return reflect.ValueOf(u).Convert(keyType), true
+	case reflect.Interface:
+		if reflect.TypeOf(part).Implements(keyType) {
+			return reflect.ValueOf(part), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+func lookupStructField(value reflect.Value, name string) reflect.Value {
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		jsonName := strings.Split(field.Tag.Get("json"), ",")[0]
+		if strings.EqualFold(field.Name, name) || (jsonName != "" && strings.EqualFold(jsonName, name)) {
+			return value.Field(i)
+		}
+	}
+
+	return reflect.Value{}
+}
@@ -0,0 +1,122 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/stretchr/testify/assert"
+)
+
+func executeTestTemplate(t *testing.T, tmpl string, data any) string {
+	parsed, err := template.New("test").Funcs(TemplateFuncs()).Parse(tmpl)
+	assert.NoError(t, err)
+
+	var b bytes.Buffer
+	err = parsed.Execute(&b, data)
+	assert.NoError(t, err)
+
+	return b.String()
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	t.Run("Join", func(t *testing.T) {
+		assert.Equal(t, "a,b,1", executeTestTemplate(t, `{{join "," .}}`, []any{"a", "b", 1}))
+	})
+
+	t.Run("StringFunctions", func(t *testing.T) {
+		assert.Equal(t, "ABC abc x-y", executeTestTemplate(t, `{{upper "abc"}} {{lower "ABC"}} {{replace "_" "-" "x_y"}}`, nil))
+	})
+
+	t.Run("Padding", func(t *testing.T) {
+		assert.Equal(t, "[ab   ][   ab]", executeTestTemplate(t, `[{{padRight 5 "ab"}}][{{padLeft 5 "ab"}}]`, nil))
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		assert.Equal(t, "none value", executeTestTemplate(t, `{{default "none" .A}} {{default "none" .B}}`, map[string]string{"A": "", "B": "value"}))
+	})
+
+	t.Run("ToJSON", func(t *testing.T) {
+		assert.Equal(t, `{"a":1}`, executeTestTemplate(t, `{{toJSON .}}`, map[string]int{"a": 1}))
+	})
+
+	t.Run("ToYAML", func(t *testing.T) {
+		assert.Equal(t, "a: 1", executeTestTemplate(t, `{{toYAML .}}`, map[string]int{"a": 1}))
+	})
+
+	t.Run("Indent", func(t *testing.T) {
+		assert.Equal(t, "  a\n  b", executeTestTemplate(t, `{{indent 2 "a\nb"}}`, nil))
+	})
+}
+
+func Test_templateDate(t *testing.T) {
+	t.Run("DateTime_Formats", func(t *testing.T) {
+		assert.Equal(t, "2024-01-02", templateDate("2006-01-02", connection.DateTime("2024-01-02T03:04:05+0000")))
+	})
+
+	t.Run("Time_Formats", func(t *testing.T) {
+		assert.Equal(t, "02/01/2024", templateDate("02/01/2006", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("RFC3339String_Formats", func(t *testing.T) {
+		assert.Equal(t, "2024", templateDate("2006", "2024-01-02T03:04:05Z"))
+	})
+
+	t.Run("Invalid_ReturnsEmpty", func(t *testing.T) {
+		assert.Equal(t, "", templateDate("2006", "invalid"))
+	})
+}
+
+func Test_templateHumanizeBytes(t *testing.T) {
+	assert.Equal(t, "512 B", templateHumanizeBytes(512))
+	assert.Equal(t, "1.5 KiB", templateHumanizeBytes(1536))
+	assert.Equal(t, "2.0 GiB", templateHumanizeBytes(int64(2*1024*1024*1024)))
+	assert.Equal(t, "abc", templateHumanizeBytes("abc"))
+}
+
+func Test_templateLookup(t *testing.T) {
+	type inner struct {
+		IPAddress string `json:"ip_address"`
+	}
+	type outer struct {
+		Name  string `json:"name"`
+		Inner *inner `json:"inner"`
+	}
+
+	data := outer{Name: "test", Inner: &inner{IPAddress: "1.2.3.4"}}
+
+	t.Run("JSONTagPath_ReturnsValue", func(t *testing.T) {
+		assert.Equal(t, "1.2.3.4", templateLookup("inner.ip_address", data))
+	})
+
+	t.Run("FieldName_ReturnsValue", func(t *testing.T) {
+		assert.Equal(t, "test", templateLookup("Name", data))
+	})
+
+	t.Run("Map_ReturnsValue", func(t *testing.T) {
+		assert.Equal(t, 1, templateLookup("a.b", map[string]any{"a": map[string]int{"b": 1}}))
+	})
+
+	t.Run("NamedStringKeyMap_ReturnsValue", func(t *testing.T) {
+		type key string
+		assert.Equal(t, "test", templateLookup("a", map[key]string{"a": "test"}))
+	})
+
+	t.Run("IntKeyMap_ReturnsValue", func(t *testing.T) {
+		assert.Equal(t, "test", templateLookup("1", map[int]string{1: "test"}))
+	})
+
+	t.Run("IntKeyMapNonNumericPart_ReturnsNil", func(t *testing.T) {
+		assert.Nil(t, templateLookup("a", map[int]string{1: "test"}))
+	})
+
+	t.Run("UnsupportedKeyMap_ReturnsNil", func(t *testing.T) {
+		assert.Nil(t, templateLookup("a", map[float64]string{1: "test"}))
+	})
+
+	t.Run("MissingPath_ReturnsNil", func(t *testing.T) {
+		assert.Nil(t, templateLookup("missing", data))
+	})
+}
@@ -0,0 +1,133 @@
+package output
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// activeWatcher is the watcher for the command being watched, if any. Output is observed by the watcher to
+// determine changed rows and whether the 'until' expression has been met
+var activeWatcher *watcher
+
+type watcher struct {
+	terminal bool
+	until    whereNode
+	previous map[string]bool
+	met      bool
+}
+
+// Watch calls fn at the interval specified by the 'watch' flag until interrupted, or until all output items
+// match the expression specified by the 'until' flag. Where stdout is a terminal, the output is redrawn in
+// place, otherwise only rows which have changed since the previous call are output
+func Watch(cmd *cobra.Command, args []string, fn func() error) error {
+	interval, _ := cmd.Flags().GetDuration("watch")
+	if interval <= 0 {
+		return errors.New("watch interval must be greater than 0")
+	}
+
+	w := &watcher{terminal: stdoutIsTerminal()}
+	if cmd.Flags().Lookup("until") != nil && cmd.Flags().Changed("until") {
+		untilFlag, _ := cmd.Flags().GetString("until")
+		until, err := parseWhereExpression(untilFlag)
+		if err != nil {
+			return fmt.Errorf("invalid until expression: %s", err)
+		}
+		w.until = until
+	}
+
+	activeWatcher = w
+	defer func() { activeWatcher = nil }()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	commandLine := strings.TrimSpace(cmd.CommandPath() + " " + strings.Join(args, " "))
+	for iteration := 0; ; iteration++ {
+		if w.terminal {
+			fmt.Print("\033[H\033[2J")
+			fmt.Printf("Every %s: %s    %s\n\n", interval, commandLine, time.Now().Format(time.RFC1123))
+		}
+
+		w.met = false
+		err := fn()
+		if err != nil {
+			if iteration == 0 {
+				return err
+			}
+			Error(err.Error())
+		}
+
+		if w.met {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// observe records the rows of d, evaluating the 'until' expression against them. Where stdout isn't a
+// terminal, d is returned with only the items which have changed since the previous call
+func (w *watcher) observe(o *OutputHandler, d any) any {
+	rows := o.convert(d, reflect.ValueOf(d))
+
+	if w.until != nil && len(rows) > 0 {
+		w.met = true
+		for _, row := range rows {
+			if !w.until.evaluate(row) {
+				w.met = false
+				break
+			}
+		}
+	}
+
+	previous := w.previous
+	w.previous = make(map[string]bool)
+	changed := make([]bool, len(rows))
+	for i, row := range rows {
+		fingerprint := rowFingerprint(row)
+		w.previous[fingerprint] = true
+		changed[i] = previous == nil || !previous[fingerprint]
+	}
+
+	if w.terminal {
+		return d
+	}
+
+	reflectedValue := reflect.ValueOf(d)
+	if reflectedValue.Kind() != reflect.Slice {
+		if len(rows) == 1 && changed[0] {
+			return d
+		}
+		return reflect.MakeSlice(reflect.SliceOf(reflectedValue.Type()), 0, 0).Interface()
+	}
+
+	resultSlice := reflect.MakeSlice(reflectedValue.Type(), 0, reflectedValue.Len())
+	for i := 0; i < reflectedValue.Len(); i++ {
+		if i < len(rows) && changed[i] {
+			resultSlice = reflect.Append(resultSlice, reflectedValue.Index(i))
+		}
+	}
+	return resultSlice.Interface()
+}
+
+// rowFingerprint returns a string uniquely identifying the keys and values of row
+func rowFingerprint(row *OrderedFields) string {
+	var fingerprint strings.Builder
+	for _, key := range row.Keys() {
+		fmt.Fprintf(&fingerprint, "%q=%q;", key, row.Get(key))
+	}
+
+	return fingerprint.String()
+}
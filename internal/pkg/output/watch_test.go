@@ -0,0 +1,142 @@
+package output
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ans-group/cli/test"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestWatchCommand(args ...string) *cobra.Command {
+	cmd := &cobra.Command{Use: "list"}
+	cmd.Flags().String("output", "", "")
+	cmd.Flags().Duration("watch", 0, "")
+	cmd.Flags().String("until", "", "")
+	cmd.ParseFlags(args)
+
+	return cmd
+}
+
+// newTestWatchIterations returns a func outputting each of iterations in turn with cmd
+func newTestWatchIterations(cmd *cobra.Command, iterations ...testModelCollection) (func() error, *int) {
+	calls := 0
+	return func() error {
+		d := iterations[calls]
+		if calls < len(iterations)-1 {
+			calls++
+		}
+		return CommandOutput(cmd, d)
+	}, &calls
+}
+
+func TestWatch(t *testing.T) {
+	pending := testModel{"task-1", "Pending", ""}
+	inProgress := testModel{"task-1", "InProgress", ""}
+	complete := testModel{"task-1", "Complete", ""}
+	other := testModel{"task-2", "Complete", ""}
+
+	t.Run("NotTerminal_OutputsChangedRowsUntilConditionMet", func(t *testing.T) {
+		cmd := newTestWatchCommand("--output", "csv", "--watch=1ms", "--until", "testproperty2=Complete")
+		fn, _ := newTestWatchIterations(cmd,
+			testModelCollection{pending, other},
+			testModelCollection{pending, other},
+			testModelCollection{inProgress, other},
+			testModelCollection{complete, other},
+		)
+
+		output := test.CatchStdOut(t, func() {
+			err := Watch(cmd, []string{}, fn)
+			assert.Nil(t, err)
+		})
+
+		assert.Equal(t, "testproperty1,testproperty2,testproperty3\ntask-1,Pending,\ntask-2,Complete,\n"+
+			"testproperty1,testproperty2,testproperty3\ntask-1,InProgress,\n"+
+			"testproperty1,testproperty2,testproperty3\ntask-1,Complete,\n", output)
+		assert.Nil(t, activeWatcher)
+	})
+
+	t.Run("Terminal_RedrawsOutput", func(t *testing.T) {
+		oldStdoutIsTerminal := stdoutIsTerminal
+		stdoutIsTerminal = func() bool { return true }
+		defer func() { stdoutIsTerminal = oldStdoutIsTerminal }()
+
+		cmd := newTestWatchCommand("--output", "value", "--watch=1ms", "--until", "testproperty2 == Complete")
+		fn, _ := newTestWatchIterations(cmd, testModelCollection{pending}, testModelCollection{complete})
+
+		output := test.CatchStdOut(t, func() {
+			err := Watch(cmd, []string{"--all"}, fn)
+			assert.Nil(t, err)
+		})
+
+		assert.Equal(t, 2, strings.Count(output, "\033[H\033[2J"))
+		assert.Contains(t, output, "Every 1ms: list --all")
+		assert.Contains(t, output, "task-1 Pending \n")
+		assert.Contains(t, output, "task-1 Complete \n")
+	})
+
+	t.Run("NoRows_ConditionNotMet", func(t *testing.T) {
+		cmd := newTestWatchCommand("--output", "value", "--watch=1ms", "--until", "testproperty2=Complete")
+		fn, calls := newTestWatchIterations(cmd, testModelCollection{}, testModelCollection{}, testModelCollection{complete})
+
+		test.CatchStdOut(t, func() {
+			err := Watch(cmd, []string{}, fn)
+			assert.Nil(t, err)
+		})
+
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("FirstCallError_ReturnsError", func(t *testing.T) {
+		cmd := newTestWatchCommand("--watch=1ms")
+
+		err := Watch(cmd, []string{}, func() error {
+			return errors.New("test error")
+		})
+
+		assert.Equal(t, "test error", err.Error())
+	})
+
+	t.Run("SubsequentCallError_OutputsErrorAndContinues", func(t *testing.T) {
+		cmd := newTestWatchCommand("--output", "value", "--watch=1ms", "--until", "testproperty2=Complete")
+		calls := 0
+
+		stdErr := test.CatchStdErr(t, func() {
+			test.CatchStdOut(t, func() {
+				err := Watch(cmd, []string{}, func() error {
+					calls++
+					switch calls {
+					case 1:
+						return CommandOutput(cmd, testModelCollection{pending})
+					case 2:
+						return errors.New("test error")
+					default:
+						return CommandOutput(cmd, testModelCollection{complete})
+					}
+				})
+				assert.Nil(t, err)
+			})
+		})
+
+		assert.Equal(t, "test error\n", stdErr)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("InvalidUntilExpression_ReturnsError", func(t *testing.T) {
+		cmd := newTestWatchCommand("--watch=1ms", "--until", "testproperty2 ==")
+
+		err := Watch(cmd, []string{}, func() error { return nil })
+
+		assert.Equal(t, "invalid until expression: unexpected end of expression at position 17", err.Error())
+	})
+
+	t.Run("InvalidInterval_ReturnsError", func(t *testing.T) {
+		cmd := newTestWatchCommand("--watch=0s")
+
+		err := Watch(cmd, []string{}, func() error { return nil })
+
+		assert.Equal(t, "watch interval must be greater than 0", err.Error())
+	})
+}
@@ -0,0 +1,434 @@
+package output
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// This file implements the expression language for the 'where' flag, which is evaluated against converted rows.
+// Expressions consist of comparisons between properties and literals, combined with &&, || and !, e.g.
+//
+//	status == "Complete" && (ram_gb > 8 || name =~ "^web")
+//
+// A single = is accepted as ==. Comparisons are numeric where both values are numbers, chronological where both
+// values are dates, and otherwise case-insensitive string comparisons. Properties which don't exist or are empty
+// are null
+
+// whereDateLayouts are the layouts attempted when comparing values as dates
+var whereDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05-0700", // connection.DateTime
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+type whereNode interface {
+	evaluate(row *OrderedFields) bool
+}
+
+type whereAnd struct {
+	left  whereNode
+	right whereNode
+}
+
+func (n whereAnd) evaluate(row *OrderedFields) bool {
+	return n.left.evaluate(row) && n.right.evaluate(row)
+}
+
+type whereOr struct {
+	left  whereNode
+	right whereNode
+}
+
+func (n whereOr) evaluate(row *OrderedFields) bool {
+	return n.left.evaluate(row) || n.right.evaluate(row)
+}
+
+type whereNot struct {
+	node whereNode
+}
+
+func (n whereNot) evaluate(row *OrderedFields) bool {
+	return !n.node.evaluate(row)
+}
+
+// whereOperand is either a property, or a literal value. Bare words on the right-hand side of a comparison are
+// treated as literals where the row has no such property, so 'status=Complete' behaves as expected
+type whereOperand struct {
+	property        string
+	literal         string
+	null            bool
+	literalFallback bool
+}
+
+// value returns the value of the operand for row, returning false where the value is null
+func (o whereOperand) value(row *OrderedFields) (string, bool) {
+	if o.null {
+		return "", false
+	}
+	if len(o.property) == 0 {
+		return o.literal, true
+	}
+
+	if o.literalFallback && !row.Exists(o.property) {
+		return o.literal, true
+	}
+
+	value := row.Get(o.property)
+	if !row.Exists(o.property) || value == "" || value == "<nil>" {
+		return "", false
+	}
+
+	return value, true
+}
+
+// whereTruthy is a lone operand, which is true where the value isn't null, false or 0
+type whereTruthy struct {
+	operand whereOperand
+}
+
+func (n whereTruthy) evaluate(row *OrderedFields) bool {
+	value, ok := n.operand.value(row)
+	if !ok || strings.EqualFold(value, "false") {
+		return false
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	return err != nil || number != 0
+}
+
+type whereComparison struct {
+	left     whereOperand
+	operator string
+	right    whereOperand
+	regex    *regexp.Regexp
+}
+
+func (n whereComparison) evaluate(row *OrderedFields) bool {
+	left, leftOK := n.left.value(row)
+	right, rightOK := n.right.value(row)
+
+	switch n.operator {
+	case "==":
+		if !leftOK || !rightOK {
+			return leftOK == rightOK
+		}
+		return compareWhereValues(left, right) == 0
+	case "!=":
+		if !leftOK || !rightOK {
+			return leftOK != rightOK
+		}
+		return compareWhereValues(left, right) != 0
+	case "=~":
+		return leftOK && n.regex.MatchString(left)
+	case "!~":
+		return !leftOK || !n.regex.MatchString(left)
+	}
+
+	if !leftOK || !rightOK {
+		return false
+	}
+
+	cmp := compareWhereValues(left, right)
+	switch n.operator {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default:
+		return cmp >= 0
+	}
+}
+
+// compareWhereValues compares a and b numerically where both are numbers, chronologically where both are
+// dates, and otherwise as case-insensitive strings
+func compareWhereValues(a string, b string) int {
+	aFloat, aErr := strconv.ParseFloat(a, 64)
+	bFloat, bErr := strconv.ParseFloat(b, 64)
+	if aErr == nil && bErr == nil {
+		switch {
+		case aFloat < bFloat:
+			return -1
+		case aFloat > bFloat:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	aTime, aOK := parseWhereDate(a)
+	bTime, bOK := parseWhereDate(b)
+	if aOK && bOK {
+		return aTime.Compare(bTime)
+	}
+
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+func parseWhereDate(s string) (time.Time, bool) {
+	for _, layout := range whereDateLayouts {
+		t, err := time.Parse(layout, s)
+		if err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+type whereTokenType int
+
+const (
+	whereTokenEnd whereTokenType = iota
+	whereTokenWord
+	whereTokenString
+	whereTokenOperator
+)
+
+type whereToken struct {
+	tokenType whereTokenType
+	value     string
+	position  int
+}
+
+// whereOperators are the supported operators, with longer operators listed first
+var whereOperators = []string{"&&", "||", "==", "!=", "=~", "!~", "<=", ">=", "=", "<", ">", "!", "(", ")"}
+
+func tokenizeWhereExpression(expression string) ([]whereToken, error) {
+	var tokens []whereToken
+
+	runes := []rune(expression)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || r == '\'':
+			start := i
+			var value strings.Builder
+			i++
+			for ; i < len(runes) && runes[i] != r; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) && (runes[i+1] == r || runes[i+1] == '\\') {
+					i++
+				}
+				value.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated string at position %d", start+1)
+			}
+			i++
+			tokens = append(tokens, whereToken{tokenType: whereTokenString, value: value.String(), position: start + 1})
+		case isWhereWordRune(r):
+			start := i
+			for i < len(runes) && isWhereWordRune(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, whereToken{tokenType: whereTokenWord, value: string(runes[start:i]), position: start + 1})
+		default:
+			operator := ""
+			for _, o := range whereOperators {
+				if strings.HasPrefix(string(runes[i:]), o) {
+					operator = o
+					break
+				}
+			}
+			if operator == "" {
+				return nil, fmt.Errorf("unexpected character [%c] at position %d", r, i+1)
+			}
+			tokens = append(tokens, whereToken{tokenType: whereTokenOperator, value: operator, position: i + 1})
+			i += len([]rune(operator))
+		}
+	}
+
+	return append(tokens, whereToken{tokenType: whereTokenEnd, position: len(runes) + 1}), nil
+}
+
+func isWhereWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_.-+:", r)
+}
+
+type whereParser struct {
+	tokens []whereToken
+	index  int
+}
+
+// parseWhereExpression parses expression into a node which can be evaluated against rows
+func parseWhereExpression(expression string) (whereNode, error) {
+	tokens, err := tokenizeWhereExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &whereParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if token := p.peek(); token.tokenType != whereTokenEnd {
+		return nil, fmt.Errorf("unexpected [%s] at position %d", token.value, token.position)
+	}
+
+	return node, nil
+}
+
+func (p *whereParser) peek() whereToken {
+	return p.tokens[p.index]
+}
+
+func (p *whereParser) next() whereToken {
+	token := p.tokens[p.index]
+	if token.tokenType != whereTokenEnd {
+		p.index++
+	}
+
+	return token
+}
+
+func (p *whereParser) acceptOperator(operators ...string) (string, bool) {
+	token := p.peek()
+	if token.tokenType != whereTokenOperator {
+		return "", false
+	}
+
+	for _, operator := range operators {
+		if token.value == operator {
+			p.index++
+			return operator, true
+		}
+	}
+
+	return "", false
+}
+
+func (p *whereParser) parseOr() (whereNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if _, ok := p.acceptOperator("||"); !ok {
+			return left, nil
+		}
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = whereOr{left: left, right: right}
+	}
+}
+
+func (p *whereParser) parseAnd() (whereNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if _, ok := p.acceptOperator("&&"); !ok {
+			return left, nil
+		}
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = whereAnd{left: left, right: right}
+	}
+}
+
+func (p *whereParser) parseUnary() (whereNode, error) {
+	if _, ok := p.acceptOperator("!"); ok {
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return whereNot{node: node}, nil
+	}
+
+	if _, ok := p.acceptOperator("("); ok {
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := p.acceptOperator(")"); !ok {
+			token := p.peek()
+			return nil, fmt.Errorf("expected [)] at position %d", token.position)
+		}
+		return node, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *whereParser) parseComparison() (whereNode, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	operator, ok := p.acceptOperator("==", "=", "!=", "=~", "!~", "<=", ">=", "<", ">")
+	if !ok {
+		return whereTruthy{operand: left}, nil
+	}
+	if operator == "=" {
+		operator = "=="
+	}
+
+	rightToken := p.peek()
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	right.literalFallback = len(right.property) > 0
+
+	comparison := whereComparison{left: left, operator: operator, right: right}
+	if operator == "=~" || operator == "!~" {
+		if rightToken.tokenType != whereTokenString {
+			return nil, fmt.Errorf("expected regular expression string at position %d", rightToken.position)
+		}
+
+		comparison.regex, err = regexp.Compile(right.literal)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression at position %d: %s", rightToken.position, err)
+		}
+	}
+
+	return comparison, nil
+}
+
+func (p *whereParser) parseOperand() (whereOperand, error) {
+	token := p.next()
+
+	switch token.tokenType {
+	case whereTokenString:
+		return whereOperand{literal: token.value}, nil
+	case whereTokenWord:
+		switch {
+		case token.value == "null":
+			return whereOperand{null: true}, nil
+		case token.value == "true" || token.value == "false":
+			return whereOperand{literal: token.value}, nil
+		case unicode.IsDigit([]rune(token.value)[0]) || token.value[0] == '-' || token.value[0] == '+':
+			return whereOperand{literal: token.value}, nil
+		default:
+			return whereOperand{property: strings.ToLower(token.value), literal: token.value}, nil
+		}
+	case whereTokenEnd:
+		return whereOperand{}, fmt.Errorf("unexpected end of expression at position %d", token.position)
+	default:
+		return whereOperand{}, fmt.Errorf("unexpected [%s] at position %d", token.value, token.position)
+	}
+}
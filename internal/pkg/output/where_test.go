@@ -0,0 +1,155 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/ans-group/cli/test"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestWhereRow() *OrderedFields {
+	row := NewOrderedFields()
+	row.Set("id", "i-abcdef12")
+	row.Set("name", "web-01")
+	row.Set("status", "Complete")
+	row.Set("ram_gb", "16")
+	row.Set("cpu", "2.000000")
+	row.Set("locked", "false")
+	row.Set("created_at", "2024-03-01T10:00:00+00:00")
+	row.Set("volume.size", "40")
+	row.Set("description", "")
+	row.Set("parent", "<nil>")
+
+	return row
+}
+
+func TestParseWhereExpression_Evaluate(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		want       bool
+	}{
+		{"Equal_Match", `status == "Complete"`, true},
+		{"Equal_CaseInsensitive", `status == "complete"`, true},
+		{"Equal_SingleEquals", `status=Complete`, true},
+		{"Equal_Properties", `name == name`, true},
+		{"Equal_BareWordNotProperty", `status == Failed`, false},
+		{"Equal_NoMatch", `status == "Failed"`, false},
+		{"NotEqual_Match", `status != "Failed"`, true},
+		{"Equal_Numeric", `cpu == 2`, true},
+		{"GreaterThan_Numeric", `ram_gb > 8`, true},
+		{"GreaterThan_NumericNotLexical", `ram_gb > 9`, true},
+		{"LessThanOrEqual_Numeric", `ram_gb <= 16`, true},
+		{"LessThan_NoMatch", `ram_gb < 16`, false},
+		{"Nested_Property", `volume.size >= 40`, true},
+		{"Regex_Match", `name =~ "^web-\d+$"`, true},
+		{"Regex_NoMatch", `name =~ "^db"`, false},
+		{"NotRegex_Match", `name !~ "^db"`, true},
+		{"Date_After", `created_at > "2024-01-01"`, true},
+		{"Date_Before", `created_at < "2024-01-01T00:00:00Z"`, false},
+		{"Date_Unquoted", `created_at >= 2024-03-01`, true},
+		{"Null_Empty", `description == null`, true},
+		{"Null_NilPointer", `parent == null`, true},
+		{"Null_Missing", `missing == null`, true},
+		{"NotNull_Present", `name != null`, true},
+		{"NotNull_Empty", `description != null`, false},
+		{"Null_Comparison", `missing > 1`, false},
+		{"And_Match", `status == "Complete" && ram_gb > 8`, true},
+		{"And_NoMatch", `status == "Complete" && ram_gb > 32`, false},
+		{"Or_Match", `status == "Failed" || ram_gb > 8`, true},
+		{"Not_Match", `!(status == "Failed")`, true},
+		{"Precedence_AndBeforeOr", `status == "Failed" && ram_gb > 8 || name == "web-01"`, true},
+		{"Grouping", `status == "Complete" && (ram_gb > 32 || name =~ "^web")`, true},
+		{"Truthy_False", `locked`, false},
+		{"Truthy_NotFalse", `!locked`, true},
+		{"Truthy_Boolean", `locked == false`, true},
+		{"String_EscapedQuote", `name != 'it\'s'`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expression, err := parseWhereExpression(tt.expression)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, expression.evaluate(newTestWhereRow()))
+		})
+	}
+}
+
+func TestParseWhereExpression_EvaluateSDKDateTime(t *testing.T) {
+	row := NewOrderedFields()
+	row.Set("created_at", "2024-03-01T10:00:00+0100")
+	row.Set("updated_at", "2024-03-01T09:45:00+0000")
+
+	tests := []struct {
+		name       string
+		expression string
+		want       bool
+	}{
+		{"Before_UTCLiteral", `created_at < "2024-03-01T09:30:00Z"`, true},
+		{"Equal_DifferentOffset", `created_at == "2024-03-01T09:00:00+00:00"`, true},
+		{"Properties_DifferentOffset", `created_at < updated_at`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expression, err := parseWhereExpression(tt.expression)
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, expression.evaluate(row))
+		})
+	}
+}
+
+func TestParseWhereExpression_Invalid(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		err        string
+	}{
+		{"UnterminatedString", `name == "web`, "unterminated string at position 9"},
+		{"UnexpectedCharacter", `name == web & status`, "unexpected character [&] at position 13"},
+		{"MissingOperand", `name ==`, "unexpected end of expression at position 8"},
+		{"MissingParenthesis", `(name == web`, "expected [)] at position 13"},
+		{"TrailingToken", `name == web)`, "unexpected [)] at position 12"},
+		{"RegexNotString", `name =~ web`, "expected regular expression string at position 9"},
+		{"InvalidRegex", `name =~ "[web"`, "invalid regular expression at position 9: error parsing regexp: missing closing ]: `[web`"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseWhereExpression(tt.expression)
+
+			assert.NotNil(t, err)
+			assert.Equal(t, tt.err, err.Error())
+		})
+	}
+}
+
+func TestOutputHandler_Where(t *testing.T) {
+	t.Run("ValidExpression_FiltersRows", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("output", "", "")
+		cmd.Flags().String("where", "", "")
+		cmd.ParseFlags([]string{"--output", "value", "--where", `testproperty1 == "Row1TestValue1" || testproperty2 =~ "^Row3"`})
+
+		output := test.CatchStdOut(t, func() {
+			err := NewOutputHandler().Output(cmd, collectionMultipleRows)
+			assert.NoError(t, err)
+		})
+
+		assert.Equal(t, "Row1TestValue1 Row1TestValue2 Row1TestValue3\n", output)
+	})
+
+	t.Run("InvalidExpression_ReturnsError", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("where", "", "")
+		cmd.ParseFlags([]string{"--where", `testproperty1 ==`})
+
+		err := NewOutputHandler().Output(cmd, collectionMultipleRows)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "invalid where expression: unexpected end of expression at position 17", err.Error())
+	})
+}